@@ -0,0 +1,86 @@
+// Command apikey mints, revokes and lists API keys from scripts and deploys:
+//
+//	apikey -scopes lexicon:read,generate -quota 1000 create deploy-bot
+//	apikey revoke <apiKeyID>
+//	apikey list
+//
+// create prints the key, with its secret, as JSON. The secret is only ever
+// shown here; the database stores its hash. list prints the keys as NDJSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+func main() {
+	scopes := flag.String("scopes", "", "comma separated scopes of the minted key: "+strings.Join(database.Scopes, ", "))
+	quota := flag.Int("quota", -1, "daily generation quota of the minted key (default unlimited)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] create NAME|revoke ID|list\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	want := map[string]int{"create": 2, "revoke": 2, "list": 1}
+	if n, ok := want[flag.Arg(0)]; !ok || flag.NArg() != n {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var config struct{ DB database.Config }
+	if _, err := toml.DecodeFile(os.Getenv("ALIASGEN_CONFIG"), &config); err != nil {
+		log.Fatalf("Failed to open config file: %s\n", err)
+	}
+
+	store, err := database.OpenStore(config.DB)
+	if err != nil {
+		log.Fatalf("Failed to open database: %s\n", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	enc := json.NewEncoder(os.Stdout)
+	switch flag.Arg(0) {
+	case "create":
+		var scopeList []string
+		if *scopes != "" {
+			scopeList = strings.Split(*scopes, ",")
+		}
+		var apiKey database.ApiKey
+		var secret string
+		apiKey, secret, err = store.ApiKeyCreate(ctx, strings.TrimSpace(flag.Arg(1)), scopeList)
+		if err == nil && *quota >= 0 {
+			apiKey.DailyGenerationQuota = quota
+			if err = store.ApiKeySetDailyGenerationQuota(ctx, apiKey.ApiKeyID, quota); err != nil {
+				// Don't leave an unlimited key behind.
+				store.ApiKeyRevoke(ctx, apiKey.ApiKeyID)
+			}
+		}
+		if err == nil {
+			enc.Encode(struct {
+				database.ApiKey
+				Secret string `json:"secret"`
+			}{apiKey, secret})
+		}
+	case "revoke":
+		err = store.ApiKeyRevoke(ctx, flag.Arg(1))
+	case "list":
+		var apiKeys []database.ApiKey
+		apiKeys, err = store.ApiKeyList(ctx)
+		for _, apiKey := range apiKeys {
+			enc.Encode(apiKey)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to %s api key: %s\n", flag.Arg(0), err)
+	}
+}
@@ -0,0 +1,87 @@
+// Command list prints a page of words or patterns as NDJSON, followed on
+// stderr by the cursors of the pages either side:
+//
+//	list -order part,-createdAt -filter language=en,wordPrefix=gr -limit 20 words
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+func main() {
+	order := flag.String("order", "", "sort keys, most significant first, each prefixed with - to sort descending")
+	filter := flag.String("filter", "", "comma separated key=value filters")
+	limit := flag.Int("limit", 0, "page size, up to 1000 (default 50)")
+	cursor := flag.String("cursor", "", "next or prev cursor of a previous page")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] words|patterns\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	orderBy, err := database.ParseOrderBy(*order)
+	if err != nil {
+		log.Fatalf("Invalid -order: %s\n", err)
+	}
+
+	var config struct{ DB database.Config }
+	if _, err := toml.DecodeFile(os.Getenv("ALIASGEN_CONFIG"), &config); err != nil {
+		log.Fatalf("Failed to open config file: %s\n", err)
+	}
+
+	store, err := database.OpenStore(config.DB)
+	if err != nil {
+		log.Fatalf("Failed to open database: %s\n", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	enc := json.NewEncoder(os.Stdout)
+	var page database.Page
+	switch flag.Arg(0) {
+	case "words":
+		var f database.WordFilter
+		var words []database.Word
+		if f, err = database.ParseWordFilter(*filter); err == nil {
+			words, page, err = store.WordList(ctx, database.WordListArgs{Filter: f, OrderBy: orderBy, Limit: *limit, Cursor: *cursor})
+		}
+		for _, word := range words {
+			enc.Encode(word)
+		}
+	case "patterns":
+		var f database.PatternFilter
+		var patterns []database.Pattern
+		if f, err = database.ParsePatternFilter(*filter); err == nil {
+			patterns, page, err = store.PatternList(ctx, database.PatternListArgs{Filter: f, OrderBy: orderBy, Limit: *limit, Cursor: *cursor})
+		}
+		for _, pattern := range patterns {
+			enc.Encode(pattern)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Failed listing %s: %s\n", flag.Arg(0), err)
+	}
+
+	if page.NextCursor != "" {
+		fmt.Fprintf(os.Stderr, "next: %s\n", page.NextCursor)
+	}
+	if page.PrevCursor != "" {
+		fmt.Fprintf(os.Stderr, "prev: %s\n", page.PrevCursor)
+	}
+}
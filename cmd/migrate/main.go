@@ -0,0 +1,105 @@
+// Command migrate applies, reverts and lists the database migrations:
+//
+//	migrate status
+//	migrate up [N]
+//	migrate down [N]
+//	migrate redo
+//
+// With -dry-run the SQL is printed instead of run.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print the SQL instead of running it")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] status|up [N]|down [N]|redo\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	n := 0
+	if flag.NArg() == 2 {
+		var err error
+		if n, err = strconv.Atoi(flag.Arg(1)); err != nil || n < 1 {
+			log.Fatalf("Invalid N: %s\n", flag.Arg(1))
+		}
+	}
+
+	var config struct{ DB database.Config }
+	if _, err := toml.DecodeFile(os.Getenv("ALIASGEN_CONFIG"), &config); err != nil {
+		log.Fatalf("Failed to open config file: %s\n", err)
+	}
+
+	dbal, err := database.Open(config.DB)
+	if err != nil {
+		log.Fatalf("Failed to open database: %s\n", err)
+	}
+	defer dbal.Close()
+
+	ctx := context.Background()
+	m := dbal.Migrator()
+	if *dryRun {
+		m.DryRun = os.Stdout
+	}
+
+	switch flag.Arg(0) {
+	case "status":
+		err = printStatus(ctx, m)
+	case "up":
+		err = m.Up(ctx, n)
+	case "down":
+		err = m.Down(ctx, n)
+	case "redo":
+		err = m.Redo(ctx)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Failed to %s: %s\n", flag.Arg(0), err)
+	}
+}
+
+func printStatus(ctx context.Context, m database.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		status, appliedAt := "pending", ""
+		switch {
+		case s.Unknown:
+			status = "unknown"
+		case s.Edited:
+			status = "edited"
+		case s.Applied:
+			status = "applied"
+		}
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+	}
+	return w.Flush()
+}
@@ -2,20 +2,24 @@ package main
 
 import (
 	"log"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/timaraxian/alias-gen/pkg/application"
 	"github.com/timaraxian/alias-gen/pkg/helpers/alerts"
+	"github.com/timaraxian/alias-gen/pkg/rpc"
 )
 
+func loadConfig() (config application.Config, err error) {
+	_, err = toml.DecodeFile(os.Getenv("ALIASGEN_CONFIG"), &config)
+	return config, err
+}
+
 func main() {
-	config := application.Config{}
-	if _, err := toml.DecodeFile(os.Getenv("ALIASGEN_CONFIG"), &config); err != nil {
+	config, err := loadConfig()
+	if err != nil {
 		log.Printf("Failed to open config file: %s\n", err)
 		os.Exit(1)
 	}
@@ -23,28 +27,31 @@ func main() {
 	//application.DBFreshService,
 	app, err := application.Mount(config, []application.Service{
 		application.DBService,
+		application.GeneratorService,
 	})
 	if err != nil {
 		alerts.AlertError(err, "Failed to mount application")
 		os.Exit(3)
 	}
 
-	srv := &http.Server{
-		ReadTimeout:  32 * time.Minute,
-		WriteTimeout: 32 * time.Minute,
-		Addr:         config.ListenAddr,
-		Handler:      app.Routes(),
-	}
+	srv := application.NewServer(app)
 
-	alerts.AlertError(nil, "Starting server at address %s", srv.Addr)
+	alerts.AlertError(nil, "Starting server at address %s", srv.HTTP.Addr)
 
+	serveErr := make(chan error, 1)
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
-			alerts.AlertError(err, "Server stopped")
-			os.Exit(4)
-		}
+		serveErr <- srv.ListenAndServe()
 	}()
 
+	var grpcSrv *rpc.Server
+	if addr := config.GRPCListenAddr; addr != "" {
+		grpcSrv = rpc.New(srv.App)
+		alerts.AlertError(nil, "Starting gRPC server at address %s", addr)
+		go func() {
+			serveErr <- grpcSrv.ListenAndServe(addr)
+		}()
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan,
 		os.Interrupt,
@@ -52,13 +59,43 @@ func main() {
 		syscall.SIGQUIT,
 		syscall.SIGUSR1,
 		syscall.SIGUSR2,
+		syscall.SIGHUP,
 	)
 
-	<-sigChan
+	for {
+		select {
+		case err := <-serveErr:
+			alerts.AlertError(err, "Server stopped")
+			if grpcSrv != nil {
+				grpcSrv.GRPC.Stop()
+			}
+			srv.App().Close()
+			os.Exit(4)
+
+		case sig := <-sigChan:
+			if sig == syscall.SIGHUP {
+				config, err := loadConfig()
+				if err == nil {
+					err = srv.Reload(config)
+				}
+				if err != nil {
+					alerts.AlertError(err, "Failed reloading config, keeping the current one")
+				} else {
+					alerts.AlertError(nil, "Config reloaded")
+				}
+				continue
+			}
 
-	if err := srv.Close(); err != nil {
-		alerts.AlertError(err, "Failed stopping server")
-	} else {
-		alerts.AlertError(nil, "Server closed")
+			// gRPC calls drain first, while the App is still open.
+			if grpcSrv != nil {
+				grpcSrv.Shutdown(config.Server.WithDefaults().ShutdownTimeout.Duration)
+			}
+			if err := srv.Shutdown(); err != nil {
+				alerts.AlertError(err, "Failed stopping server")
+			} else {
+				alerts.AlertError(nil, "Server closed")
+			}
+			return
+		}
 	}
 }
@@ -16,14 +16,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	dbal, err := database.Bootstrap(config.DB)
+	store, err := database.OpenStore(config.DB)
 	if err != nil{
 		panic(err)
 	}
 
 	app, err := tui.NewApp(config, []tui.Service{
 		func(a *tui.App) (err error) {
-			a.DBAL = dbal
+			a.Store = store
+			a.Keys = store
 			return nil
 		},
 	})
@@ -5,7 +5,12 @@ go 1.13
 require (
 	github.com/BurntSushi/toml v0.3.1
 	github.com/gdamore/tcell v1.3.0
+	github.com/golang/protobuf v1.4.1
 	github.com/lib/pq v1.8.0
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/pkg/errors v0.9.1
 	github.com/rivo/tview v0.0.0-20200915114512-42866ecf6ca6
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc v1.32.0
+	google.golang.org/protobuf v1.25.0
 )
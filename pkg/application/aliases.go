@@ -0,0 +1,343 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+)
+
+const maxAliasGenerateCount = 100
+
+type AliasGenerateArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+	Count    int    `json:"count"`
+
+	// IncludeTags and ExcludeTags narrow the words of every slot: a word
+	// needs one of IncludeTags and none of ExcludeTags. Without IncludeTags
+	// a pattern's theme applies.
+	IncludeTags []string `json:"includeTags"`
+	ExcludeTags []string `json:"excludeTags"`
+
+	// Slots narrows the words of the slots at those positions, counting
+	// from 0 up to 31. A slot's includeTags replace the alias's, and its
+	// excludeTags add to them.
+	Slots map[int]database.TagFilter `json:"slots"`
+
+	// Theme replaces the theme of every pattern drawn.
+	Theme string `json:"theme"`
+}
+
+type AliasGenerateReply struct {
+	Aliases []generator.Alias `json:"aliases"`
+
+	// QuotaRemaining is how many more aliases the API key may generate
+	// today, or null when its quota is unlimited.
+	QuotaRemaining *int `json:"quotaRemaining"`
+}
+
+func (app *App) AliasGenerate(w http.ResponseWriter, r *http.Request) {
+	args := AliasGenerateArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if args.Count == 0 {
+		args.Count = 1
+	}
+	if args.Count < 0 || args.Count > maxAliasGenerateCount {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(errors.FieldError{
+			Field: "count",
+			Code:  "OutOfRange",
+			Msg:   "must be between 1 and " + strconv.Itoa(maxAliasGenerateCount),
+		}))
+		return
+	}
+
+	opts, err := GenerateOptions(args.IncludeTags, args.ExcludeTags, args.Slots, args.Theme)
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	apiKey, _ := apiKeyFromContext(r.Context())
+	now := time.Now()
+	remaining, err := app.Keys.GenerationQuotaConsume(r.Context(), apiKey.ApiKeyID, args.Count, now)
+	if errors.QuotaExceeded.Equals(err) {
+		w.Header().Set("Retry-After", retryAfterSeconds(untilNextUTCDay(now)))
+	}
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	reply := AliasGenerateReply{QuotaRemaining: remaining}
+	for i := 0; i < args.Count; i++ {
+		alias, err := app.Generator.Generate(r.Context(), args.Language, opts)
+		if err != nil {
+			app.GenerationQuotaRefund(apiKey.ApiKeyID, args.Count, now)
+			app.respondApi(w, r, nil, err)
+			return
+		}
+		reply.Aliases = append(reply.Aliases, alias)
+	}
+
+	app.respondApi(w, r, reply, nil)
+}
+
+const (
+	maxAliasStreamCount = 100000
+
+	mimeEventStream = "text/event-stream"
+)
+
+type AliasStreamArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+
+	// IncludeTags, ExcludeTags, Slots and Theme narrow the words aliases are
+	// built from, as for aliasGenerate.
+	IncludeTags []string                   `json:"includeTags"`
+	ExcludeTags []string                   `json:"excludeTags"`
+	Slots       map[int]database.TagFilter `json:"slots"`
+	Theme       string                     `json:"theme"`
+
+	// Count is how many aliases to send. Zero streams until the space of
+	// aliases is exhausted or the client disconnects.
+	Count int `json:"count"`
+}
+
+// AliasStreamEvent is a line of an NDJSON alias stream. Every event but the
+// last carries an alias; the last carries End.
+type AliasStreamEvent struct {
+	Alias *generator.Alias `json:"alias,omitempty"`
+	End   *AliasStreamEnd  `json:"end,omitempty"`
+}
+
+// AliasStreamEnd says why a stream ended: count, exhausted, quota or error.
+type AliasStreamEnd struct {
+	Reason string    `json:"reason"`
+	Count  int       `json:"count"`
+	Error  *apiError `json:"error,omitempty"`
+}
+
+// AliasStream writes unique aliases as they're generated, as NDJSON, or as
+// Server-Sent Events when the client accepts text/event-stream. SSE sends
+// "alias" events with the alias as data, then an "end" event.
+func (app *App) AliasStream(w http.ResponseWriter, r *http.Request) {
+	args := AliasStreamArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if args.Count == 0 {
+		args.Count = maxAliasStreamCount
+	}
+	if args.Count < 0 || args.Count > maxAliasStreamCount {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(errors.FieldError{
+			Field: "count",
+			Code:  "OutOfRange",
+			Msg:   "must be between 0 and " + strconv.Itoa(maxAliasStreamCount),
+		}))
+		return
+	}
+
+	opts, err := GenerateOptions(args.IncludeTags, args.ExcludeTags, args.Slots, args.Theme)
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		app.serverErr(w, r, fmt.Errorf("response writer can't flush"))
+		return
+	}
+
+	sse := strings.Contains(r.Header.Get("Accept"), mimeEventStream)
+	write := func(event AliasStreamEvent) error {
+		var b []byte
+		var err error
+		if event.End != nil {
+			b, err = json.Marshal(event.End)
+		} else if sse {
+			b, err = json.Marshal(event.Alias)
+		} else {
+			b, err = json.Marshal(event)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case sse && event.End != nil:
+			_, err = fmt.Fprintf(w, "event: end\ndata: %s\n\n", b)
+		case sse:
+			_, err = fmt.Fprintf(w, "event: alias\ndata: %s\n\n", b)
+		case event.End != nil:
+			_, err = fmt.Fprintf(w, "{\"end\":%s}\n", b)
+		default:
+			_, err = fmt.Fprintf(w, "%s\n", b)
+		}
+		flusher.Flush()
+		return err
+	}
+
+	apiKey, _ := apiKeyFromContext(r.Context())
+	unique := app.Generator.Unique(args.Language, opts)
+	ctx := r.Context()
+
+	quota := app.ReserveQuota(apiKey.ApiKeyID, args.Count)
+	defer quota.Release()
+
+	sent := 0
+	for sent < args.Count {
+		if ctx.Err() != nil {
+			return
+		}
+
+		var alias generator.Alias
+		if alias, err = unique.Next(r.Context()); err != nil {
+			break
+		}
+		if err = quota.Take(ctx); err != nil {
+			break
+		}
+
+		if sent == 0 {
+			app.startAliasStream(w, sse)
+		}
+		if err := write(AliasStreamEvent{Alias: &alias}); err != nil {
+			return
+		}
+		sent++
+	}
+
+	// Nothing has been sent yet, so the error can be a normal reply.
+	if err != nil && sent == 0 {
+		if errors.QuotaExceeded.Equals(err) {
+			w.Header().Set("Retry-After", retryAfterSeconds(untilNextUTCDay(time.Now())))
+		}
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	end := &AliasStreamEnd{Reason: "count", Count: sent}
+	switch {
+	case err == nil:
+	case errors.GenerateExhausted.Equals(err):
+		end.Reason = "exhausted"
+	case errors.QuotaExceeded.Equals(err):
+		end.Reason = "quota"
+	default:
+		end.Reason = "error"
+		end.Error = &apiError{Code: errors.Unexpected.Code(), Message: "Unexpected server error"}
+		if appErr, ok := err.(codedErr); ok && lookupErrInfo(appErr.Code()).status < 500 {
+			end.Error = &apiError{Code: appErr.Code(), Message: lookupErrInfo(appErr.Code()).message}
+		} else {
+			app.Logger.Log(logger.Error, err.Error(), logger.Fields{
+				"requestID": requestIDFromContext(ctx),
+				"path":      r.URL.Path,
+			})
+		}
+	}
+	write(AliasStreamEvent{End: end})
+}
+
+const (
+	maxGenerateTags  = 20
+	maxGenerateSlots = 32
+)
+
+// validateTagField reports field as invalid unless tag is a valid tag.
+func validateTagField(field, tag string) []errors.FieldError {
+	err := database.ValidateTag(tag)
+	if e, ok := err.(errors.Error); ok {
+		return []errors.FieldError{{Field: field, Code: "Invalid", Msg: e.Msg()}}
+	}
+	return nil
+}
+
+// GenerateOptions checks the tags, slots and theme of a generation request.
+// The JSON API and gRPC share it.
+func GenerateOptions(includeTags, excludeTags []string, slots map[int]database.TagFilter, theme string) (opts generator.Options, err error) {
+	var fieldErrs []errors.FieldError
+	checkTags := func(field string, tags []string) {
+		if len(tags) > maxGenerateTags {
+			fieldErrs = append(fieldErrs, errors.FieldError{
+				Field: field,
+				Code:  "TooMany",
+				Msg:   "must have at most " + strconv.Itoa(maxGenerateTags) + " tags",
+			})
+			return
+		}
+		for _, tag := range tags {
+			if errs := validateTagField(field, tag); len(errs) > 0 {
+				fieldErrs = append(fieldErrs, errs...)
+				return
+			}
+		}
+	}
+
+	checkTags("includeTags", includeTags)
+	checkTags("excludeTags", excludeTags)
+	for position, slot := range slots {
+		field := "slots." + strconv.Itoa(position)
+		if position < 0 || position >= maxGenerateSlots {
+			fieldErrs = append(fieldErrs, errors.FieldError{
+				Field: field,
+				Code:  "OutOfRange",
+				Msg:   "must be between 0 and " + strconv.Itoa(maxGenerateSlots-1),
+			})
+			continue
+		}
+		checkTags(field+".includeTags", slot.IncludeTags)
+		checkTags(field+".excludeTags", slot.ExcludeTags)
+	}
+	if theme != "" {
+		fieldErrs = append(fieldErrs, validateTagField("theme", theme)...)
+	}
+	if len(fieldErrs) > 0 {
+		sort.Slice(fieldErrs, func(i, j int) bool { return fieldErrs[i].Field < fieldErrs[j].Field })
+		return opts, errors.HttpInvalidArgs.WithFields(fieldErrs...)
+	}
+
+	return generator.Options{
+		Tags:  database.TagFilter{IncludeTags: includeTags, ExcludeTags: excludeTags},
+		Slots: slots,
+		Theme: theme,
+	}, nil
+}
+
+func (app *App) startAliasStream(w http.ResponseWriter, sse bool) {
+	if sse {
+		w.Header().Set("Content-Type", mimeEventStream+"; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", mimeNDJSON+"; charset=utf-8")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+}
+
+func untilNextUTCDay(now time.Time) time.Duration {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).Sub(now)
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	secs := int64((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return strconv.FormatInt(secs, 10)
+}
@@ -0,0 +1,270 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+)
+
+// -----------------------------------------------------------------------------
+// App.AliasStream
+// -----------------------------------------------------------------------------
+func TestApp_AliasStream_CountOutOfRange(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	for _, body := range []string{
+		`{"language":"en","count":-1}`,
+		`{"language":"en","count":100001}`,
+	} {
+		r := httptest.NewRequest("POST", "/aliasStream", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.AliasStream(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatal(body, w.Code)
+		}
+	}
+}
+
+// newMemoryTestApp serves from a memory store holding four English words and
+// a pattern, and returns a request context authenticated as a generate key
+// with quota.
+func newMemoryTestApp(t *testing.T, quota int) (app *App, ctx context.Context, apiKey database.ApiKey) {
+	app, _ = newTestApp(t, Config{})
+	store := database.NewMemoryStore()
+	app.Store, app.Keys, app.Generator = store, store, generator.New(store)
+
+	ctx = context.Background()
+	if _, err := store.LanguageCreate(ctx, database.Language{Code: "en", Name: "English", Separator: " "}); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{"adjective", "noun"} {
+		if _, err := store.PartCreate(ctx, database.Part{Language: "en", Part: part}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for word, part := range map[string]string{"Grand": "adjective", "Little": "adjective", "Hotel": "noun", "Bridge": "noun"} {
+		if _, err := store.WordCreate(ctx, word, "en", part); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.PatternCreate(ctx, "adjective,noun", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	apiKey, _, err := store.ApiKeyCreate(ctx, "streamer", []string{database.ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ApiKeySetDailyGenerationQuota(ctx, apiKey.ApiKeyID, &quota); err != nil {
+		t.Fatal(err)
+	}
+	return app, context.WithValue(ctx, apiKeyCtxKey, apiKey), apiKey
+}
+
+func streamRequest(ctx context.Context, body, accept string) *http.Request {
+	r := httptest.NewRequest("POST", "/aliasStream", strings.NewReader(body)).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/json")
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	return r
+}
+
+func TestApp_AliasStream_NDJSON(t *testing.T) {
+	t.Parallel()
+	app, ctx, apiKey := newMemoryTestApp(t, 10)
+
+	w := httptest.NewRecorder()
+	app.AliasStream(w, streamRequest(ctx, `{"language":"en"}`, ""))
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), mimeNDJSON) || !w.Flushed {
+		t.Fatal(w.Header(), w.Flushed)
+	}
+
+	// Every line is an alias event but the last, which ends the stream.
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatal(w.Body.String())
+	}
+	seen := map[string]bool{}
+	for _, line := range lines[:4] {
+		var event AliasStreamEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Alias == nil || event.End != nil {
+			t.Fatal(line, err)
+		}
+		seen[event.Alias.Alias] = true
+	}
+	if len(seen) != 4 {
+		t.Fatal(seen)
+	}
+
+	var end AliasStreamEvent
+	if err := json.Unmarshal([]byte(lines[4]), &end); err != nil || end.End == nil {
+		t.Fatal(lines[4], err)
+	}
+	if end.End.Reason != "exhausted" || end.End.Count != 4 {
+		t.Fatal(end.End)
+	}
+
+	// Only the aliases sent count against the quota.
+	if used, err := app.Keys.GenerationUsage(ctx, apiKey.ApiKeyID, time.Now()); err != nil || used != 4 {
+		t.Fatal(used, err)
+	}
+}
+
+func TestApp_AliasStream_SSE(t *testing.T) {
+	t.Parallel()
+	app, ctx, _ := newMemoryTestApp(t, 10)
+
+	w := httptest.NewRecorder()
+	app.AliasStream(w, streamRequest(ctx, `{"language":"en","count":2}`, "text/event-stream"))
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), mimeEventStream) || !w.Flushed {
+		t.Fatal(w.Header(), w.Flushed)
+	}
+
+	events := strings.Split(strings.TrimSuffix(w.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 3 {
+		t.Fatal(w.Body.String())
+	}
+	for _, event := range events[:2] {
+		if !strings.HasPrefix(event, "event: alias\ndata: {\"alias\":") {
+			t.Fatal(event)
+		}
+	}
+	if events[2] != `event: end`+"\n"+`data: {"reason":"count","count":2}` {
+		t.Fatal(events[2])
+	}
+}
+
+func TestApp_AliasStream_Quota(t *testing.T) {
+	t.Parallel()
+	app, ctx, apiKey := newMemoryTestApp(t, 3)
+
+	w := httptest.NewRecorder()
+	app.AliasStream(w, streamRequest(ctx, `{"language":"en"}`, ""))
+
+	lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[3], `"reason":"quota","count":3`) {
+		t.Fatal(w.Body.String())
+	}
+	if used, err := app.Keys.GenerationUsage(ctx, apiKey.ApiKeyID, time.Now()); err != nil || used != 3 {
+		t.Fatal(used, err)
+	}
+}
+
+// cancelRecorder cancels the request once the first alias is flushed, as a
+// client hanging up would.
+type cancelRecorder struct {
+	*httptest.ResponseRecorder
+	cancel context.CancelFunc
+}
+
+func (rec cancelRecorder) Flush() {
+	rec.ResponseRecorder.Flush()
+	rec.cancel()
+}
+
+func TestApp_AliasStream_ClientCancel(t *testing.T) {
+	t.Parallel()
+	app, ctx, apiKey := newMemoryTestApp(t, 10)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := cancelRecorder{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+	app.AliasStream(w, streamRequest(ctx, `{"language":"en"}`, ""))
+
+	// The stream stops without an end event, and the rest of the batch is
+	// refunded.
+	if lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n"); len(lines) != 1 || strings.Contains(lines[0], `"end"`) {
+		t.Fatal(w.Body.String())
+	}
+	if used, err := app.Keys.GenerationUsage(context.Background(), apiKey.ApiKeyID, time.Now()); err != nil || used != 1 {
+		t.Fatal(used, err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.AliasGenerate
+// -----------------------------------------------------------------------------
+func TestApp_AliasGenerate_InvalidTags(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	for _, body := range []string{
+		`{"language":"en","includeTags":["Space!"]}`,
+		`{"language":"en","slots":{"1":{"excludeTags":["no tags"]}}}`,
+		`{"language":"en","slots":{"-1":{"includeTags":["space"]}}}`,
+		`{"language":"en","slots":{"32":{"includeTags":["space"]}}}`,
+		`{"language":"en","includeTags":[""]}`,
+		`{"language":"en","excludeTags":["` + strings.Repeat("a", 33) + `"]}`,
+		`{"language":"en","theme":"Fantasy"}`,
+	} {
+		r := httptest.NewRequest("POST", "/aliasGenerate", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.AliasGenerate(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatal(body, w.Code)
+		}
+	}
+}
+
+func TestApp_AliasGenerate_RefundsQuota(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	store := database.NewMemoryStore()
+	app.Store, app.Keys, app.Generator = store, store, generator.New(store)
+
+	ctx := context.Background()
+	apiKey, secret, err := store.ApiKeyCreate(ctx, "generator", []string{database.ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Generation in an unknown language fails after the quota was consumed.
+	r := httptest.NewRequest("POST", "/aliasGenerate", strings.NewReader(`{"language":"en","count":5}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+secret)
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatal(w.Code, w.Body.String())
+	}
+	used, err := store.GenerationUsage(ctx, apiKey.ApiKeyID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 0 {
+		t.Fatal(used)
+	}
+}
+
+func TestApp_AliasGenerate_LanguageArchived(t *testing.T) {
+	t.Parallel()
+	app, ctx, _ := newMemoryTestApp(t, 10)
+	if err := app.Store.LanguageSetArchive(ctx, "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("POST", "/aliasGenerate", strings.NewReader(`{"language":"en"}`)).WithContext(ctx)
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	app.AliasGenerate(w, r)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "LanguageArchived") {
+		t.Fatal(w.Code, w.Body.String())
+	}
+}
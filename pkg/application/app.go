@@ -3,22 +3,42 @@ package application
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"mime"
 	"net/http"
+	"os"
 	"runtime/debug"
+	"time"
 
 	"github.com/timaraxian/alias-gen/pkg/database"
 	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
 )
 
 type App struct {
-	Config Config
-	DBAL   *database.DBAL
+	Config    Config
+	Store     database.Store
+	Keys      database.KeyStore
+	Generator *generator.Generator
+	Lexicon   *generator.Lexicon
+	Logger    *logger.Logger
+
+	// done is closed by Close to stop background work.
+	done chan struct{}
 }
 
 type apiResponse struct {
-	OK   bool        `json:"ok"`
-	Data interface{} `json:"data"`
+	OK    bool        `json:"ok"`
+	Data  interface{} `json:"data,omitempty"`
+	Error *apiError   `json:"error,omitempty"`
+}
+
+type apiError struct {
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Fields  []errors.FieldError `json:"fields,omitempty"`
 }
 
 type Service func(app *App) (err error)
@@ -29,10 +49,120 @@ type Config struct {
 	DB database.Config
 
 	ListenAddr string
+
+	// GRPCListenAddr serves the gRPC API when set.
+	GRPCListenAddr string
+
+	Server ServerConfig
+
+	// MaxBodyBytes caps the size of decoded API request bodies.
+	MaxBodyBytes int64
+
+	// MaxImportBytes caps the size of /import request bodies.
+	MaxImportBytes int64
+
+	RateLimit RateLimitConfig
+
+	CORS CORSConfig
+
+	Log LogConfig
+}
+
+// ServerConfig sets the HTTP server timeouts. Durations are strings such as
+// "30s" or "2m"; zero values use the defaults.
+type ServerConfig struct {
+	ReadTimeout       Duration
+	ReadHeaderTimeout Duration
+	WriteTimeout      Duration
+	IdleTimeout       Duration
+
+	// ShutdownTimeout is how long in-flight requests get to finish once the
+	// server is asked to stop.
+	ShutdownTimeout Duration
+}
+
+const (
+	defaultReadTimeout       = 30 * time.Second
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultWriteTimeout      = 60 * time.Second
+	defaultIdleTimeout       = 2 * time.Minute
+	defaultShutdownTimeout   = 30 * time.Second
+)
+
+// WithDefaults fills unset timeouts with their defaults.
+func (c ServerConfig) WithDefaults() ServerConfig {
+	for _, d := range []struct {
+		field *Duration
+		value time.Duration
+	}{
+		{&c.ReadTimeout, defaultReadTimeout},
+		{&c.ReadHeaderTimeout, defaultReadHeaderTimeout},
+		{&c.WriteTimeout, defaultWriteTimeout},
+		{&c.IdleTimeout, defaultIdleTimeout},
+		{&c.ShutdownTimeout, defaultShutdownTimeout},
+	} {
+		if d.field.Duration <= 0 {
+			d.field.Duration = d.value
+		}
+	}
+	return c
+}
+
+// Duration is a time.Duration decoded from a string in the config file.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = time.ParseDuration(string(text))
+	return err
+}
+
+type LogConfig struct {
+	// Level is the minimum level logged: debug, info, warn or error.
+	Level string
+}
+
+// RateLimitConfig sets the token bucket applied to each client, keyed by API
+// key or remote address. Routes overrides the default per route path. A zero
+// Rate disables limiting.
+type RateLimitConfig struct {
+	Rate   float64
+	Burst  int
+	Routes map[string]RateLimit
+
+	// Addr is a bucket per remote address shared by all routes, checked
+	// before the API key is authenticated.
+	Addr RateLimit
+}
+
+type RateLimit struct {
+	Rate  float64
+	Burst int
 }
 
+// ForRoute is the limit of the route at path. gRPC methods use the path of
+// the matching route, e.g. /aliasGenerate for AliasGenerate.
+func (c RateLimitConfig) ForRoute(path string) RateLimit {
+	if limit, ok := c.Routes[path]; ok {
+		return limit
+	}
+	return RateLimit{Rate: c.Rate, Burst: c.Burst}
+}
+
+const (
+	defaultMaxBodyBytes   = 1 << 20
+	defaultMaxImportBytes = 32 << 20
+)
+
 func Mount(config Config, services []Service) (a *App, err error) {
-	a = &App{Config: config}
+	a = &App{Config: config, done: make(chan struct{})}
+
+	level, err := logger.ParseLevel(config.Log.Level)
+	if err != nil {
+		return a, err
+	}
+	a.Logger = logger.New(os.Stderr, level)
 
 	for _, s := range services {
 		if err = s(a); err != nil {
@@ -43,27 +173,97 @@ func Mount(config Config, services []Service) (a *App, err error) {
 	return a, err
 }
 
+// Close stops background work and closes the database connections. Call it
+// once the server has drained.
+func (app *App) Close() error {
+	select {
+	case <-app.done:
+		return nil
+	default:
+		close(app.done)
+	}
+
+	if app.Store != nil {
+		return app.Store.Close()
+	}
+	return nil
+}
+
+// decodeRequest decodes the JSON body of r into args, which must be a pointer
+// to an args struct, and validates it against the struct's `validate` tags.
 func (app *App) decodeRequest(r *http.Request, args interface{}) error {
-	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
-		return errors.HttpBadRequestArgs
+	maxBytes := app.Config.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBodyBytes
+	}
+
+	body := &io.LimitedReader{R: r.Body, N: maxBytes + 1}
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(args)
+	if err == nil && dec.More() {
+		err = fmt.Errorf("unexpected data after request body")
+	}
+	if body.N <= 0 {
+		return errors.HttpBodyTooLarge
+	}
+	if err != nil {
+		return errors.HttpBadRequestArgs.WithErr(err)
 	}
+
+	if fieldErrs := validators.Struct(args); len(fieldErrs) > 0 {
+		return errors.HttpInvalidArgs.WithFields(fieldErrs...)
+	}
+
 	return nil
 }
 
 func (app *App) respondApi(w http.ResponseWriter, r *http.Request, data interface{}, err error) {
 	if err != nil {
-		if appErr, ok := err.(errors.Error); !ok {
-			app.serverErr(w, r, err)
-		} else {
-			data = appErr.Code()
-		}
+		app.respondApiErr(w, r, err)
+		return
 	}
 
-	if resp, err := json.Marshal(apiResponse{err == nil, data}); err != nil {
+	app.writeApi(w, r, http.StatusOK, apiResponse{OK: true, Data: data})
+}
+
+func (app *App) respondApiErr(w http.ResponseWriter, r *http.Request, err error) {
+	appErr, ok := err.(codedErr)
+	if !ok {
 		app.serverErr(w, r, err)
-	} else {
-		w.Write(resp)
+		return
+	}
+
+	info := lookupErrInfo(appErr.Code())
+	if info.status == http.StatusInternalServerError {
+		app.serverErr(w, r, err)
+		return
+	}
+
+	body := &apiError{Code: appErr.Code(), Message: info.message}
+	if msg := appErr.Msg(); msg != "" {
+		body.Message = msg
+	}
+	if fieldErr, ok := err.(errors.ValidationError); ok {
+		body.Fields = fieldErr.Fields()
+	}
+
+	app.writeApi(w, r, info.status, apiResponse{OK: false, Error: body})
+}
+
+func (app *App) writeApi(w http.ResponseWriter, r *http.Request, status int, resp apiResponse) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		app.serverErr(w, r, err)
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	}
+	w.WriteHeader(status)
+	w.Write(b)
 }
 
 /*
@@ -75,12 +275,19 @@ func (app *App) executeView(w http.ResponseWriter, r *http.Request, path string,
 */
 
 func (app *App) serverErr(w http.ResponseWriter, r *http.Request, err error) {
-	log.Output(2, fmt.Sprintf("ERROR: %s\n%s", err.Error(), debug.Stack()))
+	app.Logger.Log(logger.Error, err.Error(), logger.Fields{
+		"requestID": requestIDFromContext(r.Context()),
+		"method":    r.Method,
+		"path":      r.URL.Path,
+		"stack":     string(debug.Stack()),
+	})
 
-	if r.Header.Get("Content-Type") == "application/json" {
-		w.Write([]byte(`{"ok": false, "data": "Unexpected"}`))
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"ok":false,"error":{"code":"Unexpected","message":"Unexpected server error"}}`))
 	} else {
-		w.WriteHeader(500)
+		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte("Unexpected"))
 	}
 }
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig lists the browser origins allowed to call the API. With no
+// AllowedOrigins, cross-origin calls are refused.
+type CORSConfig struct {
+	// AllowedOrigins are exact origins such as "https://app.example.com", or
+	// wildcard subdomains such as "https://*.example.com", which match any
+	// subdomain but not example.com itself.
+	AllowedOrigins []string
+
+	// AllowedMethods defaults to GET and POST. AllowedHeaders defaults to
+	// Authorization and Content-Type.
+	AllowedMethods []string
+	AllowedHeaders []string
+
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge Duration
+}
+
+var (
+	defaultCorsMethods = []string{"GET", "POST"}
+	defaultCorsHeaders = []string{"Authorization", "Content-Type", "X-Request-ID"}
+)
+
+const defaultCorsMaxAge = time.Hour
+
+type corsPolicy struct {
+	exact     map[string]bool
+	wildcards []corsWildcard
+
+	allowedMethods map[string]bool
+
+	// Preformatted response header values.
+	methods string
+	headers string
+	maxAge  string
+}
+
+// corsWildcard matches origins of the form prefix + subdomains + suffix, e.g.
+// "https://" + "a.b" + ".example.com".
+type corsWildcard struct {
+	prefix string
+	suffix string
+}
+
+func newCorsPolicy(config CORSConfig) (p corsPolicy) {
+	p.exact = map[string]bool{}
+	for _, origin := range config.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		if i := strings.Index(origin, "://*."); i >= 0 {
+			p.wildcards = append(p.wildcards, corsWildcard{
+				prefix: origin[:i+3],
+				suffix: origin[i+4:],
+			})
+			continue
+		}
+		p.exact[origin] = true
+	}
+
+	configured := config.AllowedMethods
+	if len(configured) == 0 {
+		configured = defaultCorsMethods
+	}
+	var methods []string
+	p.allowedMethods = map[string]bool{}
+	for _, m := range configured {
+		m = strings.ToUpper(m)
+		methods = append(methods, m)
+		p.allowedMethods[m] = true
+	}
+	p.methods = strings.Join(methods, ", ")
+
+	headers := config.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCorsHeaders
+	}
+	p.headers = strings.Join(headers, ", ")
+
+	maxAge := config.MaxAge.Duration
+	if maxAge <= 0 {
+		maxAge = defaultCorsMaxAge
+	}
+	p.maxAge = strconv.Itoa(int(maxAge.Seconds()))
+
+	return p
+}
+
+func (p corsPolicy) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	if p.exact[origin] {
+		return true
+	}
+
+	for _, w := range p.wildcards {
+		if len(origin) <= len(w.prefix)+len(w.suffix) ||
+			!strings.HasPrefix(origin, w.prefix) ||
+			!strings.HasSuffix(origin, w.suffix) {
+			continue
+		}
+		sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+		if !strings.ContainsAny(sub, "/:@") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p corsPolicy) allowsMethod(method string) bool {
+	return p.allowedMethods[strings.ToUpper(method)]
+}
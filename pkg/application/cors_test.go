@@ -0,0 +1,149 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCorsTestApp(t *testing.T) http.Handler {
+	app, _ := newTestApp(t, Config{CORS: CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"},
+		MaxAge:         Duration{10 * time.Minute},
+	}})
+	return app.Routes()
+}
+
+func preflight(origin, method string) *http.Request {
+	path := "/wordCreate"
+	if method == "GET" {
+		path = "/export"
+	}
+	r := httptest.NewRequest("OPTIONS", path, nil)
+	r.Header.Set("Origin", origin)
+	r.Header.Set("Access-Control-Request-Method", method)
+	r.Header.Set("Access-Control-Request-Headers", "authorization, content-type")
+	return r
+}
+
+// -----------------------------------------------------------------------------
+// App.corsMdl
+// -----------------------------------------------------------------------------
+func TestApp_CorsMdl_Preflight(t *testing.T) {
+	t.Parallel()
+	handler := newCorsTestApp(t)
+
+	for _, origin := range []string{
+		"https://app.example.com",
+		"https://a.example.org",
+		"https://a.b.example.org",
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, preflight(origin, "POST"))
+
+		if w.Code != http.StatusNoContent {
+			t.Fatal(origin, w.Code)
+		}
+		if w.Header().Get("Access-Control-Allow-Origin") != origin {
+			t.Fatal(origin, w.Header())
+		}
+		if w.Header().Get("Access-Control-Allow-Methods") != "GET, POST" {
+			t.Fatal(w.Header())
+		}
+		if w.Header().Get("Access-Control-Allow-Headers") == "" {
+			t.Fatal(w.Header())
+		}
+		if w.Header().Get("Access-Control-Max-Age") != "600" {
+			t.Fatal(w.Header())
+		}
+		if w.Header().Get("Access-Control-Allow-Credentials") != "" {
+			t.Fatal(w.Header())
+		}
+	}
+}
+
+func TestApp_CorsMdl_PreflightRefused(t *testing.T) {
+	t.Parallel()
+	handler := newCorsTestApp(t)
+
+	for _, r := range []*http.Request{
+		preflight("https://evil.com", "POST"),
+		preflight("https://example.org", "POST"),
+		preflight("https://app.example.com.evil.com", "POST"),
+		preflight("http://app.example.com", "POST"),
+		preflight("https://app.example.com", "DELETE"),
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != http.StatusNoContent {
+			t.Fatal(w.Code)
+		}
+		for key := range w.Header() {
+			if strings.HasPrefix(key, "Access-Control-") {
+				t.Fatal(r.Header.Get("Origin"), key)
+			}
+		}
+	}
+}
+
+func TestApp_CorsMdl_Simple(t *testing.T) {
+	t.Parallel()
+	handler := newCorsTestApp(t)
+
+	r := httptest.NewRequest("POST", "/wordCreate", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Fatal(w.Header())
+	}
+	if w.Header().Get("Vary") != "Origin" {
+		t.Fatal(w.Header())
+	}
+}
+
+func TestApp_CorsMdl_SimpleRefused(t *testing.T) {
+	t.Parallel()
+	handler := newCorsTestApp(t)
+
+	r := httptest.NewRequest("POST", "/wordCreate", nil)
+	r.Header.Set("Origin", "https://evil.com")
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatal(w.Header())
+	}
+}
+
+func TestApp_CorsMdl_Get(t *testing.T) {
+	t.Parallel()
+	handler := newCorsTestApp(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, preflight("https://app.example.com", "GET"))
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Fatal(w.Code, w.Header())
+	}
+
+	// Authenticated GET routes carry the policy; public ones don't.
+	for path, want := range map[string]string{
+		"/export?language=en": "https://app.example.com",
+		"/openapi.json":       "",
+	} {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Header().Get("Access-Control-Allow-Origin") != want {
+			t.Fatal(path, w.Header())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+type codedErr interface {
+	Code() string
+	Msg() string
+}
+
+type errInfo struct {
+	status  int
+	message string
+}
+
+// errInfos maps an errors.Error code to the HTTP status and human readable
+// message returned to API clients. Codes that aren't listed are treated as
+// unexpected server errors.
+var errInfos = map[string]errInfo{}
+
+func registerErr(e errors.Error, status int, message string) {
+	errInfos[e.Code()] = errInfo{status: status, message: message}
+}
+
+func init() {
+	registerErr(errors.Unexpected, http.StatusInternalServerError, "Unexpected server error")
+
+	registerErr(errors.HttpBadRequestArgs, http.StatusBadRequest, "Request arguments could not be decoded")
+	registerErr(errors.HttpBadMethod, http.StatusMethodNotAllowed, "Method not allowed")
+	registerErr(errors.HttpBadContentType, http.StatusUnsupportedMediaType, "Unsupported content type")
+	registerErr(errors.HttpBodyTooLarge, http.StatusRequestEntityTooLarge, "Request body too large")
+	registerErr(errors.HttpInvalidArgs, http.StatusBadRequest, "Request arguments failed validation")
+
+	registerErr(errors.WordDuplicate, http.StatusConflict, "Word already exists")
+	registerErr(errors.WordNotFound, http.StatusNotFound, "Word not found")
+
+	registerErr(errors.PatternDuplicate, http.StatusConflict, "Pattern already exists")
+	registerErr(errors.PatternNotFound, http.StatusNotFound, "Pattern not found")
+
+	registerErr(errors.LanguageDuplicate, http.StatusConflict, "Language already exists")
+	registerErr(errors.LanguageNotFound, http.StatusNotFound, "Language not found")
+	registerErr(errors.LanguageInvalid, http.StatusBadRequest, "Invalid language")
+	registerErr(errors.LanguageArchived, http.StatusConflict, "Language is archived")
+
+	registerErr(errors.PartDuplicate, http.StatusConflict, "Part already exists")
+	registerErr(errors.PartNotFound, http.StatusNotFound, "Part not found")
+	registerErr(errors.PartInvalid, http.StatusBadRequest, "Invalid part")
+
+	registerErr(errors.TagInvalid, http.StatusBadRequest, "Invalid tag")
+
+	registerErr(errors.ApiKeyNotFound, http.StatusNotFound, "API key not found")
+	registerErr(errors.ApiKeyInvalidScope, http.StatusBadRequest, "Unknown API key scope")
+
+	registerErr(errors.AuthRequired, http.StatusUnauthorized, "Authentication required")
+	registerErr(errors.AuthInvalid, http.StatusUnauthorized, "Invalid or revoked API key")
+	registerErr(errors.AuthForbidden, http.StatusForbidden, "API key lacks the required scope")
+
+	registerErr(errors.RateLimited, http.StatusTooManyRequests, "Too many requests")
+	registerErr(errors.QuotaExceeded, http.StatusTooManyRequests, "Daily generation quota exceeded")
+	registerErr(errors.GenerateFailed, http.StatusUnprocessableEntity, "No alias could be generated from the lexicon")
+	registerErr(errors.GenerateExhausted, http.StatusUnprocessableEntity, "No new alias could be generated from the lexicon")
+
+	registerErr(errors.InvalidUUID, http.StatusBadRequest, "Invalid UUID")
+	registerErr(errors.InvalidCursor, http.StatusBadRequest, "Invalid or stale page cursor")
+	registerErr(errors.InvalidOrderBy, http.StatusBadRequest, "Unknown or repeated sort key")
+	registerErr(errors.InvalidFilter, http.StatusBadRequest, "Invalid list filter")
+
+	registerErr(errors.NotSupported, http.StatusNotImplemented, "Not supported by the configured store")
+}
+
+func lookupErrInfo(code string) errInfo {
+	if info, ok := errInfos[code]; ok {
+		return info
+	}
+	return errInfos[errors.Unexpected.Code()]
+}
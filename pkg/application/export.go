@@ -0,0 +1,93 @@
+package application
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+type ExportArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+
+	// Format is json (NDJSON), csv or toml. It defaults to json.
+	Format string `json:"format"`
+
+	// Archived includes archived words and patterns. The language and its
+	// parts are exported whether it's archived or not.
+	Archived bool `json:"archived"`
+}
+
+// Export streams a language's lexicon in a format /import accepts.
+func (app *App) Export(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	args := ExportArgs{
+		Language: query.Get("language"),
+		Format:   query.Get("format"),
+	}
+	if args.Format == "" {
+		args.Format = "json"
+	}
+
+	fieldErrs := validators.Struct(&args)
+	if archived := query.Get("archived"); archived != "" {
+		var err error
+		if args.Archived, err = strconv.ParseBool(archived); err != nil {
+			fieldErrs = append(fieldErrs, errors.FieldError{Field: "archived", Code: "Invalid", Msg: "must be true or false"})
+		}
+	}
+
+	enc, contentType, ok := newLexiconEncoder(args.Format, w)
+	if !ok {
+		fieldErrs = append(fieldErrs, errors.FieldError{Field: "format", Code: "Invalid", Msg: "must be json, csv or toml"})
+	}
+	if len(fieldErrs) > 0 {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(fieldErrs...))
+		return
+	}
+
+	porter, ok := app.Store.(database.LexiconPorter)
+	if !ok {
+		app.respondApi(w, r, nil, errors.NotSupported)
+		return
+	}
+
+	// Unknown languages are reported before anything is streamed.
+	if _, err := app.Store.LanguageGet(r.Context(), args.Language); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.Header().Set(lexiconSchemaHeader, strconv.Itoa(lexiconSchemaVersion))
+	w.Header().Set("Content-Disposition", `attachment; filename="lexicon-`+args.Language+`.`+exportExtension(args.Format)+`"`)
+
+	// Once rows are streaming the status is sent, so failures can only be
+	// logged and the response cut short.
+	err := enc.Header(args.Language)
+	if err == nil {
+		err = porter.LexiconExport(r.Context(), args.Language, args.Archived, func(row database.LexiconRow) error {
+			return enc.Row(row)
+		})
+	}
+	if err == nil {
+		err = enc.Flush()
+	}
+	if err != nil {
+		app.Logger.Log(logger.Error, "Export failed: "+err.Error(), logger.Fields{
+			"requestID": requestIDFromContext(r.Context()),
+			"language":  args.Language,
+		})
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func exportExtension(format string) string {
+	if format == "json" {
+		return "ndjson"
+	}
+	return format
+}
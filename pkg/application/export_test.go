@@ -0,0 +1,31 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// App.Export
+// -----------------------------------------------------------------------------
+func TestApp_Export_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	w := httptest.NewRecorder()
+	app.Export(w, httptest.NewRequest("GET", "/export?format=xml&archived=maybe", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatal(w.Code)
+	}
+
+	resp := apiResponse{Error: &apiError{}}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Error.Fields) != 3 {
+		t.Fatal(resp.Error.Fields)
+	}
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+const readyCheckTimeout = 2 * time.Second
+
+type HealthCheck struct {
+	Name      string  `json:"name"`
+	OK        bool    `json:"ok"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+type HealthReply struct {
+	OK     bool          `json:"ok"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// Healthz reports that the process is up and serving requests. It doesn't
+// touch any dependency.
+func (app *App) Healthz(w http.ResponseWriter, r *http.Request) {
+	app.writeHealth(w, HealthReply{OK: true, Checks: []HealthCheck{}})
+}
+
+// Readyz reports whether the server can handle traffic: the database is
+// reachable, fully migrated, and the lexicon cache is loaded.
+func (app *App) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+	defer cancel()
+
+	reply := HealthReply{OK: true}
+	for _, check := range []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{"database", app.checkDatabase},
+		{"migrations", app.checkMigrations},
+		{"lexicon", app.checkLexicon},
+	} {
+		start := time.Now()
+		err := check.fn(ctx)
+
+		result := HealthCheck{
+			Name:      check.name,
+			OK:        err == nil,
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if err != nil {
+			result.Error = err.Error()
+			reply.OK = false
+		}
+		reply.Checks = append(reply.Checks, result)
+	}
+
+	app.writeHealth(w, reply)
+}
+
+func (app *App) checkDatabase(ctx context.Context) error {
+	if app.Store == nil {
+		return fmt.Errorf("database not configured")
+	}
+	if pinger, ok := app.Store.(interface{ PingContext(context.Context) error }); ok {
+		return pinger.PingContext(ctx)
+	}
+	return nil
+}
+
+// checkMigrations checks the Postgres schema. The other stores create and
+// upgrade their own when they're opened.
+func (app *App) checkMigrations(ctx context.Context) error {
+	if app.Store == nil {
+		return fmt.Errorf("database not configured")
+	}
+	if dbal, ok := app.Store.(*database.DBAL); ok {
+		return dbal.Migrator().Check(ctx)
+	}
+	return nil
+}
+
+func (app *App) checkLexicon(ctx context.Context) error {
+	if app.Lexicon == nil || !app.Lexicon.Loaded() {
+		return fmt.Errorf("lexicon cache not loaded")
+	}
+	return nil
+}
+
+func (app *App) writeHealth(w http.ResponseWriter, reply HealthReply) {
+	status := http.StatusOK
+	if !reply.OK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(reply)
+}
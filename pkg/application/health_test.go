@@ -0,0 +1,91 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+// -----------------------------------------------------------------------------
+// App.Healthz
+// -----------------------------------------------------------------------------
+func TestApp_Healthz(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatal(w.Code)
+	}
+
+	reply := HealthReply{}
+	if err := json.Unmarshal(w.Body.Bytes(), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.OK {
+		t.Fatal(reply)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.Readyz
+// -----------------------------------------------------------------------------
+func TestApp_Readyz_NotReady(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatal(w.Code)
+	}
+
+	reply := HealthReply{}
+	if err := json.Unmarshal(w.Body.Bytes(), &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.OK || len(reply.Checks) != 3 {
+		t.Fatal(reply)
+	}
+	for _, check := range reply.Checks {
+		if check.OK || check.Error == "" {
+			t.Fatal(check)
+		}
+	}
+}
+
+func TestApp_Readyz_NotRateLimited(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{RateLimit: RateLimitConfig{Rate: 1, Burst: 1}})
+	handler := app.Routes()
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		if w.Code == http.StatusTooManyRequests {
+			t.Fatal(i)
+		}
+	}
+}
+
+func TestApp_Readyz_MemoryStore(t *testing.T) {
+	t.Parallel()
+	app, err := Mount(Config{DB: database.Config{Driver: database.DriverMemory}}, []Service{DBService, GeneratorService})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Close()
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatal(w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,215 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+type ImportArgs struct {
+	// OnDuplicate is skip, overwrite or fail. It defaults to fail.
+	OnDuplicate string `json:"onDuplicate"`
+}
+
+type ImportReply struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Errored int `json:"errored"`
+
+	Rows []ImportRowReply `json:"rows"`
+}
+
+// ImportRowReply reports on a row of the import. Row counts data rows from 1.
+type ImportRowReply struct {
+	Row    int                 `json:"row"`
+	Status string              `json:"status"`
+	ID     string              `json:"id,omitempty"`
+	Errors []errors.FieldError `json:"errors,omitempty"`
+}
+
+type lexiconLanguageRow struct {
+	Language  string  `json:"language" validate:"required,max=35,charset=language"`
+	Name      string  `json:"name" validate:"required,max=64"`
+	Script    string  `json:"script" validate:"max=4"`
+	Direction string  `json:"direction" validate:"max=3"`
+	Separator *string `json:"separator" validate:"max=8"`
+}
+
+type lexiconPatternRow struct {
+	Pattern  string `json:"pattern" validate:"required,max=255,charset=pattern"`
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+}
+
+func validateLexiconRow(row database.LexiconRow) []errors.FieldError {
+	switch row.Kind {
+	case database.RowKindLanguage:
+		return validators.Struct(&lexiconLanguageRow{
+			Language:  row.Language,
+			Name:      row.Name,
+			Script:    row.Script,
+			Direction: row.Direction,
+			Separator: row.Separator,
+		})
+	case database.RowKindPart:
+		return validators.Struct(&PartCreateArgs{Language: row.Language, Part: row.Part, UDTag: row.UDTag})
+	case database.RowKindWord:
+		fieldErrs := validators.Struct(&WordCreateArgs{Word: row.Word, Language: row.Language, Part: row.Part})
+		for _, tag := range row.Tags {
+			fieldErrs = append(fieldErrs, validateTagField("tags", tag)...)
+		}
+		return fieldErrs
+	case database.RowKindPattern:
+		fieldErrs := validators.Struct(&lexiconPatternRow{Pattern: row.Pattern, Language: row.Language})
+		if row.Theme != "" {
+			fieldErrs = append(fieldErrs, validateTagField("theme", row.Theme)...)
+		}
+		return fieldErrs
+	}
+	return []errors.FieldError{{Field: "kind", Code: "Invalid", Msg: "must be language, part, word or pattern"}}
+}
+
+// Import creates languages, parts, words and patterns in one transaction. Invalid rows fail the
+// whole import with the fail strategy, and are reported and left out
+// otherwise.
+func (app *App) Import(w http.ResponseWriter, r *http.Request) {
+	strategy := r.URL.Query().Get("onDuplicate")
+	if strategy == "" {
+		strategy = database.ImportFail
+	}
+	known := false
+	for _, s := range database.ImportStrategies {
+		known = known || s == strategy
+	}
+	if !known {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(errors.FieldError{
+			Field: "onDuplicate",
+			Code:  "Invalid",
+			Msg:   "must be skip, overwrite or fail",
+		}))
+		return
+	}
+
+	maxBytes := app.Config.MaxImportBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxImportBytes
+	}
+	body := &io.LimitedReader{R: r.Body, N: maxBytes + 1}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	records, err := decodeLexicon(contentType, body)
+	if body.N <= 0 {
+		err = errors.HttpBodyTooLarge
+	}
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	reply := ImportReply{Rows: make([]ImportRowReply, len(records))}
+
+	var rows []database.LexiconRow
+	var rowNumbers []int
+	var invalid []errors.FieldError
+	for i, record := range records {
+		reply.Rows[i].Row = i + 1
+
+		fieldErrs := validateLexiconRow(record.Row)
+		if record.Err != nil {
+			fieldErrs = append([]errors.FieldError{*record.Err}, fieldErrs...)
+		}
+		if len(fieldErrs) > 0 {
+			reply.Rows[i].Status = database.ImportErrored
+			reply.Rows[i].Errors = fieldErrs
+			for _, fieldErr := range fieldErrs {
+				fieldErr.Field = rowField(i+1, fieldErr.Field)
+				invalid = append(invalid, fieldErr)
+			}
+			continue
+		}
+
+		rows = append(rows, record.Row)
+		rowNumbers = append(rowNumbers, i+1)
+	}
+
+	if strategy == database.ImportFail && len(invalid) > 0 {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(invalid...))
+		return
+	}
+
+	porter, ok := app.Store.(database.LexiconPorter)
+	if !ok {
+		app.respondApi(w, r, nil, errors.NotSupported)
+		return
+	}
+
+	results, index, err := porter.LexiconImport(r.Context(), rows, strategy)
+	if rowErr, ok := err.(errors.Error); ok && index >= 0 {
+		fieldErr := errors.FieldError{
+			Field: rowField(rowNumbers[index], ""),
+			Code:  rowErr.Code(),
+			Msg:   "already exists",
+		}
+		switch {
+		case errors.LanguageInvalid.Equals(rowErr):
+			fieldErr.Msg = rowErr.Msg()
+		case errors.PartInvalid.Equals(rowErr):
+			fieldErr.Field = rowField(rowNumbers[index], "part")
+			fieldErr.Msg = rowErr.Msg()
+		case errors.TagInvalid.Equals(rowErr) && rows[index].Kind == database.RowKindWord:
+			fieldErr.Field = rowField(rowNumbers[index], "tags")
+			fieldErr.Msg = rowErr.Msg()
+		case errors.TagInvalid.Equals(rowErr):
+			fieldErr.Field = rowField(rowNumbers[index], "theme")
+			fieldErr.Msg = rowErr.Msg()
+		case errors.LanguageNotFound.Equals(rowErr):
+			fieldErr.Field = rowField(rowNumbers[index], "language")
+			fieldErr.Msg = "unknown language"
+		case errors.PartNotFound.Equals(rowErr) && rows[index].Kind == database.RowKindWord:
+			fieldErr.Field = rowField(rowNumbers[index], "part")
+			fieldErr.Msg = "unknown part"
+		case errors.PartNotFound.Equals(rowErr):
+			fieldErr.Field = rowField(rowNumbers[index], "pattern")
+			fieldErr.Msg = "unknown part " + rowErr.Msg()
+		}
+		app.respondApi(w, r, nil, rowErr.WithFields(fieldErr))
+		return
+	}
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	for i, result := range results {
+		row := &reply.Rows[rowNumbers[i]-1]
+		row.Status = result.Status
+		row.ID = result.ID
+	}
+
+	for _, row := range reply.Rows {
+		switch row.Status {
+		case database.ImportCreated:
+			reply.Created++
+		case database.ImportUpdated:
+			reply.Updated++
+		case database.ImportSkipped:
+			reply.Skipped++
+		case database.ImportErrored:
+			reply.Errored++
+		}
+	}
+
+	app.respondApi(w, r, reply, nil)
+}
+
+func rowField(row int, field string) string {
+	if field == "" {
+		return fmt.Sprintf("rows[%d]", row)
+	}
+	return fmt.Sprintf("rows[%d].%s", row, field)
+}
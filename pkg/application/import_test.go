@@ -0,0 +1,97 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// App.Import
+// -----------------------------------------------------------------------------
+func TestApp_Import_InvalidStrategy(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	r := httptest.NewRequest("POST", "/import?onDuplicate=merge", strings.NewReader(""))
+	r.Header.Set("Content-Type", mimeNDJSON)
+	w := httptest.NewRecorder()
+	app.Import(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatal(w.Code)
+	}
+}
+
+func TestApp_Import_InvalidRowsFail(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	r := httptest.NewRequest("POST", "/import", strings.NewReader(`{"kind":"word","word":"Grand","language":"en","part":"adjective"}
+{"kind":"word","word":"","language":"en","part":"noun"}
+{"kind":"verb","language":"en"}
+{"kind":"language","language":"de"}
+{"kind":"part","language":"en","part":"Noun"}
+{"kind":"word","word":"Hotel","language":"en","part":"noun","tags":["Space!"]}
+{"kind":"pattern","pattern":"noun","language":"en","theme":"no theme"}
+`))
+	r.Header.Set("Content-Type", mimeNDJSON)
+	w := httptest.NewRecorder()
+	app.Import(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatal(w.Code)
+	}
+
+	resp := apiResponse{Error: &apiError{}}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	fields := resp.Error.Fields
+	if len(fields) != 6 || fields[0].Field != "rows[2].word" || fields[1].Field != "rows[3].kind" ||
+		fields[2].Field != "rows[4].name" || fields[3].Field != "rows[5].part" ||
+		fields[4].Field != "rows[6].tags" || fields[5].Field != "rows[7].theme" {
+		t.Fatal(fields)
+	}
+}
+
+func TestApp_Import_TooLarge(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{MaxImportBytes: 16})
+
+	r := httptest.NewRequest("POST", "/import", strings.NewReader(`{"kind":"word","word":"Grand","language":"en","part":"adjective"}`))
+	r.Header.Set("Content-Type", mimeNDJSON)
+	w := httptest.NewRecorder()
+	app.Import(w, r)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatal(w.Code)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.contentTypeApiMdl
+// -----------------------------------------------------------------------------
+func TestApp_ContentTypeApiMdl(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	handler := app.contentTypeApiMdl([]string{mimeCSV})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for contentType, code := range map[string]int{
+		"text/csv":                http.StatusOK,
+		"text/csv; charset=utf-8": http.StatusOK,
+		"application/json":        http.StatusUnsupportedMediaType,
+		"":                        http.StatusUnsupportedMediaType,
+	} {
+		r := httptest.NewRequest("POST", "/import", nil)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != code {
+			t.Fatal(contentType, w.Code)
+		}
+	}
+}
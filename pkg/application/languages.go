@@ -0,0 +1,81 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+type LanguageCreateArgs struct {
+	// Code is a BCP 47 tag, e.g. "en" or "pt-BR".
+	Code string `json:"code" validate:"required,max=35,charset=language"`
+	Name string `json:"name" validate:"required,max=64"`
+
+	// Script is an ISO 15924 code, e.g. "Latn".
+	Script string `json:"script" validate:"max=4"`
+
+	// Direction is ltr (the default) or rtl.
+	Direction string `json:"direction" validate:"max=3"`
+
+	// Separator joins the words of the language's aliases, a space when
+	// omitted. Languages written without spaces set it to "".
+	Separator *string `json:"separator" validate:"max=8"`
+}
+
+type LanguageCreateReply struct {
+	Language database.Language `json:"language"`
+}
+
+func (app *App) LanguageCreate(w http.ResponseWriter, r *http.Request) {
+	args := LanguageCreateArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	separator := " "
+	if args.Separator != nil {
+		separator = *args.Separator
+	}
+
+	language, err := app.Store.LanguageCreate(r.Context(), database.Language{
+		Code:      args.Code,
+		Name:      args.Name,
+		Script:    args.Script,
+		Direction: args.Direction,
+		Separator: separator,
+	})
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	app.respondApi(w, r, LanguageCreateReply{Language: language}, nil)
+}
+
+type LanguageListArgs struct {
+	ShowArchived bool `json:"showArchived"`
+}
+
+type LanguageListReply struct {
+	Languages []database.Language `json:"languages"`
+}
+
+func (app *App) LanguageList(w http.ResponseWriter, r *http.Request) {
+	args := LanguageListArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	languages, err := app.Store.LanguageList(r.Context(), args.ShowArchived)
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if languages == nil {
+		languages = []database.Language{}
+	}
+	app.respondApi(w, r, LanguageListReply{Languages: languages}, nil)
+}
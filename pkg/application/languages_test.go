@@ -0,0 +1,38 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// App.LanguageCreate
+// -----------------------------------------------------------------------------
+func TestApp_LanguageCreate_Separator(t *testing.T) {
+	t.Parallel()
+	app, _, _ := newMemoryTestApp(t, 0)
+
+	for body, separator := range map[string]string{
+		`{"code":"fr","name":"French"}`:                  " ",
+		`{"code":"ja","name":"Japanese","separator":""}`: "",
+		`{"code":"de","name":"German","separator":"-"}`:  "-",
+	} {
+		r := httptest.NewRequest("POST", "/languageCreate", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.LanguageCreate(w, r)
+
+		var envelope struct {
+			Data LanguageCreateReply `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil || w.Code != http.StatusOK {
+			t.Fatal(body, w.Code, w.Body.String())
+		}
+		if envelope.Data.Language.Separator != separator {
+			t.Fatal(body, envelope.Data.Language)
+		}
+	}
+}
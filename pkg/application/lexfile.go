@@ -0,0 +1,462 @@
+package application
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Lexicon files hold database.LexiconRows, the language first and then its
+// parts, patterns and words. Exports start with a header giving the schema
+// version and language; imports accept files with or without one. Version 1
+// files have no language or part rows.
+//
+// NDJSON: the header is {"schemaVersion":2,"language":"en"}, then each line
+// is a row's JSON object.
+//
+// CSV: the header is a comment line "# alias-gen lexicon schemaVersion=2
+// language=en", then a record naming the columns, in any order, from
+// lexiconColumns, then one record per row. A word's tags are separated by
+// spaces.
+//
+// TOML: top level schemaVersion and language keys, then [[languages]],
+// [[parts]], [[patterns]] and [[words]] tables without the kind and language
+// fields.
+const (
+	mimeNDJSON = "application/x-ndjson"
+	mimeCSV    = "text/csv"
+	mimeTOML   = "application/toml"
+)
+
+const (
+	lexiconSchemaVersion       = 2
+	oldestLexiconSchemaVersion = 1
+)
+
+// lexiconSchemaHeader is the HTTP header exports carry the schema version in.
+const lexiconSchemaHeader = "X-Lexicon-Schema-Version"
+
+var lexiconColumns = []string{"kind", "language", "part", "word", "pattern", "archived", "name", "script", "direction", "separator", "ud_tag", "tags", "theme"}
+
+const maxNDJSONLine = 64 << 10
+
+// lexiconRecord is a decoded row, or the reason the row couldn't be decoded.
+type lexiconRecord struct {
+	Row database.LexiconRow
+	Err *errors.FieldError
+}
+
+type lexiconHeader struct {
+	SchemaVersion int    `json:"schemaVersion" toml:"schemaVersion"`
+	Language      string `json:"language" toml:"language"`
+}
+
+func (h lexiconHeader) check() error {
+	if h.SchemaVersion < oldestLexiconSchemaVersion || h.SchemaVersion > lexiconSchemaVersion {
+		return errors.HttpBadRequestArgs.WithMsg(fmt.Sprintf("unsupported lexicon schemaVersion %d", h.SchemaVersion))
+	}
+	return nil
+}
+
+func decodeLexicon(contentType string, r io.Reader) (records []lexiconRecord, err error) {
+	switch contentType {
+	case mimeNDJSON:
+		return decodeLexiconNDJSON(r)
+	case mimeCSV:
+		return decodeLexiconCSV(r)
+	case mimeTOML:
+		return decodeLexiconTOML(r)
+	}
+	return nil, errors.HttpBadContentType
+}
+
+func decodeLexiconNDJSON(r io.Reader) (records []lexiconRecord, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxNDJSONLine)
+
+	first := true
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if first {
+			first = false
+			header := lexiconHeader{}
+			dec := json.NewDecoder(bytes.NewReader(line))
+			dec.DisallowUnknownFields()
+			if dec.Decode(&header) == nil {
+				if err := header.check(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		record := lexiconRecord{}
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&record.Row); err != nil {
+			record.Err = &errors.FieldError{Code: "Malformed", Msg: err.Error()}
+		}
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.HttpBadRequestArgs.WithErr(err)
+	}
+	return records, nil
+}
+
+const csvHeaderPrefix = "# alias-gen lexicon"
+
+func decodeLexiconCSV(r io.Reader) (records []lexiconRecord, err error) {
+	br := bufio.NewReader(r)
+	if b, _ := br.Peek(1); len(b) == 1 && b[0] == '#' {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, errors.HttpBadRequestArgs.WithErr(err)
+		}
+		header, err := parseCSVHeader(line)
+		if err != nil {
+			return nil, err
+		}
+		if err := header.check(); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columnNames, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.HttpBadRequestArgs.WithErr(err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range columnNames {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range lexiconColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, errors.HttpBadRequestArgs.WithMsg(fmt.Sprintf("unknown CSV column %q", name))
+		}
+		columns[name] = i
+	}
+
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, errors.HttpBadRequestArgs.WithErr(err)
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := lexiconRecord{Row: database.LexiconRow{
+			Kind:      get("kind"),
+			Language:  get("language"),
+			Name:      get("name"),
+			Script:    get("script"),
+			Direction: get("direction"),
+			Part:      get("part"),
+			UDTag:     get("ud_tag"),
+			Word:      get("word"),
+			Tags:      strings.Fields(get("tags")),
+			Pattern:   get("pattern"),
+			Theme:     get("theme"),
+		}}
+		if len(record.Row.Tags) == 0 {
+			record.Row.Tags = nil
+		}
+		// Separators are often spaces, so they aren't trimmed.
+		if i, ok := columns["separator"]; ok && i < len(fields) && record.Row.Kind == database.RowKindLanguage {
+			separator := fields[i]
+			record.Row.Separator = &separator
+		}
+		if archived := get("archived"); archived != "" {
+			if record.Row.Archived, err = strconv.ParseBool(archived); err != nil {
+				record.Err = &errors.FieldError{Field: "archived", Code: "Malformed", Msg: "must be true or false"}
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+func parseCSVHeader(line string) (header lexiconHeader, err error) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, csvHeaderPrefix) {
+		return header, errors.HttpBadRequestArgs.WithMsg("CSV comment isn't a lexicon header")
+	}
+
+	for _, field := range strings.Fields(strings.TrimPrefix(line, csvHeaderPrefix)) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "schemaVersion":
+			if header.SchemaVersion, err = strconv.Atoi(kv[1]); err != nil {
+				return header, errors.HttpBadRequestArgs.WithMsg("malformed lexicon schemaVersion")
+			}
+		case "language":
+			header.Language = kv[1]
+		}
+	}
+	return header, nil
+}
+
+type lexiconTOMLFile struct {
+	lexiconHeader
+	Languages []lexiconTOMLLanguage `toml:"languages"`
+	Parts     []lexiconTOMLPart     `toml:"parts"`
+	Patterns  []lexiconTOMLPattern  `toml:"patterns"`
+	Words     []lexiconTOMLWord     `toml:"words"`
+}
+
+type lexiconTOMLLanguage struct {
+	Name      string  `toml:"name"`
+	Script    string  `toml:"script"`
+	Direction string  `toml:"direction"`
+	Separator *string `toml:"separator"`
+	Archived  bool    `toml:"archived"`
+}
+
+type lexiconTOMLPart struct {
+	Part  string `toml:"part"`
+	UDTag string `toml:"udTag"`
+}
+
+type lexiconTOMLPattern struct {
+	Pattern  string `toml:"pattern"`
+	Theme    string `toml:"theme"`
+	Archived bool   `toml:"archived"`
+}
+
+type lexiconTOMLWord struct {
+	Part     string   `toml:"part"`
+	Word     string   `toml:"word"`
+	Tags     []string `toml:"tags"`
+	Archived bool     `toml:"archived"`
+}
+
+func decodeLexiconTOML(r io.Reader) (records []lexiconRecord, err error) {
+	file := lexiconTOMLFile{}
+	md, err := toml.DecodeReader(r, &file)
+	if err != nil {
+		return nil, errors.HttpBadRequestArgs.WithErr(err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, errors.HttpBadRequestArgs.WithMsg(fmt.Sprintf("unknown TOML key %q", undecoded[0].String()))
+	}
+	if err := file.check(); err != nil {
+		return nil, err
+	}
+
+	for _, l := range file.Languages {
+		records = append(records, lexiconRecord{Row: database.LexiconRow{
+			Kind:      database.RowKindLanguage,
+			Language:  file.Language,
+			Name:      l.Name,
+			Script:    l.Script,
+			Direction: l.Direction,
+			Separator: l.Separator,
+			Archived:  l.Archived,
+		}})
+	}
+	for _, p := range file.Parts {
+		records = append(records, lexiconRecord{Row: database.LexiconRow{
+			Kind:     database.RowKindPart,
+			Language: file.Language,
+			Part:     p.Part,
+			UDTag:    p.UDTag,
+		}})
+	}
+	for _, p := range file.Patterns {
+		records = append(records, lexiconRecord{Row: database.LexiconRow{
+			Kind:     database.RowKindPattern,
+			Language: file.Language,
+			Pattern:  p.Pattern,
+			Theme:    p.Theme,
+			Archived: p.Archived,
+		}})
+	}
+	for _, w := range file.Words {
+		records = append(records, lexiconRecord{Row: database.LexiconRow{
+			Kind:     database.RowKindWord,
+			Language: file.Language,
+			Part:     w.Part,
+			Word:     w.Word,
+			Tags:     w.Tags,
+			Archived: w.Archived,
+		}})
+	}
+	return records, nil
+}
+
+// -----------------------------------------------------------------------------
+// lexiconEncoder writes a lexicon file a row at a time.
+type lexiconEncoder interface {
+	Header(language string) error
+	Row(row database.LexiconRow) error
+	Flush() error
+}
+
+func newLexiconEncoder(format string, w io.Writer) (enc lexiconEncoder, contentType string, ok bool) {
+	switch format {
+	case "json":
+		return &ndjsonLexiconEncoder{w: bufio.NewWriter(w)}, mimeNDJSON, true
+	case "csv":
+		return &csvLexiconEncoder{raw: w, w: csv.NewWriter(w)}, mimeCSV, true
+	case "toml":
+		return &tomlLexiconEncoder{w: bufio.NewWriter(w)}, mimeTOML, true
+	}
+	return nil, "", false
+}
+
+type ndjsonLexiconEncoder struct {
+	w *bufio.Writer
+}
+
+func (e *ndjsonLexiconEncoder) writeJSON(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e.w.Write(b)
+	return e.w.WriteByte('\n')
+}
+
+func (e *ndjsonLexiconEncoder) Header(language string) error {
+	return e.writeJSON(lexiconHeader{SchemaVersion: lexiconSchemaVersion, Language: language})
+}
+
+func (e *ndjsonLexiconEncoder) Row(row database.LexiconRow) error {
+	return e.writeJSON(row)
+}
+
+func (e *ndjsonLexiconEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+type csvLexiconEncoder struct {
+	raw io.Writer
+	w   *csv.Writer
+}
+
+func (e *csvLexiconEncoder) Header(language string) error {
+	// The comment line isn't a CSV record, so it's written around the
+	// csv.Writer, before it has buffered anything.
+	if _, err := fmt.Fprintf(e.raw, "%s schemaVersion=%d language=%s\n", csvHeaderPrefix, lexiconSchemaVersion, language); err != nil {
+		return err
+	}
+	return e.w.Write(lexiconColumns)
+}
+
+func (e *csvLexiconEncoder) Row(row database.LexiconRow) error {
+	separator := ""
+	if row.Separator != nil {
+		separator = *row.Separator
+	}
+	return e.w.Write([]string{
+		row.Kind,
+		row.Language,
+		row.Part,
+		row.Word,
+		row.Pattern,
+		strconv.FormatBool(row.Archived),
+		row.Name,
+		row.Script,
+		row.Direction,
+		separator,
+		row.UDTag,
+		strings.Join(row.Tags, " "),
+		row.Theme,
+	})
+}
+
+func (e *csvLexiconEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type tomlLexiconEncoder struct {
+	w *bufio.Writer
+}
+
+// tomlString quotes s as a TOML basic string. JSON string escapes are a
+// subset of TOML's.
+func tomlString(s string) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (e *tomlLexiconEncoder) Header(language string) error {
+	_, err := fmt.Fprintf(e.w, "schemaVersion = %d\nlanguage = %s\n", lexiconSchemaVersion, tomlString(language))
+	return err
+}
+
+func (e *tomlLexiconEncoder) Row(row database.LexiconRow) (err error) {
+	switch row.Kind {
+	case database.RowKindLanguage:
+		_, err = fmt.Fprintf(e.w, "\n[[languages]]\nname = %s\nscript = %s\ndirection = %s\n", tomlString(row.Name), tomlString(row.Script), tomlString(row.Direction))
+		if err == nil && row.Separator != nil {
+			_, err = fmt.Fprintf(e.w, "separator = %s\n", tomlString(*row.Separator))
+		}
+		if err == nil {
+			_, err = fmt.Fprintf(e.w, "archived = %t\n", row.Archived)
+		}
+	case database.RowKindPart:
+		_, err = fmt.Fprintf(e.w, "\n[[parts]]\npart = %s\nudTag = %s\n", tomlString(row.Part), tomlString(row.UDTag))
+	case database.RowKindPattern:
+		_, err = fmt.Fprintf(e.w, "\n[[patterns]]\npattern = %s\n", tomlString(row.Pattern))
+		if err == nil && row.Theme != "" {
+			_, err = fmt.Fprintf(e.w, "theme = %s\n", tomlString(row.Theme))
+		}
+		if err == nil {
+			_, err = fmt.Fprintf(e.w, "archived = %t\n", row.Archived)
+		}
+	case database.RowKindWord:
+		_, err = fmt.Fprintf(e.w, "\n[[words]]\npart = %s\nword = %s\n", tomlString(row.Part), tomlString(row.Word))
+		if err == nil && len(row.Tags) > 0 {
+			tags := make([]string, len(row.Tags))
+			for i, tag := range row.Tags {
+				tags[i] = tomlString(tag)
+			}
+			_, err = fmt.Fprintf(e.w, "tags = [%s]\n", strings.Join(tags, ", "))
+		}
+		if err == nil {
+			_, err = fmt.Fprintf(e.w, "archived = %t\n", row.Archived)
+		}
+	}
+	return err
+}
+
+func (e *tomlLexiconEncoder) Flush() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// decodeLexicon
+// -----------------------------------------------------------------------------
+func TestDecodeLexicon_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	records, err := decodeLexicon(mimeNDJSON, strings.NewReader(`{"kind":"word","word":"Grand","language":"en","part":"adjective"}
+
+{"kind":"pattern","pattern":"adjective,noun","language":"en","archived":true}
+{"kind":"word",
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatal(records)
+	}
+
+	if records[0].Err != nil || !reflect.DeepEqual(records[0].Row, database.LexiconRow{Kind: "word", Word: "Grand", Language: "en", Part: "adjective"}) {
+		t.Fatal(records[0])
+	}
+	if records[1].Err != nil || !records[1].Row.Archived || records[1].Row.Pattern != "adjective,noun" {
+		t.Fatal(records[1])
+	}
+	if records[2].Err == nil || records[2].Err.Code != "Malformed" {
+		t.Fatal(records[2])
+	}
+}
+
+func TestDecodeLexicon_CSV(t *testing.T) {
+	t.Parallel()
+
+	records, err := decodeLexicon(mimeCSV, strings.NewReader("kind,language,part,word,pattern,archived\n"+
+		"word,en,adjective,Grand,,false\n"+
+		"pattern,en,,,\"adjective,noun\",\n"+
+		"word,en,noun,Hotel,,maybe\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatal(records)
+	}
+
+	if records[0].Err != nil || !reflect.DeepEqual(records[0].Row, database.LexiconRow{Kind: "word", Word: "Grand", Language: "en", Part: "adjective"}) {
+		t.Fatal(records[0])
+	}
+	if records[1].Err != nil || records[1].Row.Pattern != "adjective,noun" {
+		t.Fatal(records[1])
+	}
+	if records[2].Err == nil || records[2].Err.Field != "archived" {
+		t.Fatal(records[2])
+	}
+}
+
+func TestDecodeLexicon_CSVUnknownColumn(t *testing.T) {
+	t.Parallel()
+
+	_, err := decodeLexicon(mimeCSV, strings.NewReader("kind,colour\nword,red\n"))
+	if !errors.HttpBadRequestArgs.Equals(err) {
+		t.Fatal(err)
+	}
+}
+
+func TestDecodeLexicon_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	for contentType, body := range map[string]string{
+		mimeNDJSON: `{"schemaVersion":3,"language":"en"}` + "\n",
+		mimeCSV:    "# alias-gen lexicon schemaVersion=0 language=en\nkind,language\n",
+		mimeTOML:   "schemaVersion = 3\nlanguage = \"en\"\n",
+	} {
+		if _, err := decodeLexicon(contentType, strings.NewReader(body)); !errors.HttpBadRequestArgs.Equals(err) {
+			t.Fatal(contentType, err)
+		}
+	}
+}
+
+func TestDecodeLexicon_Version1(t *testing.T) {
+	t.Parallel()
+
+	records, err := decodeLexicon(mimeTOML, strings.NewReader("schemaVersion = 1\nlanguage = \"en\"\n\n[[words]]\npart = \"noun\"\nword = \"Hotel\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || !reflect.DeepEqual(records[0].Row, database.LexiconRow{Kind: "word", Word: "Hotel", Language: "en", Part: "noun"}) {
+		t.Fatal(records)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// lexiconEncoder
+// -----------------------------------------------------------------------------
+func TestLexiconEncoder_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	space, none := " ", ""
+	rows := []database.LexiconRow{
+		{Kind: database.RowKindLanguage, Language: "fr", Name: "Fran\u00e7ais", Script: "Latn", Direction: "ltr", Separator: &space},
+		{Kind: database.RowKindLanguage, Language: "fr", Name: "Japanese", Direction: "ltr", Separator: &none, Archived: true},
+		{Kind: database.RowKindPart, Language: "fr", Part: "adjective", UDTag: "ADJ"},
+		{Kind: database.RowKindPart, Language: "fr", Part: "nom"},
+		{Kind: database.RowKindPattern, Language: "fr", Pattern: "adjective,noun", Theme: "fantasy"},
+		{Kind: database.RowKindPattern, Language: "fr", Pattern: "noun", Archived: true},
+		{Kind: database.RowKindWord, Language: "fr", Part: "adjective", Word: "Grand", Tags: []string{"corporate-safe", "fantasy"}},
+		{Kind: database.RowKindWord, Language: "fr", Part: "noun", Word: "H\u00f4tel \"d'or\"", Archived: true},
+	}
+
+	for format, contentType := range map[string]string{
+		"json": mimeNDJSON,
+		"csv":  mimeCSV,
+		"toml": mimeTOML,
+	} {
+		buf := &bytes.Buffer{}
+		enc, gotContentType, ok := newLexiconEncoder(format, buf)
+		if !ok || gotContentType != contentType {
+			t.Fatal(format, gotContentType)
+		}
+
+		if err := enc.Header("fr"); err != nil {
+			t.Fatal(format, err)
+		}
+		for _, row := range rows {
+			if err := enc.Row(row); err != nil {
+				t.Fatal(format, err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatal(format, err)
+		}
+
+		records, err := decodeLexicon(contentType, buf)
+		if err != nil {
+			t.Fatal(format, err, buf.String())
+		}
+		if len(records) != len(rows) {
+			t.Fatal(format, records)
+		}
+		for i, record := range records {
+			if record.Err != nil || !reflect.DeepEqual(record.Row, rows[i]) {
+				t.Fatal(format, i, record)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/metrics"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec(
+		"aliasgen_http_requests_total",
+		"HTTP requests by route, method and status.",
+		"route", "method", "status",
+	)
+	httpRequestDuration = metrics.NewHistogramVec(
+		"aliasgen_http_request_duration_seconds",
+		"HTTP request latency in seconds by route, method and status.",
+		nil,
+		"route", "method", "status",
+	)
+	lexiconWords = metrics.NewGaugeVec(
+		"aliasgen_lexicon_words",
+		"Unarchived words by language and part.",
+		"language", "part",
+	)
+)
+
+// metricsMdl records the request count and latency of a route.
+func (app *App) metricsMdl(path string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			statusText := strconv.Itoa(status)
+
+			httpRequests.Inc(path, r.Method, statusText)
+			httpRequestDuration.Observe(time.Since(start).Seconds(), path, r.Method, statusText)
+		})
+	}
+}
+
+// Metrics serves the metrics registry in the Prometheus text format.
+func (app *App) Metrics(w http.ResponseWriter, r *http.Request) {
+	if app.Store != nil {
+		if sizes, err := app.Store.LexiconSizes(r.Context()); err == nil {
+			lexiconWords.Reset()
+			for _, size := range sizes {
+				lexiconWords.Set(float64(size.Words), size.Language, size.Part)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := metrics.Default.Write(w); err != nil {
+		app.serverErr(w, r, err)
+	}
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// App.Metrics
+// -----------------------------------------------------------------------------
+func TestApp_Metrics(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	handler := app.Routes()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/openapi.json", nil))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatal(w.Code)
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatal(w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Body.String(), `aliasgen_http_requests_total{route="/openapi.json",method="GET",status="200"}`) {
+		t.Fatal(w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "# TYPE aliasgen_db_query_duration_seconds histogram") {
+		t.Fatal(w.Body.String())
+	}
+}
@@ -1,12 +1,20 @@
 package application
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"mime"
+	"net"
 	"net/http"
-	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/timaraxian/alias-gen/pkg/database"
 	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"github.com/timaraxian/alias-gen/pkg/helpers/ratelimit"
 )
 
 type middleware func(next http.Handler) http.Handler
@@ -29,10 +37,94 @@ func (app *App) secureHeadersMdl(next http.Handler) http.Handler {
 	})
 }
 
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestIDMdl honours a well formed X-Request-ID from the client, or
+// generates one, and echoes it on the response.
+func (app *App) requestIDMdl(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = crypto.NewUUID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+
+		info := &requestInfo{ID: id}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestInfoCtxKey, info)))
+	})
+}
+
+// statusRecorder captures the status and size of a response for logging.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+func (rec *statusRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// logMdl writes a structured access log line once the response is done.
 func (app *App) logMdl(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("INFO: %s - %s %s %s %s", r.RemoteAddr, r.Proto, r.Host, r.Method, r.URL.RequestURI())
-		next.ServeHTTP(w, r)
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		level := logger.Info
+		if status >= 500 {
+			level = logger.Error
+		} else if status >= 400 {
+			level = logger.Warn
+		}
+
+		fields := logger.Fields{
+			"method":     r.Method,
+			"path":       r.URL.Path,
+			"status":     status,
+			"bytes":      rec.bytes,
+			"durationMs": float64(time.Since(start)) / float64(time.Millisecond),
+			"remoteAddr": r.RemoteAddr,
+		}
+		if info, ok := r.Context().Value(requestInfoCtxKey).(*requestInfo); ok {
+			fields["requestID"] = info.ID
+			if info.ApiKeyName != "" {
+				fields["apiKey"] = info.ApiKeyName
+			}
+		}
+
+		app.Logger.Log(level, "access", fields)
 	})
 }
 
@@ -40,6 +132,11 @@ func (app *App) catchPanicMdl(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
+				// Handlers abort a response they've started streaming with
+				// ErrAbortHandler, which the server handles quietly.
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
 				w.Header().Set("Connection", "close")
 				app.serverErr(w, r, errors.Unexpected.WithErr(fmt.Errorf("%s", err)))
 			}
@@ -72,15 +169,24 @@ func (app *App) postOnlyApiMdl(next http.Handler) http.Handler {
 	})
 }
 
-func (app *App) jsonOnlyApiMdl(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
-			app.respondApi(w, r, false, errors.HttpBadContentType)
-			return
-		}
+// contentTypeApiMdl refuses request bodies that aren't one of consumes.
+// Parameters such as charset are ignored.
+func (app *App) contentTypeApiMdl(consumes []string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err == nil {
+				for _, c := range consumes {
+					if mediaType == c {
+						next.ServeHTTP(w, r)
+						return
+					}
+				}
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			app.respondApi(w, r, false, errors.HttpBadContentType)
+		})
+	}
 }
 
 func (app *App) setApiHeadersMdl(next http.Handler) http.Handler {
@@ -94,35 +200,181 @@ func (app *App) setApiHeadersMdl(next http.Handler) http.Handler {
 	})
 }
 
-func getOrigin(r *http.Request) (origin string) {
-	if origin = r.Header.Get("Origin"); len(origin) > 0 {
-		return origin
-	}
+// corsMdl answers preflight requests and sets the CORS response headers for
+// origins allowed by config. Other origins get no CORS headers, so browsers
+// block their cross-origin calls.
+func (app *App) corsMdl(config CORSConfig) middleware {
+	policy := newCorsPolicy(config)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Origin")
 
-	if origin = r.Header.Get("Referer"); len(origin) == 0 {
-		return ""
+			origin := r.Header.Get("Origin")
+			allowed := origin != "" && policy.allowsOrigin(origin)
+
+			if r.Method == "OPTIONS" {
+				if allowed && policy.allowsMethod(r.Header.Get("Access-Control-Request-Method")) {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+					w.Header().Set("Access-Control-Allow-Methods", policy.methods)
+					w.Header().Set("Access-Control-Allow-Headers", policy.headers)
+					w.Header().Set("Access-Control-Max-Age", policy.maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+
+			next.ServeHTTP(w, r)
+		})
 	}
+}
 
-	if u, err := url.Parse(origin); err != nil {
-		return ""
-	} else {
-		return u.Scheme + "://" + u.Host
+type ctxKey int
+
+const (
+	apiKeyCtxKey ctxKey = iota
+	requestInfoCtxKey
+	connCtxKey
+)
+
+// requestInfo is shared down the middleware chain so inner middlewares can
+// annotate the access log.
+type requestInfo struct {
+	ID         string
+	ApiKeyName string
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoCtxKey).(*requestInfo); ok {
+		return info.ID
 	}
+	return ""
 }
-func (app *App) corsMdl(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		origin := getOrigin(r)
 
-		w.Header().Set("Access-Control-Max-Age", "3600")
-		w.Header().Set("Access-Control-Allow-Origin", origin)
-		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Referer")
+func apiKeyFromContext(ctx context.Context) (apiKey database.ApiKey, ok bool) {
+	apiKey, ok = ctx.Value(apiKeyCtxKey).(database.ApiKey)
+	return apiKey, ok
+}
 
-		if r.Method == "OPTIONS" {
-			return
+// authMdl authenticates the bearer API key on the request and requires it to
+// carry scope.
+func (app *App) authMdl(scope string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authz := r.Header.Get("Authorization")
+			if !strings.HasPrefix(authz, "Bearer ") {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="alias-gen"`)
+				app.respondApi(w, r, nil, errors.AuthRequired)
+				return
+			}
+
+			apiKey, err := app.Keys.ApiKeyAuthenticate(r.Context(), strings.TrimSpace(strings.TrimPrefix(authz, "Bearer ")))
+			if err != nil {
+				if errors.AuthInvalid.Equals(err) {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="alias-gen", error="invalid_token"`)
+				}
+				app.respondApi(w, r, nil, err)
+				return
+			}
+
+			if !apiKey.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="alias-gen", error="insufficient_scope", scope="`+scope+`"`)
+				app.respondApi(w, r, nil, errors.AuthForbidden)
+				return
+			}
+
+			if info, ok := r.Context().Value(requestInfoCtxKey).(*requestInfo); ok {
+				info.ApiKeyName = apiKey.Name
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyCtxKey, apiKey)))
+		})
+	}
+}
+
+// noWriteDeadlineMdl lifts the write deadline the server set on the
+// connection for this request. The server sets it again for the next one.
+func (app *App) noWriteDeadlineMdl(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conn, ok := r.Context().Value(connCtxKey).(net.Conn); ok {
+			conn.SetWriteDeadline(time.Time{})
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// lexiconWriteMdl invalidates the lexicon cache once a write succeeds, so
+// generation sees it without waiting for the next refresh.
+func (app *App) lexiconWriteMdl(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		if rec.status < 400 {
+			app.LexiconChanged()
+		}
+	})
+}
+
+// LexiconChanged tells the lexicon cache, if any, that the database changed.
+func (app *App) LexiconChanged() {
+	if app.Lexicon != nil {
+		app.Lexicon.Invalidate()
+	}
+}
+
+// rateLimitMdl limits each client to limit's token bucket. Clients are keyed
+// by API key when the request is authenticated, and by remote address
+// otherwise.
+func (app *App) rateLimitMdl(limit RateLimit) middleware {
+	if limit.Rate <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return app.limiterMdl(ratelimit.New(limit.Rate, limit.Burst), clientKey)
+}
+
+// addrRateLimitMdl limits each remote address to limit's token bucket, shared
+// by every route the middleware wraps. It runs ahead of authMdl, so guessing
+// API keys is limited too.
+func (app *App) addrRateLimitMdl(limit RateLimit) middleware {
+	if limit.Rate <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return app.limiterMdl(ratelimit.New(limit.Rate, limit.Burst), addrKey)
+}
+
+func (app *App) limiterMdl(limiter *ratelimit.Limiter, key func(r *http.Request) string) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, retryAfter := limiter.Allow(key(r)); !ok {
+				w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+				app.respondApi(w, r, nil, errors.RateLimited)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func clientKey(r *http.Request) string {
+	if apiKey, ok := apiKeyFromContext(r.Context()); ok {
+		return "key:" + apiKey.ApiKeyID
+	}
+	return addrKey(r)
+}
+
+func addrKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+func newTestApp(t *testing.T, config Config) (app *App, logs *bytes.Buffer) {
+	app, err := Mount(config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs = &bytes.Buffer{}
+	app.Logger = logger.New(logs, logger.Debug)
+	return app, logs
+}
+
+// -----------------------------------------------------------------------------
+// App.requestIDMdl
+// -----------------------------------------------------------------------------
+func TestApp_RequestIDMdl_Generated(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	if err := validators.UUID(w.Header().Get("X-Request-ID")); err != nil {
+		t.Fatal(w.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestApp_RequestIDMdl_Honoured(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	r := httptest.NewRequest("GET", "/openapi.json", nil)
+	r.Header.Set("X-Request-ID", "lb-1234.abc")
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, r)
+
+	if w.Header().Get("X-Request-ID") != "lb-1234.abc" {
+		t.Fatal(w.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestApp_RequestIDMdl_Malformed(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	r := httptest.NewRequest("GET", "/openapi.json", nil)
+	r.Header.Set("X-Request-ID", "bad id\nwith newline")
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, r)
+
+	if err := validators.UUID(w.Header().Get("X-Request-ID")); err != nil {
+		t.Fatal(w.Header().Get("X-Request-ID"))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.logMdl
+// -----------------------------------------------------------------------------
+func TestApp_LogMdl(t *testing.T) {
+	t.Parallel()
+	app, logs := newTestApp(t, Config{})
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatal(err, logs.String())
+	}
+
+	if entry["msg"] != "access" || entry["level"] != "info" {
+		t.Fatal(entry)
+	}
+	if entry["status"] != float64(http.StatusOK) {
+		t.Fatal(entry["status"])
+	}
+	if entry["bytes"] != float64(w.Body.Len()) {
+		t.Fatal(entry["bytes"])
+	}
+	if entry["requestID"] != w.Header().Get("X-Request-ID") {
+		t.Fatal(entry["requestID"])
+	}
+}
+
+func TestApp_LogMdl_Level(t *testing.T) {
+	t.Parallel()
+	app, logs := newTestApp(t, Config{})
+	app.Logger.SetLevel(logger.Warn)
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	if logs.Len() != 0 {
+		t.Fatal(logs.String())
+	}
+
+	w = httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("POST", "/openapi.json", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatal(w.Code)
+	}
+	if !bytes.Contains(logs.Bytes(), []byte(`"level":"warn"`)) {
+		t.Fatal(logs.String())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.catchPanicMdl
+// -----------------------------------------------------------------------------
+func TestApp_CatchPanicMdl_JSON(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	handler := app.catchPanicMdl(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8"} {
+		r := httptest.NewRequest("POST", "/wordGet", strings.NewReader(`{}`))
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		var resp apiResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(contentType, err, w.Body.String())
+		}
+		if w.Code != http.StatusInternalServerError || resp.OK || resp.Error == nil || resp.Error.Code != "Unexpected" {
+			t.Fatal(contentType, w.Code, w.Body.String())
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.addrRateLimitMdl
+// -----------------------------------------------------------------------------
+func TestApp_AddrRateLimitMdl(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{RateLimit: RateLimitConfig{Addr: RateLimit{Rate: 0.001, Burst: 2}}})
+	store := database.NewMemoryStore()
+	app.Store, app.Keys = store, store
+	handler := app.Routes()
+
+	// The bucket is shared by routes and taken before the key is checked.
+	for i, path := range []string{"/wordList", "/patternList", "/aliasGenerate"} {
+		r := httptest.NewRequest("POST", path, strings.NewReader(`{}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", "Bearer guess")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		want := http.StatusUnauthorized
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Fatal(path, w.Code, w.Body.String())
+		}
+	}
+}
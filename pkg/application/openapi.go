@@ -0,0 +1,309 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type openAPIDoc struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary"`
+	OperationID string                     `json:"operationId"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema        `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+const openAPIVersion = "0.1.0"
+
+// OpenAPI serves the OpenAPI 3 description generated from the route table.
+func (app *App) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(app.openAPISpec())
+	if err != nil {
+		app.serverErr(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
+
+func (app *App) openAPISpec() openAPIDoc {
+	doc := openAPIDoc{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: "Alias-gen", Version: openAPIVersion},
+		Paths:   map[string]map[string]openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{},
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"apiKey": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	doc.Components.Schemas["ApiError"] = openAPISchemaOf(reflect.TypeOf(apiError{}), doc.Components.Schemas)
+	errResponse := openAPIResponse{
+		Description: "Error",
+		Content: map[string]openAPIMediaType{
+			"application/json": {Schema: openAPIEnvelope("error", &openAPISchema{Ref: "#/components/schemas/ApiError"})},
+		},
+	}
+
+	for _, rt := range app.routeTable() {
+		op := openAPIOperation{
+			Summary:     rt.Summary,
+			OperationID: strings.Trim(strings.Replace(rt.Path, "/", "_", -1), "_."),
+			Responses:   map[string]openAPIResponse{},
+		}
+		if rt.Scope != "" {
+			op.Security = []map[string][]string{{"apiKey": {rt.Scope}}}
+		}
+
+		if rt.Args != nil {
+			argsSchema := openAPISchemaOf(reflect.TypeOf(rt.Args), doc.Components.Schemas)
+			if rt.Method == "GET" {
+				op.Parameters = openAPIQueryParameters(reflect.TypeOf(rt.Args))
+			} else {
+				op.RequestBody = &openAPIRequestBody{Required: true, Content: map[string]openAPIMediaType{}}
+				for _, contentType := range rt.consumes() {
+					op.RequestBody.Content[contentType] = openAPIMediaType{Schema: argsSchema}
+				}
+			}
+		}
+		if rt.Query != nil {
+			op.Parameters = append(op.Parameters, openAPIQueryParameters(reflect.TypeOf(rt.Query))...)
+		}
+
+		if len(rt.Produces) > 0 {
+			content := map[string]openAPIMediaType{}
+			for _, contentType := range rt.Produces {
+				var schema *openAPISchema
+				if rt.Reply != nil {
+					schema = openAPISchemaOf(reflect.TypeOf(rt.Reply), doc.Components.Schemas)
+				} else if contentType == "application/json" {
+					schema = &openAPISchema{Type: "object"}
+				} else {
+					schema = &openAPISchema{Type: "string"}
+				}
+				content[contentType] = openAPIMediaType{Schema: schema}
+			}
+			op.Responses["200"] = openAPIResponse{Description: "OK", Content: content}
+		} else {
+			op.Responses["200"] = openAPIResponse{
+				Description: "OK",
+				Content: map[string]openAPIMediaType{
+					"application/json": {Schema: openAPIEnvelope("data", openAPISchemaOf(reflect.TypeOf(rt.Reply), doc.Components.Schemas))},
+				},
+			}
+		}
+		op.Responses["default"] = errResponse
+
+		if doc.Paths[rt.Path] == nil {
+			doc.Paths[rt.Path] = map[string]openAPIOperation{}
+		}
+		doc.Paths[rt.Path][strings.ToLower(rt.Method)] = op
+	}
+
+	return doc
+}
+
+func openAPIEnvelope(field string, schema *openAPISchema) *openAPISchema {
+	return &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"ok":  {Type: "boolean"},
+			field: schema,
+		},
+		Required: []string{"ok", field},
+	}
+}
+
+func openAPIQueryParameters(t reflect.Type) (params []openAPIParameter) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := openAPIFieldName(f)
+		if !ok {
+			continue
+		}
+		params = append(params, openAPIParameter{
+			Name:     name,
+			In:       "query",
+			Required: openAPIRequired(f),
+			Schema:   openAPISchemaOf(f.Type, nil),
+		})
+	}
+
+	return params
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// openAPISchemaOf returns the schema for t. Named structs are added to
+// schemas and referenced; when schemas is nil they are inlined instead.
+func openAPISchemaOf(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	if t.Kind() == reflect.Ptr {
+		schema := openAPISchemaOf(t.Elem(), schemas)
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	}
+
+	switch {
+	case t == timeType:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.String:
+		return &openAPISchema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &openAPISchema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &openAPISchema{Type: "array", Items: openAPISchemaOf(t.Elem(), schemas)}
+	case t.Kind() == reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: openAPISchemaOf(t.Elem(), schemas)}
+	case t.Kind() == reflect.Struct:
+		if schemas != nil && t.Name() != "" {
+			if _, ok := schemas[t.Name()]; !ok {
+				// Reserve the name first so recursive types terminate.
+				schemas[t.Name()] = &openAPISchema{}
+				*schemas[t.Name()] = *openAPIStructSchema(t, schemas)
+			}
+			return &openAPISchema{Ref: "#/components/schemas/" + t.Name()}
+		}
+		return openAPIStructSchema(t, schemas)
+	default:
+		return &openAPISchema{}
+	}
+}
+
+func openAPIStructSchema(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := openAPIFieldName(f)
+		if !ok {
+			continue
+		}
+
+		prop := openAPISchemaOf(f.Type, schemas)
+		if max, ok := openAPIMaxLength(f); ok {
+			prop.MaxLength = &max
+		}
+		schema.Properties[name] = prop
+
+		if openAPIRequired(f) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+func openAPIFieldName(f reflect.StructField) (name string, ok bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+
+	name = strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+func openAPIRules(f reflect.StructField) []string {
+	if tag := f.Tag.Get("validate"); tag != "" {
+		return strings.Split(tag, ",")
+	}
+	return nil
+}
+
+func openAPIRequired(f reflect.StructField) bool {
+	for _, rule := range openAPIRules(f) {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+func openAPIMaxLength(f reflect.StructField) (max int, ok bool) {
+	for _, rule := range openAPIRules(f) {
+		if strings.HasPrefix(rule, "max=") {
+			max, err := strconv.Atoi(strings.TrimPrefix(rule, "max="))
+			return max, err == nil
+		}
+	}
+	return 0, false
+}
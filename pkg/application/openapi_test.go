@@ -0,0 +1,104 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// App.openAPISpec
+// -----------------------------------------------------------------------------
+func TestApp_OpenAPI_DescribesEveryRoute(t *testing.T) {
+	t.Parallel()
+	app := &App{}
+
+	spec := app.openAPISpec()
+
+	for _, rt := range app.routeTable() {
+		if rt.Summary == "" {
+			t.Fatal("route has no summary", rt.Path)
+		}
+		if len(rt.Produces) == 0 && rt.Reply == nil {
+			t.Fatal("route has no reply type", rt.Path)
+		}
+		if rt.Method == "POST" && rt.Args == nil {
+			t.Fatal("route has no args type", rt.Path)
+		}
+
+		op, ok := spec.Paths[rt.Path][strings.ToLower(rt.Method)]
+		if !ok {
+			t.Fatal("route missing from spec", rt.Path)
+		}
+		if _, ok := op.Responses["200"]; !ok {
+			t.Fatal("route has no success response", rt.Path)
+		}
+	}
+
+	n := 0
+	for _, ops := range spec.Paths {
+		n += len(ops)
+	}
+	if n != len(app.routeTable()) {
+		t.Fatal(n, len(app.routeTable()))
+	}
+}
+
+func TestApp_OpenAPI_ArgsSchema(t *testing.T) {
+	t.Parallel()
+	app := &App{}
+
+	spec := app.openAPISpec()
+
+	schema, ok := spec.Components.Schemas["WordCreateArgs"]
+	if !ok {
+		t.Fatal(spec.Components.Schemas)
+	}
+	if len(schema.Required) != 3 {
+		t.Fatal(schema.Required)
+	}
+	word, ok := schema.Properties["word"]
+	if !ok {
+		t.Fatal(schema.Properties)
+	}
+	if word.Type != "string" || word.MaxLength == nil || *word.MaxLength != 64 {
+		t.Fatal(word)
+	}
+}
+
+func TestApp_OpenAPI_Served(t *testing.T) {
+	t.Parallel()
+	app, err := Mount(Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatal(w.Code)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["openapi"] != "3.0.3" {
+		t.Fatal(doc["openapi"])
+	}
+}
+
+func TestApp_OpenAPI_ExportProduces(t *testing.T) {
+	t.Parallel()
+	app := &App{}
+
+	content := app.openAPISpec().Paths["/export"]["get"].Responses["200"].Content
+	for _, contentType := range []string{mimeNDJSON, mimeCSV, mimeTOML} {
+		if _, ok := content[contentType]; !ok {
+			t.Fatal(contentType, content)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+type PartCreateArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+	Part     string `json:"part" validate:"required,max=32,charset=part"`
+
+	// UDTag is the Universal Dependencies tag the part maps to, e.g. ADJ.
+	UDTag string `json:"udTag" validate:"max=5"`
+}
+
+type PartCreateReply struct {
+	Part database.Part `json:"part"`
+}
+
+func (app *App) PartCreate(w http.ResponseWriter, r *http.Request) {
+	args := PartCreateArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	part, err := app.Store.PartCreate(r.Context(), database.Part{
+		Language: args.Language,
+		Part:     args.Part,
+		UDTag:    args.UDTag,
+	})
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	app.respondApi(w, r, PartCreateReply{Part: part}, nil)
+}
+
+type PartListArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+}
+
+type PartListReply struct {
+	Parts []database.Part `json:"parts"`
+}
+
+func (app *App) PartList(w http.ResponseWriter, r *http.Request) {
+	args := PartListArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	parts, err := app.Store.PartList(r.Context(), args.Language)
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if parts == nil {
+		parts = []database.Part{}
+	}
+	app.respondApi(w, r, PartListReply{Parts: parts}, nil)
+}
+
+type PartRenameArgs struct {
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+	From     string `json:"from" validate:"required,max=32,charset=part"`
+	To       string `json:"to" validate:"required,max=32,charset=part"`
+}
+
+type PartRenameReply struct{}
+
+// PartRename renames a part along with the words and pattern slots using it.
+func (app *App) PartRename(w http.ResponseWriter, r *http.Request) {
+	args := PartRenameArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	renamer, ok := app.Store.(database.PartRenamer)
+	if !ok {
+		app.respondApi(w, r, nil, errors.NotSupported)
+		return
+	}
+
+	if err := renamer.PartRename(r.Context(), args.Language, args.From, args.To); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	app.respondApi(w, r, PartRenameReply{}, nil)
+}
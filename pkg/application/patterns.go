@@ -0,0 +1,105 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+type PatternListArgs struct {
+	Filter database.PatternFilter `json:"filter"`
+
+	// OrderBy keys are pattern, language, createdAt and updatedAt. It defaults to
+	// createdAt, oldest first.
+	OrderBy []database.OrderBy `json:"orderBy"`
+
+	// Limit defaults to 50 and may be up to 1000.
+	Limit int `json:"limit"`
+
+	// Cursor is the nextCursor or prevCursor of a previous reply.
+	Cursor string `json:"cursor"`
+}
+
+type PatternListReply struct {
+	Patterns []database.Pattern `json:"patterns"`
+
+	// NextCursor and PrevCursor fetch the pages either side, and are empty
+	// when there are none.
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+
+	// Facets counts every row matching the filter, not just this page. Only
+	// the first page, fetched without a cursor, carries them.
+	Facets *database.Facets `json:"facets"`
+}
+
+func (app *App) PatternList(w http.ResponseWriter, r *http.Request) {
+	args := PatternListArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+	if err := validateListLimit(args.Limit); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if len(args.OrderBy) == 0 {
+		args.OrderBy = []database.OrderBy{{Key: "createdAt"}}
+	}
+	patterns, page, err := app.Store.PatternList(r.Context(), database.PatternListArgs{
+		Filter:  args.Filter,
+		OrderBy: args.OrderBy,
+		Limit:   args.Limit,
+		Cursor:  args.Cursor,
+	})
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	var facets *database.Facets
+	if args.Cursor == "" {
+		f, err := app.Store.PatternFacets(r.Context(), args.Filter)
+		if err != nil {
+			app.respondApi(w, r, nil, err)
+			return
+		}
+		facets = &f
+	}
+
+	if patterns == nil {
+		patterns = []database.Pattern{}
+	}
+	app.respondApi(w, r, PatternListReply{
+		Patterns:   patterns,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Facets:     facets,
+	}, nil)
+}
+
+type PatternSetThemeArgs struct {
+	PatternID string `json:"patternID" validate:"required,max=36"`
+
+	// Theme is the tag the pattern's slots draw words with when generation
+	// doesn't ask for any. Empty clears it.
+	Theme string `json:"theme" validate:"max=32,charset=tag"`
+}
+
+type PatternSetThemeReply struct{}
+
+func (app *App) PatternSetTheme(w http.ResponseWriter, r *http.Request) {
+	args := PatternSetThemeArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if err := app.Store.PatternSetTheme(r.Context(), args.PatternID, args.Theme); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	app.respondApi(w, r, PatternSetThemeReply{}, nil)
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+)
+
+// GenerationQuotaRefund gives back n aliases consumed at now that weren't
+// delivered. It outlives the request, and failures are only logged.
+func (app *App) GenerationQuotaRefund(apiKeyID string, n int, now time.Time) {
+	if n <= 0 {
+		return
+	}
+	if err := app.Keys.GenerationQuotaRefund(context.Background(), apiKeyID, n, now); err != nil {
+		app.Logger.Log(logger.Error, err.Error(), logger.Fields{"apiKeyID": apiKeyID})
+	}
+}
+
+// quotaBatch is how many aliases a QuotaReservation consumes at a time.
+const quotaBatch = 100
+
+// QuotaReservation consumes an API key's quota for a stream of up to count
+// aliases in batches, rather than an alias at a time. Release refunds what
+// the stream didn't use.
+type QuotaReservation struct {
+	app      *App
+	apiKeyID string
+
+	// left is how many aliases may still be reserved, and reserved how many
+	// of the last batch are unused. at is when it was consumed.
+	left     int
+	reserved int
+	at       time.Time
+}
+
+func (app *App) ReserveQuota(apiKeyID string, count int) *QuotaReservation {
+	return &QuotaReservation{app: app, apiKeyID: apiKeyID, left: count}
+}
+
+// Take uses one alias of quota, consuming another batch when the last is
+// used up. Near the end of the quota the batch shrinks to what's left, and
+// once nothing is Take fails with errors.QuotaExceeded.
+func (q *QuotaReservation) Take(ctx context.Context) error {
+	if q.reserved == 0 {
+		n := quotaBatch
+		if n > q.left {
+			n = q.left
+		}
+		if n <= 0 {
+			return errors.QuotaExceeded
+		}
+
+		at := time.Now()
+		remaining, err := q.app.Keys.GenerationQuotaConsume(ctx, q.apiKeyID, n, at)
+		if errors.QuotaExceeded.Equals(err) && remaining != nil && *remaining > 0 {
+			n = *remaining
+			_, err = q.app.Keys.GenerationQuotaConsume(ctx, q.apiKeyID, n, at)
+		}
+		if err != nil {
+			return err
+		}
+		q.left -= n
+		q.reserved, q.at = n, at
+	}
+
+	q.reserved--
+	return nil
+}
+
+// Release refunds the unused part of the last batch.
+func (q *QuotaReservation) Release() {
+	q.app.GenerationQuotaRefund(q.apiKeyID, q.reserved, q.at)
+	q.reserved = 0
+}
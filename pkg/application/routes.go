@@ -2,28 +2,301 @@ package application
 
 import (
 	"net/http"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
 )
 
+type route struct {
+	Path    string
+	Method  string
+	Summary string
+
+	// Args is the JSON request body for POST routes, or the query parameters
+	// for GET routes. Reply is the type returned in the data field of the api
+	// envelope.
+	Args  interface{}
+	Reply interface{}
+
+	// Consumes lists the request body content types of a POST route, which
+	// defaults to application/json. Query is the query parameters of a POST
+	// route.
+	Consumes []string
+	Query    interface{}
+
+	// Scope is the API key scope required to call the route. Routes without
+	// a scope are public.
+	Scope string
+
+	// Produces lists the reply content types of routes that don't reply with
+	// the JSON api envelope.
+	Produces []string
+
+	// Unlimited exempts the route from rate limiting, for probes polled by
+	// the orchestrator.
+	Unlimited bool
+
+	// Streaming routes are served without the server's write timeout, as
+	// their replies take as long as there is to send.
+	Streaming bool
+
+	Handler http.HandlerFunc
+}
+
+func (app *App) routeTable() []route {
+	return []route{
+		{
+			Path:    "/languageCreate",
+			Method:  "POST",
+			Summary: "Create a language",
+			Args:    LanguageCreateArgs{},
+			Reply:   LanguageCreateReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.LanguageCreate,
+		},
+		{
+			Path:    "/languageList",
+			Method:  "POST",
+			Summary: "List languages ordered by code",
+			Args:    LanguageListArgs{},
+			Reply:   LanguageListReply{},
+			Scope:   database.ScopeLexiconRead,
+			Handler: app.LanguageList,
+		},
+		{
+			Path:    "/partCreate",
+			Method:  "POST",
+			Summary: "Create a part of speech for a language",
+			Args:    PartCreateArgs{},
+			Reply:   PartCreateReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.PartCreate,
+		},
+		{
+			Path:    "/partList",
+			Method:  "POST",
+			Summary: "List the parts of speech of a language",
+			Args:    PartListArgs{},
+			Reply:   PartListReply{},
+			Scope:   database.ScopeLexiconRead,
+			Handler: app.PartList,
+		},
+		{
+			Path:    "/partRename",
+			Method:  "POST",
+			Summary: "Rename a part of speech and the words and patterns using it",
+			Args:    PartRenameArgs{},
+			Reply:   PartRenameReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.PartRename,
+		},
+		{
+			Path:    "/wordCreate",
+			Method:  "POST",
+			Summary: "Create a word",
+			Args:    WordCreateArgs{},
+			Reply:   WordCreateReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.WordCreate,
+		},
+		{
+			Path:    "/wordList",
+			Method:  "POST",
+			Summary: "List words matching a filter, a page at a time",
+			Args:    WordListArgs{},
+			Reply:   WordListReply{},
+			Scope:   database.ScopeLexiconRead,
+			Handler: app.WordList,
+		},
+		{
+			Path:    "/wordTag",
+			Method:  "POST",
+			Summary: "Tag a word",
+			Args:    WordTagArgs{},
+			Reply:   WordTagReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.WordTag,
+		},
+		{
+			Path:    "/wordUntag",
+			Method:  "POST",
+			Summary: "Remove a tag from a word",
+			Args:    WordTagArgs{},
+			Reply:   WordTagReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.WordUntag,
+		},
+		{
+			Path:    "/wordSearch",
+			Method:  "POST",
+			Summary: "Search words by prefix and spelling, best matches first",
+			Args:    WordSearchArgs{},
+			Reply:   WordSearchReply{},
+			Scope:   database.ScopeLexiconRead,
+			Handler: app.WordSearch,
+		},
+		{
+			Path:    "/patternList",
+			Method:  "POST",
+			Summary: "List patterns matching a filter, a page at a time",
+			Args:    PatternListArgs{},
+			Reply:   PatternListReply{},
+			Scope:   database.ScopeLexiconRead,
+			Handler: app.PatternList,
+		},
+		{
+			Path:    "/patternSetTheme",
+			Method:  "POST",
+			Summary: "Set or clear the theme a pattern generates with by default",
+			Args:    PatternSetThemeArgs{},
+			Reply:   PatternSetThemeReply{},
+			Scope:   database.ScopeLexiconWrite,
+			Handler: app.PatternSetTheme,
+		},
+		{
+			Path:    "/aliasGenerate",
+			Method:  "POST",
+			Summary: "Generate random aliases for a language",
+			Args:    AliasGenerateArgs{},
+			Reply:   AliasGenerateReply{},
+			Scope:   database.ScopeGenerate,
+			Handler: app.AliasGenerate,
+		},
+		{
+			Path:      "/aliasStream",
+			Method:    "POST",
+			Summary:   "Stream unique aliases as NDJSON, or as Server-Sent Events with Accept: text/event-stream",
+			Args:      AliasStreamArgs{},
+			Reply:     AliasStreamEvent{},
+			Produces:  []string{mimeNDJSON, mimeEventStream},
+			Scope:     database.ScopeGenerate,
+			Streaming: true,
+			Handler:   app.AliasStream,
+		},
+		{
+			Path:     "/import",
+			Method:   "POST",
+			Summary:  "Import words and patterns from NDJSON, CSV or TOML",
+			Args:     database.LexiconRow{},
+			Query:    ImportArgs{},
+			Reply:    ImportReply{},
+			Consumes: []string{mimeNDJSON, mimeCSV, mimeTOML},
+			Scope:    database.ScopeLexiconWrite,
+			Handler:  app.Import,
+		},
+		{
+			Path:      "/export",
+			Method:    "GET",
+			Summary:   "Export a language's words and patterns as NDJSON, CSV or TOML",
+			Args:      ExportArgs{},
+			Produces:  []string{mimeNDJSON, mimeCSV, mimeTOML},
+			Scope:     database.ScopeLexiconRead,
+			Streaming: true,
+			Handler:   app.Export,
+		},
+		{
+			Path:     "/openapi.json",
+			Method:   "GET",
+			Summary:  "OpenAPI description of this API",
+			Produces: []string{"application/json"},
+			Handler:  app.OpenAPI,
+		},
+		{
+			Path:     "/metrics",
+			Method:   "GET",
+			Summary:  "Server metrics in the Prometheus text format",
+			Produces: []string{"text/plain; version=0.0.4"},
+			Handler:  app.Metrics,
+		},
+		{
+			Path:      "/healthz",
+			Method:    "GET",
+			Summary:   "Liveness probe, OK while the process is serving",
+			Reply:     HealthReply{},
+			Produces:  []string{"application/json"},
+			Unlimited: true,
+			Handler:   app.Healthz,
+		},
+		{
+			Path:      "/readyz",
+			Method:    "GET",
+			Summary:   "Readiness probe, checks the database, migrations and lexicon cache",
+			Reply:     HealthReply{},
+			Produces:  []string{"application/json"},
+			Unlimited: true,
+			Handler:   app.Readyz,
+		},
+	}
+}
+
+func (rt route) consumes() []string {
+	if len(rt.Consumes) == 0 {
+		return []string{"application/json"}
+	}
+	return rt.Consumes
+}
+
 func (app *App) Routes() http.Handler {
 	mux := http.NewServeMux()
 
-	//	getMdl := middlewareGroup(app.getOnlyWebMdl)
+	corsMdl := app.corsMdl(app.Config.CORS)
+
+	// Authenticated GET routes are API routes too, so browsers calling them
+	// with a key need the CORS policy of the POST routes.
+	getMdl := func(scope string) middleware {
+		if scope == "" {
+			return middlewareGroup(app.getOnlyWebMdl)
+		}
+		return middlewareGroup(corsMdl, app.getOnlyWebMdl)
+	}
 
-	apiMdl := middlewareGroup(
-		app.corsMdl,
-		app.postOnlyApiMdl,
-		app.jsonOnlyApiMdl,
-		app.setApiHeadersMdl,
-	)
+	apiMdl := func(consumes []string) middleware {
+		return middlewareGroup(
+			corsMdl,
+			app.postOnlyApiMdl,
+			app.contentTypeApiMdl(consumes),
+			app.setApiHeadersMdl,
+		)
+	}
+
+	addrLimitMdl := app.addrRateLimitMdl(app.Config.RateLimit.Addr)
 
 	// -----------------------------------------------------------------------------
 	// Routes
 	// -----------------------------------------------------------------------------
-	mux.Handle("/wordCreate", apiMdl(http.HandlerFunc(app.WordCreate)))
+	for _, rt := range app.routeTable() {
+		var handler http.Handler = rt.Handler
+		if rt.Streaming {
+			handler = app.noWriteDeadlineMdl(handler)
+		}
+		if rt.Scope == database.ScopeLexiconWrite {
+			handler = app.lexiconWriteMdl(handler)
+		}
+		if !rt.Unlimited {
+			handler = app.rateLimitMdl(app.Config.RateLimit.ForRoute(rt.Path))(handler)
+		}
+		if rt.Scope != "" {
+			handler = app.authMdl(rt.Scope)(handler)
+		}
+		if !rt.Unlimited {
+			handler = addrLimitMdl(handler)
+		}
+
+		switch rt.Method {
+		case "GET":
+			handler = getMdl(rt.Scope)(handler)
+		case "POST":
+			handler = apiMdl(rt.consumes())(handler)
+		default:
+			panic("unsupported route method: " + rt.Method)
+		}
+
+		mux.Handle(rt.Path, app.metricsMdl(rt.Path)(handler))
+	}
 
 	return middlewareGroup(
-		app.catchPanicMdl,
+		app.requestIDMdl,
 		app.logMdl,
+		app.catchPanicMdl,
 		app.secureHeadersMdl,
 	)(mux)
 }
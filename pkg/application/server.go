@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+)
+
+// Server serves an App over HTTP. Its config can be reloaded without dropping
+// connections, and Shutdown drains in-flight requests before closing the App.
+type Server struct {
+	HTTP *http.Server
+
+	app     atomic.Value // *App
+	handler atomic.Value // http.Handler
+}
+
+func NewServer(app *App) *Server {
+	timeouts := app.Config.Server.WithDefaults()
+
+	s := &Server{}
+	s.HTTP = &http.Server{
+		Addr:              app.Config.ListenAddr,
+		Handler:           s,
+		ReadTimeout:       timeouts.ReadTimeout.Duration,
+		ReadHeaderTimeout: timeouts.ReadHeaderTimeout.Duration,
+		WriteTimeout:      timeouts.WriteTimeout.Duration,
+		IdleTimeout:       timeouts.IdleTimeout.Duration,
+
+		// Streaming routes lift the write timeout off their connection.
+		ConnContext: func(ctx context.Context, conn net.Conn) context.Context {
+			return context.WithValue(ctx, connCtxKey, conn)
+		},
+	}
+	s.app.Store(app)
+	s.handler.Store(app.Routes())
+
+	return s
+}
+
+func (s *Server) App() *App {
+	return s.app.Load().(*App)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.Load().(http.Handler).ServeHTTP(w, r)
+}
+
+// ListenAndServe blocks until the server fails or is shut down. A shutdown
+// isn't an error.
+func (s *Server) ListenAndServe() error {
+	if err := s.HTTP.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Serve is ListenAndServe on a listener the caller opened.
+func (s *Server) Serve(ln net.Listener) error {
+	if err := s.HTTP.Serve(ln); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Reload applies config to new requests. Requests already in flight finish
+// with the config they started with. Rate limit buckets start afresh. The
+// listen address, database and server timeouts only change on restart.
+func (s *Server) Reload(config Config) error {
+	level, err := logger.ParseLevel(config.Log.Level)
+	if err != nil {
+		return err
+	}
+
+	current := s.App()
+	if config.ListenAddr != current.Config.ListenAddr ||
+		config.GRPCListenAddr != current.Config.GRPCListenAddr ||
+		config.DB != current.Config.DB ||
+		config.Server != current.Config.Server {
+		current.Logger.Log(logger.Warn, "Listen address, database and server settings apply on restart", nil)
+	}
+	config.ListenAddr = current.Config.ListenAddr
+	config.GRPCListenAddr = current.Config.GRPCListenAddr
+	config.DB = current.Config.DB
+	config.Server = current.Config.Server
+
+	next := *current
+	next.Config = config
+	next.Logger.SetLevel(level)
+
+	s.app.Store(&next)
+	s.handler.Store(next.Routes())
+
+	return nil
+}
+
+// Shutdown stops accepting connections and waits up to the configured
+// shutdown timeout for in-flight requests, then closes the App.
+func (s *Server) Shutdown() error {
+	app := s.App()
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.Config.Server.WithDefaults().ShutdownTimeout.Duration)
+	defer cancel()
+
+	err := s.HTTP.Shutdown(ctx)
+	if closeErr := app.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,193 @@
+package application
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// -----------------------------------------------------------------------------
+// ServerConfig
+// -----------------------------------------------------------------------------
+func TestServerConfig_Decode(t *testing.T) {
+	t.Parallel()
+	config := Config{}
+
+	_, err := toml.Decode(`
+[Server]
+ReadTimeout = "5s"
+ShutdownTimeout = "1m"
+`, &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeouts := config.Server.WithDefaults()
+	if timeouts.ReadTimeout.Duration != 5*time.Second {
+		t.Fatal(timeouts.ReadTimeout)
+	}
+	if timeouts.ShutdownTimeout.Duration != time.Minute {
+		t.Fatal(timeouts.ShutdownTimeout)
+	}
+	if timeouts.WriteTimeout.Duration != defaultWriteTimeout {
+		t.Fatal(timeouts.WriteTimeout)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.Reload
+// -----------------------------------------------------------------------------
+func TestServer_Reload(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	srv := NewServer(app)
+
+	get := func() int {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+		return w.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := get(); code != http.StatusOK {
+			t.Fatal(i, code)
+		}
+	}
+
+	err := srv.Reload(Config{
+		Log:       LogConfig{Level: "warn"},
+		RateLimit: RateLimitConfig{Rate: 1, Burst: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := get(); code != http.StatusOK {
+		t.Fatal(code)
+	}
+	if code := get(); code != http.StatusTooManyRequests {
+		t.Fatal(code)
+	}
+	if srv.App().Config.RateLimit.Rate != 1 {
+		t.Fatal(srv.App().Config.RateLimit)
+	}
+}
+
+func TestServer_Reload_InvalidLogLevel(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	srv := NewServer(app)
+
+	if err := srv.Reload(Config{Log: LogConfig{Level: "loud"}}); err == nil {
+		t.Fatal("expected error")
+	}
+	if srv.App() != app {
+		t.Fatal("config replaced")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.Shutdown
+// -----------------------------------------------------------------------------
+func TestServer_Shutdown(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	srv := NewServer(app)
+
+	// The handler signals once the request is in flight, and finishes when
+	// released.
+	started, release := make(chan struct{}), make(chan struct{})
+	srv.handler.Store(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	}))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ln) }()
+
+	replied := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("status %d", resp.StatusCode)
+			}
+		}
+		replied <- err
+	}()
+
+	<-started
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- srv.Shutdown() }()
+
+	select {
+	case err := <-shutdown:
+		t.Fatal("shutdown returned with a request in flight", err)
+	case err := <-replied:
+		t.Fatal("request finished before release", err)
+	default:
+	}
+
+	close(release)
+	if err := <-replied; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-served; err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-app.done:
+	default:
+		t.Fatal("app not closed")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.noWriteDeadlineMdl
+// -----------------------------------------------------------------------------
+func TestServer_StreamingOutlivesWriteTimeout(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{Server: ServerConfig{WriteTimeout: Duration{50 * time.Millisecond}}})
+	srv := NewServer(app)
+
+	// The reply is written after the write timeout has passed.
+	srv.handler.Store(app.noWriteDeadlineMdl(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve(ln)
+	defer srv.HTTP.Close()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "done" {
+		t.Fatal(string(body))
+	}
+}
@@ -1,27 +1,65 @@
 package application
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
 )
 
 // -----------------------------------------------------------------------------
+// DBService opens the store [DB] Driver names. Every store also holds the
+// API keys.
 func DBService(app *App) (err error) {
-	app.DBAL, err = database.Bootstrap(app.Config.DB)
-	return err
+	store, err := database.OpenStore(app.Config.DB)
+	if err != nil {
+		return err
+	}
+	app.Store, app.Keys = store, store
+	return nil
 }
 
 func NewTestDBService(conn *sql.DB) Service {
 	return func(app *App) (err error) {
-		app.DBAL = &database.DBAL{DB: conn}
-		return app.DBAL.Fresh()
+		dbal := &database.DBAL{DB: conn}
+		app.Store, app.Keys = dbal, dbal
+		return dbal.Fresh(context.Background())
+	}
+}
+
+// -----------------------------------------------------------------------------
+const lexiconRefreshInterval = 5 * time.Minute
+
+// GeneratorService serves generation from an in-memory lexicon cache that is
+// reloaded periodically. If the first load fails the generator queries the
+// database until a refresh succeeds, and /readyz reports the cache as missing.
+func GeneratorService(app *App) (err error) {
+	app.Lexicon = generator.NewLexicon(app.Store)
+	app.Generator = generator.New(app.Lexicon)
+
+	onErr := func(err error) {
+		app.Logger.Log(logger.Warn, "Failed loading lexicon cache", logger.Fields{"error": err.Error()})
+	}
+	if err := app.Lexicon.Load(context.Background(), app.Store); err != nil {
+		onErr(err)
 	}
+	go app.Lexicon.Refresh(app.Store, lexiconRefreshInterval, app.done, onErr)
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
+// DBFreshService drops and recreates the Postgres schema.
 func DBFreshService(app *App) (err error) {
-	return app.DBAL.Fresh()
+	dbal, ok := app.Store.(*database.DBAL)
+	if !ok {
+		return errors.NotSupported.WithMsg("DBFreshService needs Postgres")
+	}
+	return dbal.Fresh(context.Background())
 }
 
 // -----------------------------------------------------------------------------
@@ -1,21 +1,26 @@
 package application
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
 )
 
 type WordCreateArgs struct {
-	Word     string `json:word`
-	Language string `json:language`
-	Part     string `json:part`
+	Word     string `json:"word" validate:"required,max=64,charset=word"`
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+	Part     string `json:"part" validate:"required,max=32,charset=part"`
 }
 
 type WordCreateReply struct {
-	WordID     string     `json:wordID`
-	Word       string     `json:word`
-	Language   string     `json:language`
-	Part       string     `json:part`
+	WordID     string     `json:"wordID"`
+	Word       string     `json:"word"`
+	Language   string     `json:"language"`
+	Part       string     `json:"part"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	UpdatedAt  time.Time  `json:"updatedAt"`
 	ArchivedAt *time.Time `json:"archivedAt"`
@@ -25,20 +30,204 @@ func (app *App) WordCreate(w http.ResponseWriter, r *http.Request) {
 	args := WordCreateArgs{}
 	if err := app.decodeRequest(r, &args); err != nil {
 		app.respondApi(w, r, nil, err)
+		return
 	}
 
-	word, err := app.DBAL.WordCreate(args.Word, args.Language, args.Part)
+	word, err := app.Store.WordCreate(r.Context(), args.Word, args.Language, args.Part)
 	if err != nil {
 		app.respondApi(w, r, nil, err)
+		return
 	}
 
 	app.respondApi(w, r, WordCreateReply{
 		WordID:     word.WordID,
+		Word:       word.Word,
 		Language:   word.Language,
 		Part:       word.Part,
 		CreatedAt:  word.CreatedAt,
 		UpdatedAt:  word.UpdatedAt,
 		ArchivedAt: word.ArchivedAt,
 	}, nil)
+}
+
+type WordListArgs struct {
+	Filter database.WordFilter `json:"filter"`
+
+	// OrderBy keys are word, language, part, createdAt and updatedAt. It defaults to
+	// createdAt, oldest first.
+	OrderBy []database.OrderBy `json:"orderBy"`
+
+	// Limit defaults to 50 and may be up to 1000.
+	Limit int `json:"limit"`
+
+	// Cursor is the nextCursor or prevCursor of a previous reply.
+	Cursor string `json:"cursor"`
+}
+
+type WordListReply struct {
+	Words []database.Word `json:"words"`
+
+	// NextCursor and PrevCursor fetch the pages either side, and are empty
+	// when there are none.
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+
+	// Facets counts every row matching the filter, not just this page. Only
+	// the first page, fetched without a cursor, carries them.
+	Facets *database.Facets `json:"facets"`
+}
+
+func (app *App) WordList(w http.ResponseWriter, r *http.Request) {
+	args := WordListArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+	if err := validateListLimit(args.Limit); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if len(args.OrderBy) == 0 {
+		args.OrderBy = []database.OrderBy{{Key: "createdAt"}}
+	}
+	words, page, err := app.Store.WordList(r.Context(), database.WordListArgs{
+		Filter:  args.Filter,
+		OrderBy: args.OrderBy,
+		Limit:   args.Limit,
+		Cursor:  args.Cursor,
+	})
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	var facets *database.Facets
+	if args.Cursor == "" {
+		f, err := app.Store.WordFacets(r.Context(), args.Filter)
+		if err != nil {
+			app.respondApi(w, r, nil, err)
+			return
+		}
+		facets = &f
+	}
+
+	if words == nil {
+		words = []database.Word{}
+	}
+	app.respondApi(w, r, WordListReply{
+		Words:      words,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Facets:     facets,
+	}, nil)
+}
+
+func validateListLimit(limit int) error {
+	if limit < 0 || limit > database.MaxListLimit {
+		return errors.HttpInvalidArgs.WithFields(errors.FieldError{
+			Field: "limit",
+			Code:  "OutOfRange",
+			Msg:   "must be between 0 and " + strconv.Itoa(database.MaxListLimit),
+		})
+	}
+	return nil
+}
+
+type WordSearchArgs struct {
+	Query string `json:"query" validate:"required,max=64"`
+
+	// Language and Part narrow the search when set.
+	Language string `json:"language" validate:"max=35"`
+	Part     string `json:"part" validate:"max=32"`
+
+	// Limit defaults to 20 and may be up to 100.
+	Limit int `json:"limit"`
+
+	ShowArchived bool `json:"showArchived"`
+}
+
+type WordSearchReply struct {
+	// Matches are ranked best first: exact, then prefix, then by score.
+	Matches []database.WordMatch `json:"matches"`
+}
+
+func (app *App) WordSearch(w http.ResponseWriter, r *http.Request) {
+	args := WordSearchArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+	if args.Limit < 0 || args.Limit > database.MaxSearchLimit {
+		app.respondApi(w, r, nil, errors.HttpInvalidArgs.WithFields(errors.FieldError{
+			Field: "limit",
+			Code:  "OutOfRange",
+			Msg:   "must be between 0 and " + strconv.Itoa(database.MaxSearchLimit),
+		}))
+		return
+	}
+
+	searcher, ok := app.Store.(database.Searcher)
+	if !ok {
+		app.respondApi(w, r, nil, errors.NotSupported)
+		return
+	}
+
+	matches, err := searcher.WordSearch(r.Context(), database.WordSearchArgs{
+		Query:        args.Query,
+		Language:     args.Language,
+		Part:         args.Part,
+		Limit:        args.Limit,
+		ShowArchived: args.ShowArchived,
+	})
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if matches == nil {
+		matches = []database.WordMatch{}
+	}
+	app.respondApi(w, r, WordSearchReply{Matches: matches}, nil)
+}
+
+type WordTagArgs struct {
+	WordID string `json:"wordID" validate:"required,max=36"`
+	Tag    string `json:"tag" validate:"required,max=32,charset=tag"`
+}
+
+type WordTagReply struct {
+	// Tags are the word's tags afterwards, sorted.
+	Tags []string `json:"tags"`
+}
+
+// WordTag tags a word, for generation to draw themed words.
+func (app *App) WordTag(w http.ResponseWriter, r *http.Request) {
+	app.wordSetTag(w, r, app.Store.WordTag)
+}
+
+// WordUntag removes a tag from a word.
+func (app *App) WordUntag(w http.ResponseWriter, r *http.Request) {
+	app.wordSetTag(w, r, app.Store.WordUntag)
+}
+
+func (app *App) wordSetTag(w http.ResponseWriter, r *http.Request, set func(ctx context.Context, wordID, tag string) error) {
+	args := WordTagArgs{}
+	if err := app.decodeRequest(r, &args); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	if err := set(r.Context(), args.WordID, args.Tag); err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
+
+	word, err := app.Store.WordGet(r.Context(), args.WordID)
+	if err != nil {
+		app.respondApi(w, r, nil, err)
+		return
+	}
 
+	app.respondApi(w, r, WordTagReply{Tags: word.Tags}, nil)
 }
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+// -----------------------------------------------------------------------------
+// App.WordList
+// -----------------------------------------------------------------------------
+func TestApp_WordList_LimitOutOfRange(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	for _, body := range []string{`{"limit":-1}`, `{"limit":1001}`} {
+		r := httptest.NewRequest("POST", "/wordList", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.WordList(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatal(body, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"limit"`) {
+			t.Fatal(w.Body.String())
+		}
+	}
+}
+
+func TestApp_WordList_FacetsFirstPage(t *testing.T) {
+	t.Parallel()
+	app, _, _ := newMemoryTestApp(t, 0)
+
+	list := func(body string) (reply WordListReply) {
+		r := httptest.NewRequest("POST", "/wordList", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.WordList(w, r)
+
+		var envelope struct {
+			Data WordListReply `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil || w.Code != http.StatusOK {
+			t.Fatal(w.Code, w.Body.String())
+		}
+		return envelope.Data
+	}
+
+	first := list(`{"limit":2}`)
+	if first.Facets == nil || first.Facets.Total != 4 || first.NextCursor == "" {
+		t.Fatal(first)
+	}
+	if next := list(`{"limit":2,"cursor":"` + first.NextCursor + `"}`); next.Facets != nil || len(next.Words) != 2 {
+		t.Fatal(next)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// App.WordSearch
+// -----------------------------------------------------------------------------
+func TestApp_WordSearch_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+
+	for body, field := range map[string]string{
+		`{"query":""}`:               `"query"`,
+		`{"query":"gr","limit":-1}`:  `"limit"`,
+		`{"query":"gr","limit":101}`: `"limit"`,
+	} {
+		r := httptest.NewRequest("POST", "/wordSearch", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		app.WordSearch(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatal(body, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), field) {
+			t.Fatal(body, w.Body.String())
+		}
+	}
+}
+
+func TestApp_WordSearch_NotSupported(t *testing.T) {
+	t.Parallel()
+	app, _ := newTestApp(t, Config{})
+	store := database.NewMemoryStore()
+	app.Store, app.Keys = store, store
+
+	_, secret, err := store.ApiKeyCreate(context.Background(), "reader", []string{database.ScopeLexiconRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest("POST", "/wordSearch", strings.NewReader(`{"query":"gr"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+secret)
+	w := httptest.NewRecorder()
+	app.Routes().ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotImplemented {
+		t.Fatal(w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"NotSupported"`) {
+		t.Fatal(w.Body.String())
+	}
+}
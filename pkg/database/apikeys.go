@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+const (
+	ScopeLexiconRead  = "lexicon:read"
+	ScopeLexiconWrite = "lexicon:write"
+	ScopeGenerate     = "generate"
+)
+
+var Scopes = []string{
+	ScopeLexiconRead,
+	ScopeLexiconWrite,
+	ScopeGenerate,
+}
+
+const apiKeyPrefix = "ag_"
+
+type ApiKey struct {
+	ApiKeyID  string     `json:"apiKeyID"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+
+	// DailyGenerationQuota is the number of aliases the key may generate per
+	// UTC day. Nil means unlimited.
+	DailyGenerationQuota *int `json:"dailyGenerationQuota"`
+}
+
+func (k ApiKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func hashApiKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// newApiKey makes a key with its secret, if its scopes are known.
+func newApiKey(name string, scopes []string) (apiKey ApiKey, secret string, err error) {
+	for _, scope := range scopes {
+		known := false
+		for _, s := range Scopes {
+			known = known || s == scope
+		}
+		if !known {
+			return apiKey, secret, errors.ApiKeyInvalidScope.WithMsg(scope)
+		}
+	}
+
+	apiKey.ApiKeyID = crypto.NewUUID()
+	apiKey.Name = name
+	apiKey.Scopes = scopes
+	apiKey.CreatedAt = time.Now()
+	if apiKey.Scopes == nil {
+		apiKey.Scopes = []string{}
+	}
+
+	return apiKey, apiKeyPrefix + crypto.RandAlphaNum(40), nil
+}
+
+// ApiKeyCreate mints a new key. The returned secret is only available here;
+// the database stores its hash.
+func (dbal *DBAL) ApiKeyCreate(ctx context.Context, name string, scopes []string) (apiKey ApiKey, secret string, err error) {
+	defer observeQuery("ApiKeyCreate", time.Now(), &err)
+
+	apiKey, secret, err = newApiKey(name, scopes)
+	if err != nil {
+		return apiKey, secret, err
+	}
+
+	stmt := `INSERT INTO api_keys (
+		api_key_id,
+		name,
+		key_hash,
+		scopes,
+		created_at,
+		revoked_at
+	) VALUES ($1, $2, $3, $4, $5, NULL);`
+
+	_, err = dbal.ExecContext(ctx, stmt,
+		apiKey.ApiKeyID,
+		apiKey.Name,
+		hashApiKey(secret),
+		pq.Array(apiKey.Scopes),
+		apiKey.CreatedAt,
+	)
+	if err != nil {
+		return apiKey, "", errors.UnexpectedError(err, "Failed creating api key")
+	}
+
+	return apiKey, secret, nil
+}
+
+func (dbal *DBAL) ApiKeyGet(ctx context.Context, apiKeyID string) (apiKey ApiKey, err error) {
+	defer observeQuery("ApiKeyGet", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return apiKey, errors.ApiKeyNotFound
+	}
+
+	stmt := `SELECT
+                api_key_id,
+                name,
+                scopes,
+                created_at,
+                revoked_at,
+                daily_generation_quota FROM api_keys WHERE api_key_id=$1;`
+
+	err = dbal.QueryRowContext(ctx, stmt, apiKeyID).Scan(
+		&apiKey.ApiKeyID,
+		&apiKey.Name,
+		pq.Array(&apiKey.Scopes),
+		&apiKey.CreatedAt,
+		&apiKey.RevokedAt,
+		&apiKey.DailyGenerationQuota,
+	)
+
+	if err == nil {
+		return apiKey, nil
+	}
+
+	if err == sql.ErrNoRows {
+		return apiKey, errors.ApiKeyNotFound
+	}
+
+	return apiKey, errors.UnexpectedError(err, "Failed getting api key")
+}
+
+// ApiKeyAuthenticate returns the unrevoked key matching secret.
+func (dbal *DBAL) ApiKeyAuthenticate(ctx context.Context, secret string) (apiKey ApiKey, err error) {
+	defer observeQuery("ApiKeyAuthenticate", time.Now(), &err)
+
+	stmt := `SELECT
+                api_key_id,
+                name,
+                scopes,
+                created_at,
+                revoked_at,
+                daily_generation_quota FROM api_keys WHERE key_hash=$1 AND revoked_at IS NULL;`
+
+	err = dbal.QueryRowContext(ctx, stmt, hashApiKey(secret)).Scan(
+		&apiKey.ApiKeyID,
+		&apiKey.Name,
+		pq.Array(&apiKey.Scopes),
+		&apiKey.CreatedAt,
+		&apiKey.RevokedAt,
+		&apiKey.DailyGenerationQuota,
+	)
+
+	if err == nil {
+		return apiKey, nil
+	}
+
+	if err == sql.ErrNoRows {
+		return apiKey, errors.AuthInvalid
+	}
+
+	return apiKey, errors.UnexpectedError(err, "Failed authenticating api key")
+}
+
+func (dbal DBAL) ApiKeyRevoke(ctx context.Context, apiKeyID string) (err error) {
+	defer observeQuery("ApiKeyRevoke", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return errors.ApiKeyNotFound
+	}
+
+	stmt := `UPDATE api_keys SET revoked_at=COALESCE(revoked_at, NOW()) WHERE api_key_id=$1;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, apiKeyID)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to revoke api key")
+	} else if n == 0 {
+		return errors.ApiKeyNotFound
+	}
+
+	return nil
+}
+
+func (dbal DBAL) ApiKeySetDailyGenerationQuota(ctx context.Context, apiKeyID string, quota *int) (err error) {
+	defer observeQuery("ApiKeySetDailyGenerationQuota", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return errors.ApiKeyNotFound
+	}
+
+	stmt := `UPDATE api_keys SET daily_generation_quota=$1 WHERE api_key_id=$2;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, quota, apiKeyID)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to set daily generation quota")
+	} else if n == 0 {
+		return errors.ApiKeyNotFound
+	}
+
+	return nil
+}
+
+func (dbal DBAL) ApiKeyList(ctx context.Context) (apiKeys []ApiKey, err error) {
+	defer observeQuery("ApiKeyList", time.Now(), &err)
+
+	stmt := `SELECT
+		api_key_id,
+		name,
+		scopes,
+		created_at,
+		revoked_at,
+		daily_generation_quota FROM api_keys ORDER BY created_at;`
+
+	rows, err := dbal.QueryContext(ctx, stmt)
+	if err != nil {
+		return apiKeys, errors.UnexpectedError(err, "Failed listing api keys")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		apiKey := ApiKey{}
+		if err := rows.Scan(
+			&apiKey.ApiKeyID,
+			&apiKey.Name,
+			pq.Array(&apiKey.Scopes),
+			&apiKey.CreatedAt,
+			&apiKey.RevokedAt,
+			&apiKey.DailyGenerationQuota,
+		); err != nil {
+			return apiKeys, errors.UnexpectedError(err, "Failed scanning api keys")
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return apiKeys, errors.UnexpectedError(err, "Failed iterating api key rows")
+	}
+
+	return apiKeys, err
+}
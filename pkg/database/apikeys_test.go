@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.ApiKeyCreate
+// -----------------------------------------------------------------------------
+func TestDBAL_ApiKeyCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	apiKey, secret, err := dbal.ApiKeyCreate(ctx, "frontend", []string{ScopeLexiconRead, ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validators.UUID(apiKey.ApiKeyID); err != nil {
+		t.Fatal(apiKey.ApiKeyID)
+	}
+	if secret == "" {
+		t.Fatal(secret)
+	}
+	if apiKey.Name != "frontend" {
+		t.Fatal(apiKey.Name)
+	}
+	if !apiKey.HasScope(ScopeGenerate) || apiKey.HasScope(ScopeLexiconWrite) {
+		t.Fatal(apiKey.Scopes)
+	}
+	if apiKey.RevokedAt != nil {
+		t.Fatal(apiKey.RevokedAt)
+	}
+}
+
+func TestDBAL_ApiKeyCreate_InvalidScope(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	_, _, err := dbal.ApiKeyCreate(ctx, "frontend", []string{"lexicon:admin"})
+	if !errors.ApiKeyInvalidScope.Equals(err) {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.ApiKeyAuthenticate
+// -----------------------------------------------------------------------------
+func TestDBAL_ApiKeyAuthenticate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	apiKey_in, secret, err := dbal.ApiKeyCreate(ctx, "frontend", []string{ScopeLexiconRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiKey_out, err := dbal.ApiKeyAuthenticate(ctx, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKey_out.ApiKeyID != apiKey_in.ApiKeyID {
+		t.Fatal(apiKey_out)
+	}
+	if !apiKey_out.HasScope(ScopeLexiconRead) {
+		t.Fatal(apiKey_out.Scopes)
+	}
+}
+
+func TestDBAL_ApiKeyAuthenticate_Invalid(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	_, err := dbal.ApiKeyAuthenticate(ctx, "ag_notakey")
+	if err != errors.AuthInvalid {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.ApiKeyRevoke
+// -----------------------------------------------------------------------------
+func TestDBAL_ApiKeyRevoke(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	apiKey, secret, err := dbal.ApiKeyCreate(ctx, "frontend", []string{ScopeLexiconRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbal.ApiKeyRevoke(ctx, apiKey.ApiKeyID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dbal.ApiKeyAuthenticate(ctx, secret); err != errors.AuthInvalid {
+		t.Fatal(err)
+	}
+
+	apiKey_out, err := dbal.ApiKeyGet(ctx, apiKey.ApiKeyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKey_out.RevokedAt == nil {
+		t.Fatal(apiKey_out)
+	}
+}
+
+func TestDBAL_ApiKeyRevoke_ApiKeyNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	err := dbal.ApiKeyRevoke(ctx, crypto.NewUUID())
+	if err != errors.ApiKeyNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.ApiKeyList
+// -----------------------------------------------------------------------------
+func TestDBAL_ApiKeyList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	k1, _, err := dbal.ApiKeyCreate(ctx, "frontend", []string{ScopeLexiconRead})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, _, err := dbal.ApiKeyCreate(ctx, "provisioner", []string{ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiKeys, err := dbal.ApiKeyList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(apiKeys) != 2 {
+		t.Fatal(apiKeys)
+	}
+	if apiKeys[0].ApiKeyID != k1.ApiKeyID {
+		t.Fatal(apiKeys[0])
+	}
+	if apiKeys[1].ApiKeyID != k2.ApiKeyID {
+		t.Fatal(apiKeys[1])
+	}
+}
+
+// -----------------------------------------------------------------------------
+// KeyStore
+// -----------------------------------------------------------------------------
+func testStoreApiKeys(t *testing.T, store Store) {
+	ctx := context.Background()
+	keys := store.(KeyStore)
+
+	frontend, secret, err := keys.ApiKeyCreate(ctx, "frontend", []string{ScopeLexiconRead, ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret == "" || frontend.RevokedAt != nil || frontend.DailyGenerationQuota != nil {
+		t.Fatal(frontend, secret)
+	}
+	if _, _, err := keys.ApiKeyCreate(ctx, "frontend", []string{"lexicon:admin"}); !errors.ApiKeyInvalidScope.Equals(err) {
+		t.Fatal(err)
+	}
+	provisioner, _, err := keys.ApiKeyCreate(ctx, "provisioner", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := keys.ApiKeyAuthenticate(ctx, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ApiKeyID != frontend.ApiKeyID || !got.HasScope(ScopeGenerate) || got.HasScope(ScopeLexiconWrite) {
+		t.Fatal(got)
+	}
+	if _, err := keys.ApiKeyAuthenticate(ctx, "ag_notakey"); err != errors.AuthInvalid {
+		t.Fatal(err)
+	}
+
+	quota := 10
+	if err := keys.ApiKeySetDailyGenerationQuota(ctx, provisioner.ApiKeyID, &quota); err != nil {
+		t.Fatal(err)
+	}
+	got, err = keys.ApiKeyGet(ctx, provisioner.ApiKeyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DailyGenerationQuota == nil || *got.DailyGenerationQuota != 10 || len(got.Scopes) != 0 {
+		t.Fatal(got)
+	}
+
+	if err := keys.ApiKeyRevoke(ctx, frontend.ApiKeyID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.ApiKeyAuthenticate(ctx, secret); err != errors.AuthInvalid {
+		t.Fatal(err)
+	}
+	if err := keys.ApiKeyRevoke(ctx, crypto.NewUUID()); err != errors.ApiKeyNotFound {
+		t.Fatal(err)
+	}
+	if _, err := keys.ApiKeyGet(ctx, crypto.NewUUID()); err != errors.ApiKeyNotFound {
+		t.Fatal(err)
+	}
+
+	apiKeys, err := keys.ApiKeyList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apiKeys) != 2 || apiKeys[0].ApiKeyID != frontend.ApiKeyID || apiKeys[0].RevokedAt == nil || apiKeys[1].ApiKeyID != provisioner.ApiKeyID {
+		t.Fatal(apiKeys)
+	}
+}
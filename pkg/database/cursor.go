@@ -0,0 +1,181 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+const (
+	// DefaultListLimit is the page size of list queries that don't set one.
+	DefaultListLimit = 50
+
+	// MaxListLimit caps the page size of list queries.
+	MaxListLimit = 1000
+)
+
+func listLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultListLimit
+	case limit > MaxListLimit:
+		return MaxListLimit
+	}
+	return limit
+}
+
+// Page holds the cursors of the pages either side of a list result. A cursor
+// is empty when there are no rows that way.
+type Page struct {
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+}
+
+// listCursor is the position of a row in a sorted list: its values for the
+// sort keys, then its ID to break ties. Before asks for the page ending just
+// before the row instead of the one starting after it.
+type listCursor struct {
+	Keys   []string `json:"k"`
+	Values []string `json:"v"`
+	ID     string   `json:"id"`
+	Before bool     `json:"b,omitempty"`
+}
+
+func newListCursor(sorts []OrderBy, value func(key string) string, id string, before bool) listCursor {
+	c := listCursor{ID: id, Before: before}
+	for _, s := range sorts {
+		c.Keys = append(c.Keys, s.String())
+		c.Values = append(c.Values, value(s.Key))
+	}
+	return c
+}
+
+func (c listCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor returns nil for an empty cursor. A cursor made for another sort
+// order is invalid.
+func decodeCursor(s string, sorts []OrderBy) (*listCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.InvalidCursor
+	}
+	c := &listCursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, errors.InvalidCursor
+	}
+	if validators.UUID(c.ID) != nil || len(c.Keys) != len(sorts) || len(c.Values) != len(sorts) {
+		return nil, errors.InvalidCursor
+	}
+	for i, s := range sorts {
+		if c.Keys[i] != s.String() {
+			return nil, errors.InvalidCursor
+		}
+	}
+	return c, nil
+}
+
+// checkOrderBy rejects unknown and repeated sort keys. The columns keys maps
+// to must be NOT NULL, for keyset comparisons to hold.
+func checkOrderBy(sorts []OrderBy, keys map[string]string) error {
+	seen := map[string]bool{}
+	for _, s := range sorts {
+		if _, ok := keys[s.Key]; !ok || seen[s.Key] {
+			return errors.InvalidOrderBy
+		}
+		seen[s.Key] = true
+	}
+	return nil
+}
+
+// keyset returns the ORDER BY for sorts with idColumn as the tie-breaker, and
+// adds to q the condition selecting rows after (or before) the cursor in that
+// order.
+func keyset(sorts []OrderBy, keys map[string]string, idColumn string, c *listCursor, q *dbQuery) string {
+	backward := c != nil && c.Before
+
+	columns := map[string]string{"": idColumn}
+	for k, v := range keys {
+		columns[k] = v
+	}
+	sorts = append(append([]OrderBy{}, sorts...), OrderBy{})
+
+	order := make([]OrderBy, len(sorts))
+	for i, s := range sorts {
+		order[i] = OrderBy{Key: s.Key, Desc: s.Desc != backward, NullsFirst: s.NullsFirst != backward}
+	}
+	orderBy, _ := dbBuildOrderBy(order, columns)
+
+	if c == nil {
+		return orderBy
+	}
+
+	values := append(append([]string{}, c.Values...), c.ID)
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = q.arg(v)
+	}
+
+	// (a > $1) OR (a = $1 AND b > $2) OR (a = $1 AND b = $2 AND id > $3)
+	var terms []string
+	for i, s := range order {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[order[j].Key]+" = "+placeholders[j])
+		}
+		op := " > "
+		if s.Desc {
+			op = " < "
+		}
+		parts = append(parts, columns[s.Key]+op+placeholders[i])
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	q.where("(" + strings.Join(terms, " OR ") + ")")
+	return orderBy
+}
+
+// pageCursors works out the cursors around a page, given the query's cursor,
+// whether it returned more rows than the limit, and the cursors of the first
+// and last rows of the page in list order, which are nil when it's empty.
+func pageCursors(c *listCursor, more bool, first, last *listCursor) (page Page) {
+	backward := c != nil && c.Before
+
+	hasNext, hasPrev := more, c != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		next := c
+		if last != nil {
+			next = last
+		}
+		after := *next
+		after.Before = false
+		page.NextCursor = after.encode()
+	}
+	if hasPrev {
+		prev := c
+		if first != nil {
+			prev = first
+		}
+		before := *prev
+		before.Before = true
+		page.PrevCursor = before.encode()
+	}
+	return page
+}
+
+func cursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// -----------------------------------------------------------------------------
+// keyset
+// -----------------------------------------------------------------------------
+func TestKeyset(t *testing.T) {
+	t.Parallel()
+
+	sorts := []OrderBy{{Key: "part"}, {Key: "word", Desc: true}}
+	c := &listCursor{Values: []string{"noun", "Hotel"}, ID: "id"}
+
+	q := &dbQuery{}
+	q.arg("en")
+	orderBy := keyset(sorts, WordSortKeys, "word_id", c, q)
+	if orderBy != "ORDER BY part ASC NULLS LAST, word DESC NULLS LAST, word_id ASC NULLS LAST" {
+		t.Fatal(orderBy)
+	}
+	if q.whereClause() != "WHERE ((part > $2) OR (part = $2 AND word < $3) OR (part = $2 AND word = $3 AND word_id > $4))" {
+		t.Fatal(q.whereClause())
+	}
+	if !reflect.DeepEqual(q.args, []interface{}{"en", "noun", "Hotel", "id"}) {
+		t.Fatal(q.args)
+	}
+
+	c.Before = true
+	q = &dbQuery{}
+	orderBy = keyset(sorts, WordSortKeys, "word_id", c, q)
+	if orderBy != "ORDER BY part DESC NULLS FIRST, word ASC NULLS FIRST, word_id DESC NULLS FIRST" {
+		t.Fatal(orderBy)
+	}
+	if q.whereClause() != "WHERE ((part < $1) OR (part = $1 AND word > $2) OR (part = $1 AND word = $2 AND word_id < $3))" {
+		t.Fatal(q.whereClause())
+	}
+
+	q = &dbQuery{}
+	orderBy = keyset(nil, WordSortKeys, "word_id", nil, q)
+	if orderBy != "ORDER BY word_id ASC NULLS LAST" || q.whereClause() != "" || q.args != nil {
+		t.Fatal(orderBy, q)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// decodeCursor
+// -----------------------------------------------------------------------------
+func TestDecodeCursor(t *testing.T) {
+	t.Parallel()
+
+	sorts := []OrderBy{{Key: "word", Desc: true}}
+	word := Word{WordID: crypto.NewUUID(), Word: "Hotel"}
+	c := newListCursor(sorts, word.sortValue, word.WordID, true)
+
+	out, err := decodeCursor(c.encode(), sorts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*out, c) {
+		t.Fatal(out)
+	}
+
+	if out, err := decodeCursor("", sorts); out != nil || err != nil {
+		t.Fatal(out, err)
+	}
+	for _, s := range []string{"!!", c.encode()[1:]} {
+		if _, err := decodeCursor(s, sorts); err != errors.InvalidCursor {
+			t.Fatal(s, err)
+		}
+	}
+	if _, err := decodeCursor(c.encode(), []OrderBy{{Key: "word"}}); err != errors.InvalidCursor {
+		t.Fatal(err)
+	}
+}
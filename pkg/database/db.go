@@ -1,9 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
 	_ "github.com/lib/pq"
@@ -18,35 +21,106 @@ type Config struct {
 	DBPassword string
 	DBPort     string
 	DBSSLMode  string
+
+	// Driver picks the store OpenStore opens: postgres (the default), memory
+	// or sqlite. Path is the SQLite database file.
+	Driver string
+	Path   string
+
+	// DBStatementTimeout, e.g. "30s", cancels statements that run longer.
+	// Calls may set shorter deadlines with their context. Empty or zero
+	// leaves the server's default; negative durations fail Open.
+	DBStatementTimeout string
+
+	// RequireMigrated makes Bootstrap fail on pending migrations instead of
+	// applying them, for deployments that migrate with cmd/migrate.
+	RequireMigrated bool
 }
 
 type DBAL struct {
 	*sql.DB
 }
 
-var MigrationFiles = []string{
-	migrations.CreateWordsTable,
-	migrations.CreatePatternsTable,
+var Migrations = []Migration{
+	{"create-words", migrations.CreateWordsTable, migrations.DropWordsTable},
+	{"create-patterns", migrations.CreatePatternsTable, migrations.DropPatternsTable},
+	{"create-api-keys", migrations.CreateApiKeysTable, migrations.DropApiKeysTable},
+	{"create-generation-quotas", migrations.CreateGenerationQuotas, migrations.DropGenerationQuotas},
+	{"word-search-indexes", migrations.CreateWordSearchIndexes, migrations.DropWordSearchIndexes},
+	{"create-languages", migrations.CreateLanguagesTable, migrations.DropLanguagesTable},
+	{"create-parts", migrations.CreatePartsTable, migrations.DropPartsTable},
+	{"create-pattern-slots", migrations.CreatePatternSlotsTable, migrations.DropPatternSlotsTable},
+	{"create-word-tags", migrations.CreateWordTagsTable, migrations.DropWordTagsTable},
 }
 
-func Bootstrap(config Config) (db *DBAL, err error) {
+// Open connects to the database without migrating it.
+func Open(config Config) (db *DBAL, err error) {
+	dsn, err := config.dsn()
+	if err != nil {
+		return nil, err
+	}
+
 	db = &DBAL{}
-	db.DB, err = sql.Open("postgres", fmt.Sprintf("host=%s dbname=%s user=%s password=%s port=%s sslmode=%s",
+	db.DB, err = sql.Open("postgres", dsn)
+	return db, err
+}
+
+func (config Config) dsn() (string, error) {
+	dsn := fmt.Sprintf("host=%s dbname=%s user=%s password=%s port=%s sslmode=%s",
 		config.DBHost, config.DBName, config.DBUser, config.DBPassword, config.DBPort, config.DBSSLMode,
-	))
+	)
+	if config.DBStatementTimeout == "" {
+		return dsn, nil
+	}
+
+	timeout, err := time.ParseDuration(config.DBStatementTimeout)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid DBStatementTimeout")
+	}
+	if timeout < 0 {
+		return "", errors.New("invalid DBStatementTimeout: must not be negative")
+	}
+	// Postgres reads 0 as no timeout, which only the server's default should
+	// decide.
+	if timeout == 0 {
+		return dsn, nil
+	}
+
+	// Unknown connection parameters are set on each session. Postgres counts
+	// in milliseconds, so shorter timeouts round up rather than to 0.
+	ms := (timeout + time.Millisecond - 1) / time.Millisecond
+	return dsn + fmt.Sprintf(" statement_timeout=%d", ms), nil
+}
+
+// Bootstrap opens the database and applies pending migrations, or, with
+// RequireMigrated, fails if any are pending.
+func Bootstrap(config Config) (db *DBAL, err error) {
+	db, err = Open(config)
 	if err != nil {
 		return db, err
 	}
 
-	return db, db.Migrate()
+	ctx := context.Background()
+	if config.RequireMigrated {
+		return db, db.Migrator().Check(ctx)
+	}
+	return db, db.Migrate(ctx)
+}
+
+func (dbal *DBAL) Migrator() Migrator {
+	return NewMigrator(dbal.DB, Migrations)
+}
+
+func (dbal *DBAL) Migrate(ctx context.Context) error {
+	return dbal.Migrator().Migrate(ctx)
 }
 
-func (dbal *DBAL) Migrate() error {
-	return NewMigrator(dbal.DB, MigrationFiles).Migrate()
+func (dbal *DBAL) MigrationVersion(ctx context.Context) (int, error) {
+	return dbal.Migrator().Version(ctx)
 }
 
-func (dbal *DBAL) Fresh() error {
-	return NewMigrator(dbal.DB, MigrationFiles).Fresh()
+func (dbal *DBAL) Fresh(ctx context.Context) error {
+	return dbal.Migrator().Fresh(ctx)
 }
 
 func (dbal *DBAL) Close() error {
@@ -82,16 +156,16 @@ func dbIsNullConstraintErr(err error, column string) bool {
 	return pqErr.Code == "23502" && pqErr.Column == column
 }
 
-func (dbal *DBAL) ExecOne(query string, args ...interface{}) (result sql.Result, n int, err error) {
-	return dbExecOne(dbal, query, args...)
+func (dbal *DBAL) ExecOne(ctx context.Context, query string, args ...interface{}) (result sql.Result, n int, err error) {
+	return dbExecOne(ctx, dbal, query, args...)
 }
 
 type dbExec interface {
-	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
-func dbExecOne(db dbExec, query string, args ...interface{}) (result sql.Result, n int, err error) {
-	result, err = db.Exec(query, args...)
+func dbExecOne(ctx context.Context, db dbExec, query string, args ...interface{}) (result sql.Result, n int, err error) {
+	result, err = db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return result, 0, err
 	}
@@ -99,7 +173,7 @@ func dbExecOne(db dbExec, query string, args ...interface{}) (result sql.Result,
 	if n, err := result.RowsAffected(); err != nil {
 		return result, 0, err
 	} else if n > 1 {
-		panic("update too many rows: " + string(n))
+		panic(fmt.Sprintf("update too many rows: %d", n))
 	} else {
 		return result, int(n), nil
 	}
@@ -138,8 +212,73 @@ func dbBuildOrderBy(sorts []OrderBy, keys map[string]string) (result string, ok
 	return "ORDER BY " + strings.Join(parts, ", "), true
 }
 
-func dbTX(db *sql.DB, txFunc func(*sql.Tx) error) (err error) {
-	tx, err := db.Begin()
+// dbQuery collects the conditions of a WHERE clause and the arguments of a
+// statement, numbering their placeholders in the order they're added.
+type dbQuery struct {
+	conds []string
+	args  []interface{}
+
+	// sqlite writes the statement for SQLiteStore: placeholders are ?1, ?2,
+	// and times are passed in UTC, as SQLite compares them as text.
+	sqlite bool
+}
+
+// arg adds an argument and returns its placeholder.
+func (q *dbQuery) arg(v interface{}) string {
+	if t, ok := v.(time.Time); ok && q.sqlite {
+		v = t.UTC()
+	}
+	q.args = append(q.args, v)
+	if q.sqlite {
+		return "?" + strconv.Itoa(len(q.args))
+	}
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// ilike returns the condition that column matches the LIKE pattern, ignoring
+// case. SQLite only folds the case of ASCII letters.
+func (q *dbQuery) ilike(column, pattern string) string {
+	if q.sqlite {
+		return column + " LIKE " + q.arg(pattern) + ` ESCAPE '\'`
+	}
+	return column + " ILIKE " + q.arg(pattern)
+}
+
+// in returns the condition that column is one of values.
+func (q *dbQuery) in(column string, values []string) string {
+	if !q.sqlite {
+		return column + " = ANY(" + q.arg(pq.StringArray(values)) + ")"
+	}
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = q.arg(v)
+	}
+	return column + " IN (" + strings.Join(placeholders, ", ") + ")"
+}
+
+func (q *dbQuery) where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+func (q *dbQuery) whereClause() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// dbLikeEscape escapes the wildcards of a LIKE pattern.
+func dbLikeEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func dbTX(ctx context.Context, db *sql.DB, txFunc func(*sql.Tx) error) (err error) {
+	return dbTXOptions(ctx, db, nil, txFunc)
+}
+
+// dbTXOptions is dbTX with the isolation level and read only flag of opts.
+func dbTXOptions(ctx context.Context, db *sql.DB, opts *sql.TxOptions, txFunc func(*sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, opts)
 	if err != nil {
 		return
 	}
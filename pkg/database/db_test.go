@@ -0,0 +1,37 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// Config.dsn
+// -----------------------------------------------------------------------------
+func TestConfig_DSN_StatementTimeout(t *testing.T) {
+	t.Parallel()
+	for timeout, want := range map[string]string{
+		"":      "",
+		"0s":    "",
+		"30s":   " statement_timeout=30000",
+		"500us": " statement_timeout=1",
+	} {
+		dsn, err := Config{DBStatementTimeout: timeout}.dsn()
+		if err != nil {
+			t.Fatal(timeout, err)
+		}
+		got := ""
+		if i := strings.Index(dsn, " statement_timeout="); i >= 0 {
+			got = dsn[i:]
+		}
+		if got != want {
+			t.Fatal(timeout, dsn)
+		}
+	}
+
+	for _, timeout := range []string{"-1s", "soon"} {
+		if _, err := (Config{DBStatementTimeout: timeout}).dsn(); err == nil {
+			t.Fatal(timeout)
+		}
+	}
+}
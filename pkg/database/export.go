@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// LexiconExport calls fn with language, then every part, every pattern with
+// its theme and every word with its tags, as they're read from the database. Archived words and
+// patterns are included if archived is set. An unknown language returns
+// LanguageNotFound. Rows are ordered by part, by pattern, and by part then
+// word, so exports of the same lexicon are identical. An error from fn stops
+// the export and is returned as is. The rows are read in one read only
+// REPEATABLE READ transaction, so they're a consistent snapshot.
+func (dbal *DBAL) LexiconExport(ctx context.Context, language string, archived bool, fn func(row LexiconRow) error) (err error) {
+	defer observeQuery("LexiconExport", time.Now(), &err)
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	return dbTXOptions(ctx, dbal.DB, opts, func(tx *sql.Tx) error {
+		row := LexiconRow{Kind: RowKindLanguage, Language: language, Separator: new(string)}
+		err := tx.QueryRowContext(ctx, `SELECT
+			name,
+			script,
+			direction,
+			separator,
+			archived_at IS NOT NULL FROM languages
+			WHERE code=$1;`, language).Scan(&row.Name, &row.Script, &row.Direction, row.Separator, &row.Archived)
+		if err == sql.ErrNoRows {
+			return errors.LanguageNotFound
+		} else if err != nil {
+			return errors.UnexpectedError(err, "Failed exporting language")
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+
+		partRows, err := tx.QueryContext(ctx, `SELECT
+			part,
+			ud_tag FROM parts
+			WHERE language=$1
+			ORDER BY part;`, language)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed exporting parts")
+		}
+		defer partRows.Close()
+
+		for partRows.Next() {
+			row := LexiconRow{Kind: RowKindPart, Language: language}
+			if err := partRows.Scan(&row.Part, &row.UDTag); err != nil {
+				return errors.UnexpectedError(err, "Failed scanning parts")
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if err := partRows.Err(); err != nil {
+			return errors.UnexpectedError(err, "Failed iterating part rows")
+		}
+		partRows.Close()
+
+		patternRows, err := tx.QueryContext(ctx, `SELECT
+			pattern,
+			COALESCE(theme, ''),
+			archived_at IS NOT NULL FROM patterns
+			WHERE language=$1 AND ($2 OR archived_at IS NULL)
+			ORDER BY pattern;`, language, archived)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed exporting patterns")
+		}
+		defer patternRows.Close()
+
+		for patternRows.Next() {
+			row := LexiconRow{Kind: RowKindPattern, Language: language}
+			if err := patternRows.Scan(&row.Pattern, &row.Theme, &row.Archived); err != nil {
+				return errors.UnexpectedError(err, "Failed scanning patterns")
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if err := patternRows.Err(); err != nil {
+			return errors.UnexpectedError(err, "Failed iterating pattern rows")
+		}
+		patternRows.Close()
+
+		wordRows, err := tx.QueryContext(ctx, `SELECT
+			part,
+			word,
+			`+dbWordTagsColumn+`,
+			archived_at IS NOT NULL FROM words
+			WHERE language=$1 AND ($2 OR archived_at IS NULL)
+			ORDER BY part, word;`, language, archived)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed exporting words")
+		}
+		defer wordRows.Close()
+
+		for wordRows.Next() {
+			row := LexiconRow{Kind: RowKindWord, Language: language}
+			if err := wordRows.Scan(&row.Part, &row.Word, pq.Array(&row.Tags), &row.Archived); err != nil {
+				return errors.UnexpectedError(err, "Failed scanning words")
+			}
+			if len(row.Tags) == 0 {
+				row.Tags = nil
+			}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if err := wordRows.Err(); err != nil {
+			return errors.UnexpectedError(err, "Failed iterating word rows")
+		}
+
+		return nil
+	})
+}
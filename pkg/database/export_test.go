@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.LexiconExport
+// -----------------------------------------------------------------------------
+func TestDBAL_LexiconExport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	rows := []LexiconRow{
+		{Kind: RowKindPattern, Language: "en", Pattern: "adjective,noun", Theme: "fantasy"},
+		{Kind: RowKindWord, Language: "en", Part: "adjective", Word: "Grand", Tags: []string{"corporate-safe", "fantasy"}},
+		{Kind: RowKindWord, Language: "en", Part: "adjective", Word: "Pink", Archived: true},
+		{Kind: RowKindWord, Language: "en", Part: "noun", Word: "Hotel"},
+		{Kind: RowKindWord, Language: "fr", Part: "noun", Word: "Hôtel"},
+	}
+	if _, _, err := dbal.LexiconImport(ctx, rows, ImportFail); err != nil {
+		t.Fatal(err)
+	}
+
+	var exported []LexiconRow
+	collect := func(row LexiconRow) error {
+		exported = append(exported, row)
+		return nil
+	}
+
+	if err := dbal.LexiconExport(ctx, "en", true, collect); err != nil {
+		t.Fatal(err)
+	}
+	separator := " "
+	language := LexiconRow{Kind: RowKindLanguage, Language: "en", Name: "en", Direction: DirectionLTR, Separator: &separator}
+	if len(exported) != 1+len(testParts)+4 || !reflect.DeepEqual(exported[0], language) {
+		t.Fatal(exported)
+	}
+	if !reflect.DeepEqual(exported[1], LexiconRow{Kind: RowKindPart, Language: "en", Part: "adjective"}) {
+		t.Fatal(exported[1])
+	}
+	exported = exported[1+len(testParts):]
+	for i := range rows[:4] {
+		if !reflect.DeepEqual(exported[i], rows[i]) {
+			t.Fatal(i, exported[i])
+		}
+	}
+
+	exported = nil
+	if err := dbal.LexiconExport(ctx, "en", false, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 1+len(testParts)+3 {
+		t.Fatal(exported)
+	}
+
+	if err := dbal.LexiconExport(ctx, "de", true, collect); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_LexiconExport_RoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	newTestLanguages(t, dbal, "en")
+
+	if _, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindPattern, Language: "en", Pattern: "adjective,noun", Theme: "animals"},
+		{Kind: RowKindWord, Language: "en", Part: "adjective", Word: "Grand", Tags: []string{"corporate-safe"}},
+		{Kind: RowKindWord, Language: "en", Part: "noun", Word: "Dragon", Tags: []string{"animals", "fantasy"}, Archived: true},
+	}, ImportFail); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbal.LanguageSetArchive(ctx, "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	export := func(dbal *DBAL) (rows []LexiconRow) {
+		err := dbal.LexiconExport(ctx, "en", true, func(row LexiconRow) error {
+			rows = append(rows, row)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+	rows := export(dbal)
+	close()
+
+	// An export fills an empty database with the same lexicon, archived
+	// language included.
+	empty, close := NewTestDBAL(t)
+	defer close()
+	if _, _, err := empty.LexiconImport(ctx, rows, ImportFail); err != nil {
+		t.Fatal(err)
+	}
+	if again := export(empty); !reflect.DeepEqual(again, rows) {
+		t.Fatal(again, rows)
+	}
+}
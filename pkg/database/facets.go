@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Facets counts the rows matching a list filter, in total and grouped by
+// language, part and archived state. Archived is keyed "active" and
+// "archived". For patterns, Part counts the patterns with a slot for each part.
+type Facets struct {
+	Total    int            `json:"total"`
+	Language map[string]int `json:"language"`
+	Part     map[string]int `json:"part"`
+	Archived map[string]int `json:"archived"`
+}
+
+func newFacets() Facets {
+	return Facets{
+		Language: map[string]int{},
+		Part:     map[string]int{},
+		Archived: map[string]int{},
+	}
+}
+
+func archivedFacet(archived bool) string {
+	if archived {
+		return "archived"
+	}
+	return "active"
+}
+
+// add counts n rows with the given parts, for stores that count in Go. A
+// part repeated in parts counts once.
+func (f *Facets) add(language string, parts []string, archived bool, n int) {
+	f.Total += n
+	f.Language[language] += n
+	f.Archived[archivedFacet(archived)] += n
+	seen := map[string]bool{}
+	for _, part := range parts {
+		if !seen[part] {
+			seen[part] = true
+			f.Part[part] += n
+		}
+	}
+}
+
+// facetsQuery counts the rows of table matching q in total, and grouped by
+// language, archived state and part, when partColumn is set.
+func (dbal DBAL) facetsQuery(ctx context.Context, table, partColumn string, q *dbQuery, facets *Facets) error {
+	part := "NULL::text"
+	sets := "(), (language), (archived_at IS NOT NULL)"
+	if partColumn != "" {
+		part = partColumn
+		sets += ", (" + partColumn + ")"
+	}
+
+	stmt := `SELECT
+		GROUPING(language) = 0,
+		GROUPING(archived_at IS NOT NULL) = 0,
+		language,
+		` + part + `,
+		archived_at IS NOT NULL,
+		COUNT(*) FROM ` + table + ` ` + q.whereClause() + `
+		GROUP BY GROUPING SETS (` + sets + `);`
+
+	rows, err := dbal.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var byLanguage, byArchived bool
+		var language, partValue sql.NullString
+		var archived sql.NullBool
+		var n int
+		if err := rows.Scan(&byLanguage, &byArchived, &language, &partValue, &archived, &n); err != nil {
+			return err
+		}
+
+		switch {
+		case byLanguage:
+			facets.Language[language.String] = n
+		case byArchived:
+			facets.Archived[archivedFacet(archived.Bool)] = n
+		case partValue.Valid:
+			facets.Part[partValue.String] = n
+		default:
+			facets.Total = n
+		}
+	}
+	return rows.Err()
+}
+
+// WordFacets counts the words matching filter.
+func (dbal DBAL) WordFacets(ctx context.Context, filter WordFilter) (facets Facets, err error) {
+	defer observeQuery("WordFacets", time.Now(), &err)
+
+	q := &dbQuery{}
+	if err := filter.apply(q); err != nil {
+		return facets, err
+	}
+
+	facets = newFacets()
+	if err := dbal.facetsQuery(ctx, "words", "part", q, &facets); err != nil {
+		return facets, errors.UnexpectedError(err, "Failed counting words")
+	}
+	return facets, nil
+}
+
+// PatternFacets counts the patterns matching filter.
+func (dbal DBAL) PatternFacets(ctx context.Context, filter PatternFilter) (facets Facets, err error) {
+	defer observeQuery("PatternFacets", time.Now(), &err)
+
+	q := &dbQuery{}
+	if err := filter.apply(q); err != nil {
+		return facets, err
+	}
+
+	facets = newFacets()
+	if err := dbal.facetsQuery(ctx, "patterns", "", q, &facets); err != nil {
+		return facets, errors.UnexpectedError(err, "Failed counting patterns")
+	}
+
+	// A pattern using a part in several slots counts once.
+	stmt := `SELECT slot.part, COUNT(*)
+		FROM patterns, LATERAL (
+			SELECT DISTINCT part FROM pattern_slots WHERE pattern_slots.pattern_id = patterns.pattern_id
+		) AS slot
+		` + q.whereClause() + `
+		GROUP BY slot.part;`
+
+	rows, err := dbal.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return facets, errors.UnexpectedError(err, "Failed counting pattern parts")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var part string
+		var n int
+		if err := rows.Scan(&part, &n); err != nil {
+			return facets, errors.UnexpectedError(err, "Failed scanning pattern parts")
+		}
+		facets.Part[part] = n
+	}
+	if err := rows.Err(); err != nil {
+		return facets, errors.UnexpectedError(err, "Failed iterating pattern part rows")
+	}
+
+	return facets, nil
+}
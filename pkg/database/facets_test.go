@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// Store facets
+// -----------------------------------------------------------------------------
+func testStoreWordFacets(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en", "fr")
+
+	for _, w := range [][3]string{
+		{"Grand", "en", "adjective"},
+		{"Grey", "en", "adjective"},
+		{"Hotel", "en", "noun"},
+		{"Grand", "fr", "adjective"},
+	} {
+		if _, err := store.WordCreate(ctx, w[0], w[1], w[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archived, err := store.WordCreate(ctx, "Tall", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+
+	facets, err := store.WordFacets(ctx, WordFilter{Archived: ArchivedInclude})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if facets.Total != 5 {
+		t.Fatal(facets.Total)
+	}
+	if !reflect.DeepEqual(facets.Language, map[string]int{"en": 4, "fr": 1}) {
+		t.Fatal(facets.Language)
+	}
+	if !reflect.DeepEqual(facets.Part, map[string]int{"adjective": 4, "noun": 1}) {
+		t.Fatal(facets.Part)
+	}
+	if !reflect.DeepEqual(facets.Archived, map[string]int{"active": 4, "archived": 1}) {
+		t.Fatal(facets.Archived)
+	}
+
+	facets, err = store.WordFacets(ctx, WordFilter{Language: "en", WordPrefix: "gr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if facets.Total != 2 || facets.Part["adjective"] != 2 {
+		t.Fatal(facets)
+	}
+}
+
+func testStorePatternFacets(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en", "fr")
+
+	for _, p := range [][2]string{
+		{"adjective,adjective,noun", "en"},
+		{"article,noun", "en"},
+		{"adjective,noun", "fr"},
+	} {
+		if _, err := store.PatternCreate(ctx, p[0], p[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	facets, err := store.PatternFacets(ctx, PatternFilter{Language: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if facets.Total != 2 {
+		t.Fatal(facets.Total)
+	}
+	if !reflect.DeepEqual(facets.Part, map[string]int{"adjective": 1, "article": 1, "noun": 2}) {
+		t.Fatal(facets.Part)
+	}
+	if !reflect.DeepEqual(facets.Archived, map[string]int{"active": 2}) {
+		t.Fatal(facets.Archived)
+	}
+}
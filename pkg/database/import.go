@@ -0,0 +1,399 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+const (
+	RowKindLanguage = "language"
+	RowKindPart     = "part"
+	RowKindWord     = "word"
+	RowKindPattern  = "pattern"
+)
+
+// rowKindOrder is the order rows are imported in, so languages and then parts
+// exist before the words and patterns that need them.
+var rowKindOrder = map[string]int{
+	RowKindLanguage: 0,
+	RowKindPart:     1,
+	RowKindWord:     2,
+	RowKindPattern:  2,
+}
+
+// LexiconRow is a language, a part, a word or a pattern in an import or
+// export. Name, Script, Direction and Separator are set for languages, whose
+// code is Language, Part and UDTag for parts, Part, Word and Tags for words,
+// and Pattern and Theme for patterns. A language's Separator is a space when
+// nil. Parts aren't archived.
+type LexiconRow struct {
+	Kind      string   `json:"kind"`
+	Language  string   `json:"language"`
+	Name      string   `json:"name,omitempty"`
+	Script    string   `json:"script,omitempty"`
+	Direction string   `json:"direction,omitempty"`
+	Separator *string  `json:"separator,omitempty"`
+	Part      string   `json:"part,omitempty"`
+	UDTag     string   `json:"udTag,omitempty"`
+	Word      string   `json:"word,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Theme     string   `json:"theme,omitempty"`
+	Archived  bool     `json:"archived"`
+}
+
+// Strategies for rows that already exist.
+const (
+	// ImportSkip leaves the existing row untouched.
+	ImportSkip = "skip"
+	// ImportOverwrite replaces the existing row's archived state, and the
+	// language's details, part's UD tag, word's tags or pattern's theme.
+	ImportOverwrite = "overwrite"
+	// ImportFail rolls back the whole import.
+	ImportFail = "fail"
+)
+
+var ImportStrategies = []string{ImportSkip, ImportOverwrite, ImportFail}
+
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportErrored = "error"
+)
+
+type ImportResult struct {
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+}
+
+// LexiconImport creates rows in a single transaction, languages first, then
+// parts, then words and patterns in the order given. Parts, words and
+// patterns are imported into archived languages too. With ImportFail the
+// first existing row aborts the import with LanguageDuplicate, PartDuplicate,
+// WordDuplicate or PatternDuplicate, and index is that row's position in
+// rows. A row in an unknown language, using a part the language doesn't have,
+// or an invalid language or part, aborts it with LanguageNotFound,
+// PartNotFound, LanguageInvalid, PartInvalid or TagInvalid whatever the
+// strategy.
+// Otherwise index is -1.
+func (dbal *DBAL) LexiconImport(ctx context.Context, rows []LexiconRow, strategy string) (results []ImportResult, index int, err error) {
+	defer observeQuery("LexiconImport", time.Now(), &err)
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return rowKindOrder[rows[order[a]].Kind] < rowKindOrder[rows[order[b]].Kind]
+	})
+
+	index = -1
+	err = dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		results = make([]ImportResult, len(rows))
+		for _, i := range order {
+			row := rows[i]
+			var result ImportResult
+			var err error
+
+			switch row.Kind {
+			case RowKindLanguage:
+				result, err = importLanguage(ctx, tx, row, strategy)
+			case RowKindPart:
+				result, err = importPart(ctx, tx, row, strategy)
+			case RowKindWord:
+				result, err = importWord(ctx, tx, row, strategy)
+			case RowKindPattern:
+				result, err = importPattern(ctx, tx, row, strategy)
+			default:
+				panic("unknown lexicon row kind: " + row.Kind)
+			}
+			if err != nil {
+				index = i
+				return err
+			}
+			results[i] = result
+		}
+		return nil
+	})
+
+	if err != nil && !errors.LanguageDuplicate.Equals(err) && !errors.PartDuplicate.Equals(err) &&
+		!errors.WordDuplicate.Equals(err) && !errors.PatternDuplicate.Equals(err) &&
+		!errors.LanguageNotFound.Equals(err) && !errors.LanguageInvalid.Equals(err) &&
+		!errors.PartNotFound.Equals(err) && !errors.PartInvalid.Equals(err) &&
+		!errors.TagInvalid.Equals(err) {
+		return nil, -1, errors.UnexpectedError(err, "Failed importing lexicon")
+	}
+	return results, index, err
+}
+
+func importArchivedAt(row LexiconRow, now time.Time) *time.Time {
+	if row.Archived {
+		return &now
+	}
+	return nil
+}
+
+func importLanguage(ctx context.Context, tx *sql.Tx, row LexiconRow, strategy string) (result ImportResult, err error) {
+	now := time.Now()
+
+	language := Language{
+		Code:      row.Language,
+		Name:      row.Name,
+		Script:    row.Script,
+		Direction: row.Direction,
+		Separator: " ",
+	}
+	if language.Direction == "" {
+		language.Direction = DirectionLTR
+	}
+	if row.Separator != nil {
+		language.Separator = *row.Separator
+	}
+	if err := validateLanguage(language); err != nil {
+		return result, err
+	}
+
+	onConflict := `DO NOTHING`
+	if strategy == ImportOverwrite {
+		onConflict = `DO UPDATE SET
+			name=EXCLUDED.name,
+			script=EXCLUDED.script,
+			direction=EXCLUDED.direction,
+			separator=EXCLUDED.separator,
+			archived_at=CASE WHEN EXCLUDED.archived_at IS NULL THEN NULL
+				ELSE COALESCE(languages.archived_at, EXCLUDED.archived_at) END,
+			updated_at=EXCLUDED.updated_at`
+	}
+
+	stmt := `INSERT INTO languages (
+		code,
+		name,
+		script,
+		direction,
+		separator,
+		created_at,
+		updated_at,
+		archived_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $6, $7)
+	ON CONFLICT ON CONSTRAINT languages_pkey ` + onConflict + `
+	RETURNING code, xmax = 0;`
+
+	created := false
+	err = tx.QueryRowContext(ctx, stmt,
+		language.Code,
+		language.Name,
+		language.Script,
+		language.Direction,
+		language.Separator,
+		now,
+		importArchivedAt(row, now),
+	).Scan(&result.ID, &created)
+
+	switch {
+	case err == sql.ErrNoRows && strategy == ImportFail:
+		return result, errors.LanguageDuplicate
+	case err == sql.ErrNoRows:
+		result.Status = ImportSkipped
+		return result, nil
+	case err != nil:
+		return result, err
+	case created:
+		result.Status = ImportCreated
+	default:
+		result.Status = ImportUpdated
+	}
+	return result, nil
+}
+
+func importPart(ctx context.Context, tx *sql.Tx, row LexiconRow, strategy string) (result ImportResult, err error) {
+	now := time.Now()
+
+	if err := validatePartName(row.Part); err != nil {
+		return result, err
+	}
+	if err := validateUDTag(row.UDTag); err != nil {
+		return result, err
+	}
+
+	onConflict := `DO NOTHING`
+	if strategy == ImportOverwrite {
+		onConflict = `DO UPDATE SET
+			ud_tag=EXCLUDED.ud_tag,
+			updated_at=EXCLUDED.updated_at`
+	}
+
+	stmt := `INSERT INTO parts (
+		language,
+		part,
+		ud_tag,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $4)
+	ON CONFLICT ON CONSTRAINT parts_pkey ` + onConflict + `
+	RETURNING part, xmax = 0;`
+
+	created := false
+	err = tx.QueryRowContext(ctx, stmt,
+		row.Language,
+		row.Part,
+		row.UDTag,
+		now,
+	).Scan(&result.ID, &created)
+
+	switch {
+	case err == sql.ErrNoRows && strategy == ImportFail:
+		return result, errors.PartDuplicate
+	case err == sql.ErrNoRows:
+		result.Status = ImportSkipped
+		return result, nil
+	case dbIsForeignKeyErr(err, "parts_language_fkey"):
+		return result, errors.LanguageNotFound
+	case err != nil:
+		return result, err
+	case created:
+		result.Status = ImportCreated
+	default:
+		result.Status = ImportUpdated
+	}
+	return result, nil
+}
+
+// importWordTags sets the tags of a word imported as row, replacing any it
+// had.
+func importWordTags(ctx context.Context, tx *sql.Tx, wordID string, row LexiconRow, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM word_tags WHERE word_id=$1;`, wordID); err != nil {
+		return err
+	}
+	for _, tag := range row.Tags {
+		_, err := tx.ExecContext(ctx, `INSERT INTO word_tags (word_id, tag, created_at) VALUES ($1, $2, $3)
+			ON CONFLICT (word_id, tag) DO NOTHING;`, wordID, tag, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func importWord(ctx context.Context, tx *sql.Tx, row LexiconRow, strategy string) (result ImportResult, err error) {
+	now := time.Now()
+
+	for _, tag := range row.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return result, err
+		}
+	}
+
+	onConflict := `DO NOTHING`
+	if strategy == ImportOverwrite {
+		onConflict = `DO UPDATE SET
+			archived_at=CASE WHEN EXCLUDED.archived_at IS NULL THEN NULL
+				ELSE COALESCE(words.archived_at, EXCLUDED.archived_at) END,
+			updated_at=EXCLUDED.updated_at`
+	}
+
+	stmt := `INSERT INTO words (
+		word_id,
+		word,
+		language,
+		part,
+		created_at,
+		updated_at,
+		archived_at
+	) VALUES ($1, $2, $3, $4, $5, $5, $6)
+	ON CONFLICT ON CONSTRAINT words_language_part_word ` + onConflict + `
+	RETURNING word_id, xmax = 0;`
+
+	created := false
+	err = tx.QueryRowContext(ctx, stmt,
+		crypto.NewUUID(),
+		row.Word,
+		row.Language,
+		row.Part,
+		now,
+		importArchivedAt(row, now),
+	).Scan(&result.ID, &created)
+
+	switch {
+	case err == sql.ErrNoRows && strategy == ImportFail:
+		return result, errors.WordDuplicate
+	case err == sql.ErrNoRows:
+		result.Status = ImportSkipped
+		return result, nil
+	case dbIsForeignKeyErr(err, "words_language_fkey"):
+		return result, errors.LanguageNotFound
+	case dbIsForeignKeyErr(err, "words_part_fkey"):
+		return result, errors.PartNotFound.WithMsg(row.Part)
+	case err != nil:
+		return result, err
+	case created:
+		result.Status = ImportCreated
+	default:
+		result.Status = ImportUpdated
+	}
+	return result, importWordTags(ctx, tx, result.ID, row, now)
+}
+
+func importPattern(ctx context.Context, tx *sql.Tx, row LexiconRow, strategy string) (result ImportResult, err error) {
+	now := time.Now()
+
+	if row.Theme != "" {
+		if err := ValidateTag(row.Theme); err != nil {
+			return result, err
+		}
+	}
+
+	onConflict := `DO NOTHING`
+	if strategy == ImportOverwrite {
+		onConflict = `DO UPDATE SET
+			theme=EXCLUDED.theme,
+			archived_at=CASE WHEN EXCLUDED.archived_at IS NULL THEN NULL
+				ELSE COALESCE(patterns.archived_at, EXCLUDED.archived_at) END,
+			updated_at=EXCLUDED.updated_at`
+	}
+
+	stmt := `INSERT INTO patterns (
+		pattern_id,
+		pattern,
+		language,
+		theme,
+		created_at,
+		updated_at,
+		archived_at
+	) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $5, $6)
+	ON CONFLICT ON CONSTRAINT patterns_pattern_language ` + onConflict + `
+	RETURNING pattern_id, xmax = 0;`
+
+	created := false
+	err = tx.QueryRowContext(ctx, stmt,
+		crypto.NewUUID(),
+		row.Pattern,
+		row.Language,
+		row.Theme,
+		now,
+		importArchivedAt(row, now),
+	).Scan(&result.ID, &created)
+
+	switch {
+	case err == sql.ErrNoRows && strategy == ImportFail:
+		return result, errors.PatternDuplicate
+	case err == sql.ErrNoRows:
+		result.Status = ImportSkipped
+		return result, nil
+	case dbIsForeignKeyErr(err, "patterns_language_fkey"):
+		return result, errors.LanguageNotFound
+	case err != nil:
+		return result, err
+	case created:
+		result.Status = ImportCreated
+		err = dbSetPatternSlots(ctx, tx, result.ID, row.Pattern, row.Language)
+	default:
+		result.Status = ImportUpdated
+	}
+	return result, err
+}
@@ -0,0 +1,272 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.LexiconImport
+// -----------------------------------------------------------------------------
+func TestDBAL_LexiconImport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective"},
+		{Kind: RowKindWord, Word: "Pink", Language: "en", Part: "adjective", Archived: true},
+		{Kind: RowKindPattern, Pattern: "adjective", Language: "en"},
+	}, ImportFail)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range results {
+		if result.Status != ImportCreated {
+			t.Fatal(result)
+		}
+	}
+
+	word, err := dbal.WordGet(ctx, results[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if word.Word != "Pink" || word.ArchivedAt == nil {
+		t.Fatal(word)
+	}
+	if _, err := dbal.PatternGet(ctx, results[2].ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_LexiconImport_Skip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	existing, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective", Archived: true},
+		{Kind: RowKindWord, Word: "Pink", Language: "en", Part: "adjective"},
+	}, ImportSkip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != ImportSkipped || results[1].Status != ImportCreated {
+		t.Fatal(results)
+	}
+
+	word, err := dbal.WordGet(ctx, existing.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if word.ArchivedAt != nil {
+		t.Fatal(word.ArchivedAt)
+	}
+}
+
+func TestDBAL_LexiconImport_Overwrite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	existing, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective", Archived: true},
+	}, ImportOverwrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Status != ImportUpdated || results[0].ID != existing.WordID {
+		t.Fatal(results)
+	}
+
+	word, err := dbal.WordGet(ctx, existing.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if word.ArchivedAt == nil {
+		t.Fatal("not archived")
+	}
+}
+
+func TestDBAL_LexiconImport_Fail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if _, err := dbal.PatternCreate(ctx, "adjective", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, index, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective"},
+		{Kind: RowKindPattern, Pattern: "adjective", Language: "en"},
+	}, ImportFail)
+	if err != errors.PatternDuplicate {
+		t.Fatal(err)
+	}
+	if index != 1 {
+		t.Fatal(index)
+	}
+
+	if _, err := dbal.WordRandom(ctx, "en", "adjective", TagFilter{}); err != errors.WordNotFound {
+		t.Fatal("import wasn't rolled back", err)
+	}
+}
+
+func TestDBAL_LexiconImport_LanguageNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, index, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective"},
+		{Kind: RowKindWord, Word: "Grand", Language: "fr", Part: "adjective"},
+	}, ImportSkip)
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if index != 1 {
+		t.Fatal(index)
+	}
+
+	if _, err := dbal.WordRandom(ctx, "en", "adjective", TagFilter{}); err != errors.WordNotFound {
+		t.Fatal("import wasn't rolled back", err)
+	}
+}
+
+func TestDBAL_LexiconImport_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, index, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective"},
+		{Kind: RowKindPattern, Pattern: "adjective,animal", Language: "en"},
+	}, ImportSkip)
+	if !errors.PartNotFound.Equals(err) {
+		t.Fatal(err)
+	}
+	if index != 1 {
+		t.Fatal(index)
+	}
+
+	if _, err := dbal.WordRandom(ctx, "en", "adjective", TagFilter{}); err != errors.WordNotFound {
+		t.Fatal("import wasn't rolled back", err)
+	}
+}
+
+func TestDBAL_LexiconImport_Language(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	separator := ""
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindLanguage, Language: "ja", Name: "Japanese", Script: "Jpan", Separator: &separator, Archived: true},
+	}, ImportFail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Status != ImportCreated || results[0].ID != "ja" {
+		t.Fatal(results)
+	}
+	language, err := dbal.LanguageGet(ctx, "ja")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if language.Separator != "" || language.Direction != DirectionLTR || language.ArchivedAt == nil {
+		t.Fatal(language)
+	}
+
+	// Languages are imported before the words that come before them.
+	_, index, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindWord, Word: "Grand", Language: "en", Part: "adjective"},
+		{Kind: RowKindLanguage, Language: "en", Name: "English"},
+	}, ImportFail)
+	if err != errors.LanguageDuplicate {
+		t.Fatal(err)
+	}
+	if index != 1 {
+		t.Fatal(index)
+	}
+}
+
+func TestDBAL_LexiconImport_Part(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	// Languages and parts are imported before the words and patterns that
+	// need them.
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindPattern, Pattern: "farbe", Language: "de"},
+		{Kind: RowKindWord, Word: "Rot", Language: "de", Part: "farbe"},
+		{Kind: RowKindPart, Part: "farbe", UDTag: "ADJ", Language: "de"},
+		{Kind: RowKindLanguage, Language: "de", Name: "Deutsch"},
+	}, ImportFail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if result.Status != ImportCreated {
+			t.Fatal(results)
+		}
+	}
+	part, err := dbal.PartGet(ctx, "de", "farbe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.UDTag != "ADJ" {
+		t.Fatal(part)
+	}
+
+	results, _, err = dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindPart, Part: "farbe", Language: "de"},
+	}, ImportOverwrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Status != ImportUpdated {
+		t.Fatal(results)
+	}
+	if part, err = dbal.PartGet(ctx, "de", "farbe"); err != nil || part.UDTag != "" {
+		t.Fatal(part, err)
+	}
+
+	_, index, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindPart, Part: "farbe", Language: "de"},
+	}, ImportFail)
+	if err != errors.PartDuplicate || index != 0 {
+		t.Fatal(err, index)
+	}
+}
@@ -1,17 +1,290 @@
 package database
 
-import "github.com/timaraxian/alias-gen/pkg/errors"
-
-func (dbal *DBAL) GetDistinctLanguages() (languages []string, err error) {
-	stmt := `SELECT DISTINCT
-		p.language
-		FROM words w
-		INNER JOIN patterns p
-		ON w.language = p.language
-		WHERE p.archived_at IS NULL AND w.archived_at IS NULL
-		ORDER BY p.language;`
-
-	rows, err := dbal.Query(stmt)
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Writing directions.
+const (
+	DirectionLTR = "ltr"
+	DirectionRTL = "rtl"
+)
+
+// Language is a language words and patterns belong to. Code is a BCP 47 tag,
+// e.g. "en" or "pt-BR", and Script an ISO 15924 code, e.g. "Latn", or empty
+// when unknown. Separator joins the words of the language's aliases.
+type Language struct {
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Script     string     `json:"script"`
+	Direction  string     `json:"direction"`
+	Separator  string     `json:"separator"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	ArchivedAt *time.Time `json:"archivedAt"`
+}
+
+// validateLanguageCode checks the shape of a BCP 47 tag: a 2 to 8 letter
+// primary subtag, or "x" or "i" for private use and grandfathered tags,
+// followed by subtags of 1 to 8 letters and digits.
+func validateLanguageCode(code string) error {
+	subtags := strings.Split(code, "-")
+	for i, subtag := range subtags {
+		if len(subtag) < 1 || len(subtag) > 8 {
+			return errors.LanguageInvalid.WithMsg("malformed code " + code)
+		}
+		for _, r := range subtag {
+			if r >= utf8.RuneSelf || !(unicode.IsLetter(r) || i > 0 && unicode.IsDigit(r)) {
+				return errors.LanguageInvalid.WithMsg("malformed code " + code)
+			}
+		}
+		if i == 0 && len(subtag) == 1 && subtag != "x" && subtag != "i" {
+			return errors.LanguageInvalid.WithMsg("malformed code " + code)
+		}
+	}
+	return nil
+}
+
+func validateLanguage(language Language) error {
+	if err := validateLanguageCode(language.Code); err != nil {
+		return err
+	}
+	if strings.TrimSpace(language.Name) == "" {
+		return errors.LanguageInvalid.WithMsg("name is required")
+	}
+	if language.Direction != DirectionLTR && language.Direction != DirectionRTL {
+		return errors.LanguageInvalid.WithMsg("direction must be ltr or rtl")
+	}
+	return nil
+}
+
+// dbCheckLanguageActive fails unless code is an unarchived language. Archived
+// languages take no new words, patterns or parts. The statement suits both
+// Postgres and SQLite.
+func dbCheckLanguageActive(ctx context.Context, db dbQueryRow, code string) error {
+	var archived bool
+	err := db.QueryRowContext(ctx, `SELECT archived_at IS NOT NULL FROM languages WHERE code=$1;`, code).Scan(&archived)
+	switch {
+	case err == sql.ErrNoRows:
+		return errors.LanguageNotFound
+	case err != nil:
+		return errors.UnexpectedError(err, "Failed checking language")
+	case archived:
+		return errors.LanguageArchived
+	}
+	return nil
+}
+
+// LanguageCreate adds a language. Direction defaults to left to right.
+func (dbal *DBAL) LanguageCreate(ctx context.Context, language_in Language) (language Language, err error) {
+	defer observeQuery("LanguageCreate", time.Now(), &err)
+
+	language = language_in
+	if language.Direction == "" {
+		language.Direction = DirectionLTR
+	}
+	if err := validateLanguage(language); err != nil {
+		return language, err
+	}
+
+	language.CreatedAt = time.Now()
+	language.UpdatedAt = language.CreatedAt
+	language.ArchivedAt = nil
+
+	stmt := `INSERT INTO languages (
+		code,
+		name,
+		script,
+		direction,
+		separator,
+		created_at,
+		updated_at,
+		archived_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, NULL);`
+
+	_, err = dbal.ExecContext(ctx, stmt,
+		language.Code,
+		language.Name,
+		language.Script,
+		language.Direction,
+		language.Separator,
+		language.CreatedAt,
+		language.UpdatedAt,
+	)
+
+	if err == nil {
+		return language, nil
+	}
+
+	if dbIsDuplicateErr(err, "languages_pkey") {
+		return language, errors.LanguageDuplicate
+	}
+
+	return language, errors.UnexpectedError(err, "Failed creating language")
+}
+
+func (dbal *DBAL) LanguageGet(ctx context.Context, code string) (language Language, err error) {
+	defer observeQuery("LanguageGet", time.Now(), &err)
+
+	stmt := `SELECT
+                code,
+                name,
+                script,
+                direction,
+                separator,
+                created_at,
+                updated_at,
+                archived_at FROM languages WHERE code=$1;`
+
+	err = dbal.QueryRowContext(ctx, stmt, code).Scan(
+		&language.Code,
+		&language.Name,
+		&language.Script,
+		&language.Direction,
+		&language.Separator,
+		&language.CreatedAt,
+		&language.UpdatedAt,
+		&language.ArchivedAt,
+	)
+
+	if err == nil {
+		return language, nil
+	}
+
+	if err == sql.ErrNoRows {
+		return language, errors.LanguageNotFound
+	}
+
+	return language, errors.UnexpectedError(err, "Failed getting language")
+}
+
+// LanguageList returns the languages ordered by code.
+func (dbal DBAL) LanguageList(ctx context.Context, showArchived bool) (languages []Language, err error) {
+	defer observeQuery("LanguageList", time.Now(), &err)
+
+	stmt := `SELECT
+                code,
+                name,
+                script,
+                direction,
+                separator,
+                created_at,
+                updated_at,
+                archived_at FROM languages
+                WHERE $1 OR archived_at IS NULL
+                ORDER BY code;`
+
+	rows, err := dbal.QueryContext(ctx, stmt, showArchived)
+	if err != nil {
+		return languages, errors.UnexpectedError(err, "Failed listing languages")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var language Language
+		if err := rows.Scan(
+			&language.Code,
+			&language.Name,
+			&language.Script,
+			&language.Direction,
+			&language.Separator,
+			&language.CreatedAt,
+			&language.UpdatedAt,
+			&language.ArchivedAt,
+		); err != nil {
+			return languages, errors.UnexpectedError(err, "Failed scanning languages")
+		}
+		languages = append(languages, language)
+	}
+
+	if err := rows.Err(); err != nil {
+		return languages, errors.UnexpectedError(err, "Failed iterating languages")
+	}
+
+	return languages, nil
+}
+
+// LanguageUpdate sets the name, script, direction and separator of the
+// unarchived language with language.Code.
+func (dbal DBAL) LanguageUpdate(ctx context.Context, language Language) (err error) {
+	defer observeQuery("LanguageUpdate", time.Now(), &err)
+
+	if err := validateLanguage(language); err != nil {
+		return err
+	}
+
+	stmt := `UPDATE languages SET
+		name=$1,
+		script=$2,
+		direction=$3,
+		separator=$4,
+		updated_at=$5
+		WHERE code=$6 AND archived_at IS NULL;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt,
+		language.Name,
+		language.Script,
+		language.Direction,
+		language.Separator,
+		time.Now(),
+		language.Code,
+	)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to update language")
+	} else if n == 0 {
+		return errors.LanguageNotFound
+	}
+
+	return nil
+}
+
+// LanguageSetArchive hides a language from GetDistinctLanguages, and stops
+// words, patterns and parts being created in it and aliases generated from
+// it. Its words and patterns are left as they are.
+func (dbal DBAL) LanguageSetArchive(ctx context.Context, code string) (err error) {
+	defer observeQuery("LanguageSetArchive", time.Now(), &err)
+
+	stmt := `UPDATE languages SET archived_at=COALESCE(archived_at, NOW()) WHERE code=$1;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, code)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to archive language")
+	} else if n == 0 {
+		return errors.LanguageNotFound
+	}
+
+	return nil
+}
+
+func (dbal DBAL) LanguageSetUnArchive(ctx context.Context, code string) (err error) {
+	defer observeQuery("LanguageSetUnArchive", time.Now(), &err)
+
+	stmt := `UPDATE languages SET archived_at=NULL WHERE code=$1;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, code)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to unarchive language")
+	} else if n == 0 {
+		return errors.LanguageNotFound
+	}
+
+	return nil
+}
+
+// GetDistinctLanguages returns the codes of the unarchived languages.
+func (dbal *DBAL) GetDistinctLanguages(ctx context.Context) (languages []string, err error) {
+	defer observeQuery("GetDistinctLanguages", time.Now(), &err)
+
+	stmt := `SELECT code FROM languages WHERE archived_at IS NULL ORDER BY code;`
+
+	rows, err := dbal.QueryContext(ctx, stmt)
 	if err != nil {
 		return languages, errors.UnexpectedError(err, "Failed getting distinct languages")
 	}
@@ -1,39 +1,211 @@
 package database
 
-import "testing"
+import (
+	"context"
+	"testing"
 
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.LanguageCreate
+// -----------------------------------------------------------------------------
+func TestDBAL_LanguageCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	language, err := dbal.LanguageCreate(ctx, Language{Code: "ar-EG", Name: "Egyptian Arabic", Script: "Arab", Direction: DirectionRTL, Separator: " "})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := dbal.LanguageGet(ctx, "ar-EG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Egyptian Arabic" || got.Script != "Arab" || got.Direction != DirectionRTL || got.Separator != " " {
+		t.Fatal(got)
+	}
+	if !got.CreatedAt.Equal(got.UpdatedAt) {
+		t.Fatal(got.UpdatedAt)
+	}
+	if language.ArchivedAt != nil || got.ArchivedAt != nil {
+		t.Fatal(got.ArchivedAt)
+	}
+
+	// Direction defaults to ltr, and the separator may be empty.
+	ja, err := dbal.LanguageCreate(ctx, Language{Code: "ja", Name: "Japanese"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ja.Direction != DirectionLTR || ja.Separator != "" {
+		t.Fatal(ja)
+	}
+}
+
+func TestDBAL_LanguageCreate_Duplicate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, err := dbal.LanguageCreate(ctx, Language{Code: "en", Name: "English"})
+	if err != errors.LanguageDuplicate {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_LanguageCreate_Invalid(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	for _, language := range []Language{
+		{Code: "", Name: "Empty"},
+		{Code: "e", Name: "Too short"},
+		{Code: "en--GB", Name: "Empty subtag"},
+		{Code: "en-toolongsubtag", Name: "Long subtag"},
+		{Code: "1a", Name: "Digit"},
+		{Code: "en", Name: " "},
+		{Code: "en", Name: "English", Direction: "ttb"},
+	} {
+		_, err := dbal.LanguageCreate(ctx, language)
+		if !errors.LanguageInvalid.Equals(err) {
+			t.Fatal(language, err)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.LanguageGet
+// -----------------------------------------------------------------------------
+func TestDBAL_LanguageGet_LanguageNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	_, err := dbal.LanguageGet(ctx, "en")
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.LanguageUpdate
+// -----------------------------------------------------------------------------
+func TestDBAL_LanguageUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	err := dbal.LanguageUpdate(ctx, Language{Code: "en", Name: "English", Script: "Latn", Direction: DirectionLTR, Separator: "-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	language, err := dbal.LanguageGet(ctx, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if language.Name != "English" || language.Script != "Latn" || language.Separator != "-" {
+		t.Fatal(language)
+	}
+	if !language.UpdatedAt.After(language.CreatedAt) {
+		t.Fatal(language.UpdatedAt)
+	}
+
+	err = dbal.LanguageUpdate(ctx, Language{Code: "fr", Name: "French", Direction: DirectionLTR})
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.LanguageList
+// -----------------------------------------------------------------------------
+func TestDBAL_LanguageList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "fr", "en", "de")
+
+	if err := dbal.LanguageSetArchive(ctx, "de"); err != nil {
+		t.Fatal(err)
+	}
+
+	languages, err := dbal.LanguageList(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 2 || languages[0].Code != "en" || languages[1].Code != "fr" {
+		t.Fatal(languages)
+	}
+
+	languages, err = dbal.LanguageList(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 3 || languages[0].Code != "de" || languages[0].ArchivedAt == nil {
+		t.Fatal(languages)
+	}
+
+	if err := dbal.LanguageSetUnArchive(ctx, "de"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbal.LanguageSetArchive(ctx, "es"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.GetDistinctLanguages
+// -----------------------------------------------------------------------------
 func TestDBAL_GetDistinctLanguage(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "jp", "fr", "de")
 
-	_, err := dbal.WordCreate("Grand", "en", "adjective")
+	_, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.WordCreate("Ookii", "jp", "adjective")
+	_, err = dbal.WordCreate(ctx, "Ookii", "jp", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.WordCreate("Le", "fr", "article")
+	_, err = dbal.WordCreate(ctx, "Le", "fr", "article")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.PatternCreate("adjective,place", "en")
+	_, err = dbal.PatternCreate(ctx, "adjective,place", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.PatternCreate("adjective,place", "fr")
+	_, err = dbal.PatternCreate(ctx, "adjective,place", "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
+	if err := dbal.LanguageSetArchive(ctx, "de"); err != nil {
+		t.Fatal(err)
+	}
 
-	languages, err := dbal.GetDistinctLanguages()
+	languages, err := dbal.GetDistinctLanguages(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if len(languages) != 2 {
+	// Languages without patterns are listed; archived ones aren't.
+	if len(languages) != 3 {
 		t.Fatal(languages)
 	}
 	if languages[0] != "en" {
@@ -42,5 +214,8 @@ func TestDBAL_GetDistinctLanguage(t *testing.T) {
 	if languages[1] != "fr" {
 		t.Fatal(languages)
 	}
+	if languages[2] != "jp" {
+		t.Fatal(languages)
+	}
 
 }
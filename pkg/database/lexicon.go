@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+type LexiconSize struct {
+	Language string `json:"language"`
+	Part     string `json:"part"`
+	Words    int    `json:"words"`
+}
+
+// LexiconSizes counts the unarchived words of every language and part.
+func (dbal *DBAL) LexiconSizes(ctx context.Context) (sizes []LexiconSize, err error) {
+	defer observeQuery("LexiconSizes", time.Now(), &err)
+
+	stmt := `SELECT
+		language,
+		part,
+		COUNT(*)
+		FROM words
+		WHERE archived_at IS NULL
+		GROUP BY language, part
+		ORDER BY language, part;`
+
+	rows, err := dbal.QueryContext(ctx, stmt)
+	if err != nil {
+		return sizes, errors.UnexpectedError(err, "Failed counting lexicon")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		size := LexiconSize{}
+		if err := rows.Scan(
+			&size.Language,
+			&size.Part,
+			&size.Words,
+		); err != nil {
+			return sizes, errors.UnexpectedError(err, "Failed scanning lexicon sizes")
+		}
+		sizes = append(sizes, size)
+	}
+
+	if err := rows.Err(); err != nil {
+		return sizes, errors.UnexpectedError(err, "Failed iterating lexicon sizes")
+	}
+
+	return sizes, err
+}
+
+// LexiconLoad returns every unarchived word and pattern.
+func (dbal *DBAL) LexiconLoad(ctx context.Context) (words []Word, patterns []Pattern, err error) {
+	defer observeQuery("LexiconLoad", time.Now(), &err)
+
+	rows, err := dbal.QueryContext(ctx, `SELECT
+		word_id,
+		word,
+		language,
+		part,
+		created_at,
+		updated_at,
+		archived_at,
+		`+dbWordTagsColumn+` FROM words WHERE archived_at IS NULL;`)
+	if err != nil {
+		return words, patterns, errors.UnexpectedError(err, "Failed loading words")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		word := Word{}
+		if err := rows.Scan(
+			&word.WordID,
+			&word.Word,
+			&word.Language,
+			&word.Part,
+			&word.CreatedAt,
+			&word.UpdatedAt,
+			&word.ArchivedAt,
+			pq.Array(&word.Tags),
+		); err != nil {
+			return words, patterns, errors.UnexpectedError(err, "Failed scanning words")
+		}
+		words = append(words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return words, patterns, errors.UnexpectedError(err, "Failed iterating word rows")
+	}
+
+	patternRows, err := dbal.QueryContext(ctx, `SELECT
+		pattern_id,
+		pattern,
+		language,
+		created_at,
+		updated_at,
+		archived_at,
+		COALESCE(theme, '') FROM patterns WHERE archived_at IS NULL;`)
+	if err != nil {
+		return words, patterns, errors.UnexpectedError(err, "Failed loading patterns")
+	}
+	defer patternRows.Close()
+
+	for patternRows.Next() {
+		pattern := Pattern{}
+		if err := patternRows.Scan(
+			&pattern.PatternID,
+			&pattern.Pattern,
+			&pattern.Language,
+			&pattern.CreatedAt,
+			&pattern.UpdatedAt,
+			&pattern.ArchivedAt,
+			&pattern.Theme,
+		); err != nil {
+			return words, patterns, errors.UnexpectedError(err, "Failed scanning patterns")
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := patternRows.Err(); err != nil {
+		return words, patterns, errors.UnexpectedError(err, "Failed iterating pattern rows")
+	}
+
+	return words, patterns, nil
+}
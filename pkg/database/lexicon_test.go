@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// Store.LexiconSizes
+// -----------------------------------------------------------------------------
+func testStoreLexiconSizes(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	if _, err := store.WordCreate(ctx, "Grand", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "Pink", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "Hotel", "en", "noun"); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := store.WordCreate(ctx, "Inn", "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+
+	sizes, err := store.LexiconSizes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sizes) != 2 {
+		t.Fatal(sizes)
+	}
+	if sizes[0] != (LexiconSize{"en", "adjective", 2}) {
+		t.Fatal(sizes[0])
+	}
+	if sizes[1] != (LexiconSize{"en", "noun", 1}) {
+		t.Fatal(sizes[1])
+	}
+}
+
+func TestDBAL_LexiconSizes_Canceled(t *testing.T) {
+	t.Parallel()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := dbal.LexiconSizes(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.LexiconLoad
+// -----------------------------------------------------------------------------
+func TestDBAL_LexiconLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if _, err := dbal.WordCreate(ctx, "Grand", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := dbal.WordCreate(ctx, "Pink", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dbal.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.PatternCreate(ctx, "adjective", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	words, patterns, err := dbal.LexiconLoad(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(words) != 1 || words[0].Word != "Grand" {
+		t.Fatal(words)
+	}
+	if len(patterns) != 1 || patterns[0].Pattern != "adjective" {
+		t.Fatal(patterns)
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"strings"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// String is the key, prefixed with "-" when descending, as ParseOrderBy reads.
+func (s OrderBy) String() string {
+	if s.Desc {
+		return "-" + s.Key
+	}
+	return s.Key
+}
+
+// ParseOrderBy reads a comma separated list of sort keys, each prefixed with
+// "-" to sort descending, e.g. "part,-createdAt".
+func ParseOrderBy(spec string) (sorts []OrderBy, err error) {
+	for _, key := range strings.Split(spec, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		s := OrderBy{Key: strings.TrimPrefix(key, "-"), Desc: strings.HasPrefix(key, "-")}
+		if s.Key == "" {
+			return nil, errors.InvalidOrderBy
+		}
+		sorts = append(sorts, s)
+	}
+	return sorts, nil
+}
+
+// Archived filter values. Archived rows are left out unless asked for.
+const (
+	ArchivedExclude = "exclude"
+	ArchivedInclude = "include"
+	ArchivedOnly    = "only"
+)
+
+// timeFilter holds the filters words and patterns share.
+type timeFilter struct {
+	createdAfter, createdBefore *time.Time
+	updatedAfter, updatedBefore *time.Time
+	archived                    string
+}
+
+func (f timeFilter) apply(q *dbQuery) error {
+	for _, c := range []struct {
+		column, op string
+		t          *time.Time
+	}{
+		{"created_at", ">=", f.createdAfter},
+		{"created_at", "<", f.createdBefore},
+		{"updated_at", ">=", f.updatedAfter},
+		{"updated_at", "<", f.updatedBefore},
+	} {
+		if c.t != nil {
+			q.where(c.column + " " + c.op + " " + q.arg(*c.t))
+		}
+	}
+
+	switch f.archived {
+	case "", ArchivedExclude:
+		q.where("archived_at IS NULL")
+	case ArchivedOnly:
+		q.where("archived_at IS NOT NULL")
+	case ArchivedInclude:
+	default:
+		return errors.InvalidFilter
+	}
+	return nil
+}
+
+// parseFilterSpec reads a comma separated list of key=value filters, e.g.
+// "language=en,part=noun,createdAfter=2020-01-01".
+func parseFilterSpec(spec string, set map[string]func(string) error) error {
+	for _, kv := range strings.Split(spec, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		i := strings.Index(kv, "=")
+		if i < 0 {
+			return errors.InvalidFilter
+		}
+		fn, ok := set[strings.TrimSpace(kv[:i])]
+		if !ok {
+			return errors.InvalidFilter
+		}
+		if err := fn(strings.TrimSpace(kv[i+1:])); err != nil {
+			return errors.InvalidFilter
+		}
+	}
+	return nil
+}
+
+func setString(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+// setTime reads an RFC 3339 time or a date.
+func setTime(dst **time.Time) func(string) error {
+	return func(v string) error {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", v); err != nil {
+				return err
+			}
+		}
+		*dst = &t
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// ParseOrderBy
+// -----------------------------------------------------------------------------
+func TestParseOrderBy(t *testing.T) {
+	t.Parallel()
+
+	sorts, err := ParseOrderBy(" part, -createdAt,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sorts, []OrderBy{{Key: "part"}, {Key: "createdAt", Desc: true}}) {
+		t.Fatal(sorts)
+	}
+	if sorts[1].String() != "-createdAt" {
+		t.Fatal(sorts[1].String())
+	}
+
+	if sorts, err := ParseOrderBy(""); err != nil || sorts != nil {
+		t.Fatal(sorts, err)
+	}
+	if _, err := ParseOrderBy("part,-"); err != errors.InvalidOrderBy {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// ParseWordFilter
+// -----------------------------------------------------------------------------
+func TestParseWordFilter(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseWordFilter("language=en, wordPrefix=gr,createdAfter=2020-01-02,updatedBefore=2020-01-02T03:04:05Z,archived=only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Language != "en" || f.WordPrefix != "gr" || f.Archived != ArchivedOnly {
+		t.Fatal(f)
+	}
+	if !f.CreatedAfter.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal(f.CreatedAfter)
+	}
+	if !f.UpdatedBefore.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatal(f.UpdatedBefore)
+	}
+
+	for _, spec := range []string{"language", "colour=red", "createdAfter=yesterday"} {
+		if _, err := ParseWordFilter(spec); err != errors.InvalidFilter {
+			t.Fatal(spec, err)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WordFilter.apply
+// -----------------------------------------------------------------------------
+func TestWordFilter_apply(t *testing.T) {
+	t.Parallel()
+
+	q := &dbQuery{}
+	err := WordFilter{Language: "en", WordContains: "50%_off"}.apply(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.whereClause() != "WHERE language = $1 AND word ILIKE $2 AND archived_at IS NULL" {
+		t.Fatal(q.whereClause())
+	}
+	if !reflect.DeepEqual(q.args, []interface{}{"en", `%50\%\_off%`}) {
+		t.Fatal(q.args)
+	}
+}
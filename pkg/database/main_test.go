@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -14,6 +15,12 @@ var tdb *testdb.Manager
 func TestMain(m *testing.M) {
 	var config struct{ DB Config }
 	_, err := toml.DecodeFile("../../config.test.toml", &config)
+	if os.IsNotExist(err) {
+		// Without a Postgres to test against, NewTestDBAL skips the tests
+		// that need one.
+		log.Print("config.test.toml not found, skipping Postgres tests")
+		os.Exit(m.Run())
+	}
 	if err != nil {
 		log.Fatal(err, "database test config not found", "../..config.test.toml")
 	}
@@ -41,16 +48,40 @@ func TestMain(m *testing.M) {
 	status = m.Run()
 }
 
-func NewTestDBAL() (dbal *DBAL, close func()) {
+func NewTestDBAL(t *testing.T) (dbal *DBAL, close func()) {
+	if tdb == nil {
+		t.Skip("config.test.toml not found")
+	}
+
+	ctx := context.Background()
 	conn, close, err := tdb.NewConn()
 	if err != nil {
 		panic(err)
 	}
 
 	dbal = &DBAL{DB: conn}
-	err = NewMigrator(conn, MigrationFiles).Fresh()
+	err = NewMigrator(conn, Migrations).Fresh(ctx)
 	if err != nil {
 		panic(err)
 	}
 	return dbal, close
 }
+
+// testParts are the parts newTestLanguages gives each language.
+var testParts = []string{"adjective", "article", "noun", "place", "verb"}
+
+// newTestLanguages creates languages, with testParts, for a test's words and
+// patterns to use.
+func newTestLanguages(t *testing.T, store Store, codes ...string) {
+	ctx := context.Background()
+	for _, code := range codes {
+		if _, err := store.LanguageCreate(ctx, Language{Code: code, Name: code, Separator: " "}); err != nil {
+			t.Fatal(err)
+		}
+		for _, part := range testParts {
+			if _, err := store.PartCreate(ctx, Part{Language: code, Part: part}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,820 @@
+package database
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// MemoryStore is a Store held in memory, for tests and throwaway setups. It
+// enforces the same constraints as the Postgres schema.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	languages map[string]Language
+	parts     map[[2]string]Part
+	words     map[string]Word
+	patterns  map[string]Pattern
+	apiKeys   map[string]memoryApiKey
+	usage     map[[2]string]int
+	rand      *rand.Rand
+	randMu    sync.Mutex
+}
+
+var (
+	_ Store    = (*MemoryStore)(nil)
+	_ KeyStore = (*MemoryStore)(nil)
+)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		languages: map[string]Language{},
+		parts:     map[[2]string]Part{},
+		words:     map[string]Word{},
+		patterns:  map[string]Pattern{},
+		apiKeys:   map[string]memoryApiKey{},
+		usage:     map[[2]string]int{},
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+func (s *MemoryStore) intn(n int) int {
+	s.randMu.Lock()
+	defer s.randMu.Unlock()
+	return s.rand.Intn(n)
+}
+
+// -----------------------------------------------------------------------------
+
+// checkWord returns the error the words table's constraints would raise for
+// word, ignoring the row with word.WordID.
+func (s *MemoryStore) checkWord(word Word) error {
+	for _, w := range s.words {
+		if w.WordID != word.WordID && w.Language == word.Language && w.Part == word.Part && w.Word == word.Word {
+			return errors.WordDuplicate
+		}
+	}
+	if _, ok := s.languages[word.Language]; !ok {
+		return errors.LanguageNotFound
+	}
+	if _, ok := s.parts[[2]string{word.Language, word.Part}]; !ok {
+		return errors.PartNotFound
+	}
+	return nil
+}
+
+// checkLanguageActive fails unless code is an unarchived language.
+func (s *MemoryStore) checkLanguageActive(code string) error {
+	language, ok := s.languages[code]
+	if !ok {
+		return errors.LanguageNotFound
+	}
+	if language.ArchivedAt != nil {
+		return errors.LanguageArchived
+	}
+	return nil
+}
+
+func (s *MemoryStore) WordCreate(ctx context.Context, word_in, language, part string) (word Word, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLanguageActive(language); err != nil {
+		return word, err
+	}
+
+	word.WordID = crypto.NewUUID()
+	word.Word = word_in
+	word.Language = language
+	word.Part = part
+	word.CreatedAt = time.Now()
+	word.UpdatedAt = word.CreatedAt
+	word.Tags = []string{}
+
+	if err := s.checkWord(word); err != nil {
+		return word, err
+	}
+
+	s.words[word.WordID] = word
+	return word, nil
+}
+
+func (s *MemoryStore) WordGet(ctx context.Context, wordID string) (word Word, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	word, ok := s.words[wordID]
+	if !ok {
+		return word, errors.WordNotFound
+	}
+	return word, nil
+}
+
+// setWord applies set to an unarchived word, if the result is valid.
+func (s *MemoryStore) setWord(wordID string, set func(word *Word)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, ok := s.words[wordID]
+	if !ok || word.ArchivedAt != nil {
+		return errors.WordNotFound
+	}
+
+	set(&word)
+	word.UpdatedAt = time.Now()
+	if err := s.checkWord(word); err != nil {
+		return err
+	}
+
+	s.words[wordID] = word
+	return nil
+}
+
+func (s *MemoryStore) WordSetWord(ctx context.Context, wordID, word string) error {
+	return s.setWord(wordID, func(w *Word) { w.Word = word })
+}
+
+func (s *MemoryStore) WordSetLanguage(ctx context.Context, wordID, language string) error {
+	return s.setWord(wordID, func(w *Word) { w.Language = language })
+}
+
+func (s *MemoryStore) WordSetPart(ctx context.Context, wordID, part string) error {
+	return s.setWord(wordID, func(w *Word) { w.Part = part })
+}
+
+func (s *MemoryStore) WordUpdate(ctx context.Context, word Word) error {
+	return s.setWord(word.WordID, func(w *Word) {
+		w.Word, w.Language, w.Part = word.Word, word.Language, word.Part
+	})
+}
+
+func (s *MemoryStore) WordSetArchive(ctx context.Context, wordID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, ok := s.words[wordID]
+	if !ok {
+		return errors.WordNotFound
+	}
+	if word.ArchivedAt == nil {
+		now := time.Now()
+		word.ArchivedAt = &now
+	}
+	s.words[wordID] = word
+	return nil
+}
+
+func (s *MemoryStore) WordSetUnArchive(ctx context.Context, wordID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, ok := s.words[wordID]
+	if !ok {
+		return errors.WordNotFound
+	}
+	word.ArchivedAt = nil
+	s.words[wordID] = word
+	return nil
+}
+
+func (s *MemoryStore) WordList(ctx context.Context, listArgs WordListArgs) (words []Word, page Page, err error) {
+	s.mu.RLock()
+	all := make([]Word, 0, len(s.words))
+	for _, word := range s.words {
+		all = append(all, word)
+	}
+	s.mu.RUnlock()
+
+	return listWords(all, listArgs)
+}
+
+func (s *MemoryStore) WordRandom(ctx context.Context, language, part string, tags TagFilter) (word Word, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var words []Word
+	for _, w := range s.words {
+		if w.Language == language && w.Part == part && w.ArchivedAt == nil && tags.Match(w.Tags) {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		return word, errors.WordNotFound
+	}
+	return words[s.intn(len(words))], nil
+}
+
+// setTags replaces the tags of a word with those edit returns. Stored tags
+// are never changed in place, as words handed out share them.
+func (s *MemoryStore) setTags(wordID string, edit func(tags []string) []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	word, ok := s.words[wordID]
+	if !ok {
+		return errors.WordNotFound
+	}
+	word.Tags = edit(append([]string{}, word.Tags...))
+	sort.Strings(word.Tags)
+	s.words[wordID] = word
+	return nil
+}
+
+func (s *MemoryStore) WordTag(ctx context.Context, wordID, tag string) error {
+	if err := ValidateTag(tag); err != nil {
+		return err
+	}
+	return s.setTags(wordID, func(tags []string) []string {
+		for _, t := range tags {
+			if t == tag {
+				return tags
+			}
+		}
+		return append(tags, tag)
+	})
+}
+
+func (s *MemoryStore) WordUntag(ctx context.Context, wordID, tag string) error {
+	return s.setTags(wordID, func(tags []string) []string {
+		kept := tags[:0]
+		for _, t := range tags {
+			if t != tag {
+				kept = append(kept, t)
+			}
+		}
+		return kept
+	})
+}
+
+func (s *MemoryStore) WordFacets(ctx context.Context, filter WordFilter) (facets Facets, err error) {
+	if err := checkArchivedFilter(filter.Archived); err != nil {
+		return facets, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	facets = newFacets()
+	for _, word := range s.words {
+		if filter.match(word) {
+			facets.add(word.Language, []string{word.Part}, word.ArchivedAt != nil, 1)
+		}
+	}
+	return facets, nil
+}
+
+// WordArchiveBreaks returns the unarchived patterns archiving the word would
+// leave with a slot no word can fill, as DBAL.WordArchiveBreaks does.
+func (s *MemoryStore) WordArchiveBreaks(ctx context.Context, wordID string) (patterns []Pattern, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	word, ok := s.words[wordID]
+	if !ok {
+		return patterns, errors.WordNotFound
+	}
+	if word.ArchivedAt != nil {
+		return patterns, nil
+	}
+	for _, w := range s.words {
+		if w.WordID != wordID && w.Language == word.Language && w.Part == word.Part && w.ArchivedAt == nil {
+			return patterns, nil
+		}
+	}
+
+	filter := PatternFilter{Language: word.Language, Part: word.Part}
+	for _, pattern := range s.patterns {
+		if filter.match(pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Pattern != patterns[j].Pattern {
+			return patterns[i].Pattern < patterns[j].Pattern
+		}
+		return patterns[i].PatternID < patterns[j].PatternID
+	})
+	return patterns, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// checkPattern returns the error the patterns table's constraints, and the
+// check of its slots' parts, would raise for pattern, ignoring the row with
+// pattern.PatternID.
+func (s *MemoryStore) checkPattern(pattern Pattern) error {
+	for _, p := range s.patterns {
+		if p.PatternID != pattern.PatternID && p.Pattern == pattern.Pattern && p.Language == pattern.Language {
+			return errors.PatternDuplicate
+		}
+	}
+	if _, ok := s.languages[pattern.Language]; !ok {
+		return errors.LanguageNotFound
+	}
+	for _, slot := range strings.Split(pattern.Pattern, ",") {
+		if _, ok := s.parts[[2]string{pattern.Language, slot}]; !ok {
+			return errors.PartNotFound.WithMsg(slot)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) PatternCreate(ctx context.Context, pattern_in, language string) (pattern Pattern, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLanguageActive(language); err != nil {
+		return pattern, err
+	}
+
+	pattern.PatternID = crypto.NewUUID()
+	pattern.Pattern = pattern_in
+	pattern.Language = language
+	pattern.CreatedAt = time.Now()
+	pattern.UpdatedAt = pattern.CreatedAt
+
+	if err := s.checkPattern(pattern); err != nil {
+		return pattern, err
+	}
+
+	s.patterns[pattern.PatternID] = pattern
+	return pattern, nil
+}
+
+func (s *MemoryStore) PatternGet(ctx context.Context, patternID string) (pattern Pattern, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pattern, ok := s.patterns[patternID]
+	if !ok {
+		return pattern, errors.PatternNotFound
+	}
+	return pattern, nil
+}
+
+// setPattern applies set to an unarchived pattern, if the result is valid.
+func (s *MemoryStore) setPattern(patternID string, set func(pattern *Pattern)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pattern, ok := s.patterns[patternID]
+	if !ok || pattern.ArchivedAt != nil {
+		return errors.PatternNotFound
+	}
+
+	set(&pattern)
+	pattern.UpdatedAt = time.Now()
+	if err := s.checkPattern(pattern); err != nil {
+		return err
+	}
+
+	s.patterns[patternID] = pattern
+	return nil
+}
+
+func (s *MemoryStore) PatternSetPattern(ctx context.Context, patternID, pattern string) error {
+	return s.setPattern(patternID, func(p *Pattern) { p.Pattern = pattern })
+}
+
+func (s *MemoryStore) PatternSetLanguage(ctx context.Context, patternID, language string) error {
+	return s.setPattern(patternID, func(p *Pattern) { p.Language = language })
+}
+
+func (s *MemoryStore) PatternUpdate(ctx context.Context, pattern Pattern) error {
+	return s.setPattern(pattern.PatternID, func(p *Pattern) {
+		p.Pattern, p.Language = pattern.Pattern, pattern.Language
+	})
+}
+
+func (s *MemoryStore) PatternSetTheme(ctx context.Context, patternID, theme string) error {
+	if theme != "" {
+		if err := ValidateTag(theme); err != nil {
+			return err
+		}
+	}
+	return s.setPattern(patternID, func(p *Pattern) { p.Theme = theme })
+}
+
+func (s *MemoryStore) PatternSetArchive(ctx context.Context, patternID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pattern, ok := s.patterns[patternID]
+	if !ok {
+		return errors.PatternNotFound
+	}
+	if pattern.ArchivedAt == nil {
+		now := time.Now()
+		pattern.ArchivedAt = &now
+	}
+	s.patterns[patternID] = pattern
+	return nil
+}
+
+func (s *MemoryStore) PatternSetUnArchive(ctx context.Context, patternID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pattern, ok := s.patterns[patternID]
+	if !ok {
+		return errors.PatternNotFound
+	}
+	pattern.ArchivedAt = nil
+	s.patterns[patternID] = pattern
+	return nil
+}
+
+func (s *MemoryStore) PatternList(ctx context.Context, listArgs PatternListArgs) (patterns []Pattern, page Page, err error) {
+	s.mu.RLock()
+	all := make([]Pattern, 0, len(s.patterns))
+	for _, pattern := range s.patterns {
+		all = append(all, pattern)
+	}
+	s.mu.RUnlock()
+
+	return listPatterns(all, listArgs)
+}
+
+func (s *MemoryStore) PatternRandom(ctx context.Context, language string) (pattern Pattern, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var patterns []Pattern
+	for _, p := range s.patterns {
+		if p.Language == language && p.ArchivedAt == nil {
+			patterns = append(patterns, p)
+		}
+	}
+	if len(patterns) == 0 {
+		return pattern, errors.PatternNotFound
+	}
+	return patterns[s.intn(len(patterns))], nil
+}
+
+func (s *MemoryStore) PatternFacets(ctx context.Context, filter PatternFilter) (facets Facets, err error) {
+	if err := checkArchivedFilter(filter.Archived); err != nil {
+		return facets, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	facets = newFacets()
+	for _, pattern := range s.patterns {
+		if filter.match(pattern) {
+			facets.add(pattern.Language, strings.Split(pattern.Pattern, ","), pattern.ArchivedAt != nil, 1)
+		}
+	}
+	return facets, nil
+}
+
+// -----------------------------------------------------------------------------
+
+func (s *MemoryStore) LanguageCreate(ctx context.Context, language_in Language) (language Language, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	language = language_in
+	if language.Direction == "" {
+		language.Direction = DirectionLTR
+	}
+	if err := validateLanguage(language); err != nil {
+		return language, err
+	}
+	if _, ok := s.languages[language.Code]; ok {
+		return language, errors.LanguageDuplicate
+	}
+
+	language.CreatedAt = time.Now()
+	language.UpdatedAt = language.CreatedAt
+	language.ArchivedAt = nil
+
+	s.languages[language.Code] = language
+	return language, nil
+}
+
+func (s *MemoryStore) LanguageGet(ctx context.Context, code string) (language Language, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	language, ok := s.languages[code]
+	if !ok {
+		return language, errors.LanguageNotFound
+	}
+	return language, nil
+}
+
+func (s *MemoryStore) LanguageList(ctx context.Context, showArchived bool) (languages []Language, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, language := range s.languages {
+		if showArchived || language.ArchivedAt == nil {
+			languages = append(languages, language)
+		}
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Code < languages[j].Code })
+	return languages, nil
+}
+
+func (s *MemoryStore) LanguageUpdate(ctx context.Context, language Language) error {
+	if err := validateLanguage(language); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.languages[language.Code]
+	if !ok || current.ArchivedAt != nil {
+		return errors.LanguageNotFound
+	}
+
+	current.Name = language.Name
+	current.Script = language.Script
+	current.Direction = language.Direction
+	current.Separator = language.Separator
+	current.UpdatedAt = time.Now()
+	s.languages[language.Code] = current
+	return nil
+}
+
+func (s *MemoryStore) LanguageSetArchive(ctx context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	language, ok := s.languages[code]
+	if !ok {
+		return errors.LanguageNotFound
+	}
+	if language.ArchivedAt == nil {
+		now := time.Now()
+		language.ArchivedAt = &now
+	}
+	s.languages[code] = language
+	return nil
+}
+
+func (s *MemoryStore) LanguageSetUnArchive(ctx context.Context, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	language, ok := s.languages[code]
+	if !ok {
+		return errors.LanguageNotFound
+	}
+	language.ArchivedAt = nil
+	s.languages[code] = language
+	return nil
+}
+
+func (s *MemoryStore) GetDistinctLanguages(ctx context.Context) (codes []string, err error) {
+	languages, err := s.LanguageList(ctx, false)
+	for _, language := range languages {
+		codes = append(codes, language.Code)
+	}
+	return codes, err
+}
+
+// -----------------------------------------------------------------------------
+
+func (s *MemoryStore) PartCreate(ctx context.Context, part_in Part) (part Part, err error) {
+	part = part_in
+	if err := validatePartName(part.Part); err != nil {
+		return part, err
+	}
+	if err := validateUDTag(part.UDTag); err != nil {
+		return part, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := [2]string{part.Language, part.Part}
+	if _, ok := s.parts[key]; ok {
+		return part, errors.PartDuplicate
+	}
+	if err := s.checkLanguageActive(part.Language); err != nil {
+		return part, err
+	}
+
+	part.CreatedAt = time.Now()
+	part.UpdatedAt = part.CreatedAt
+	s.parts[key] = part
+	return part, nil
+}
+
+func (s *MemoryStore) PartGet(ctx context.Context, language, part_in string) (part Part, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	part, ok := s.parts[[2]string{language, part_in}]
+	if !ok {
+		return part, errors.PartNotFound
+	}
+	return part, nil
+}
+
+func (s *MemoryStore) PartList(ctx context.Context, language string) (parts []Part, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, part := range s.parts {
+		if part.Language == language {
+			parts = append(parts, part)
+		}
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Part < parts[j].Part })
+	return parts, nil
+}
+
+// -----------------------------------------------------------------------------
+
+func (s *MemoryStore) LexiconLoad(ctx context.Context) (words []Word, patterns []Pattern, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, word := range s.words {
+		if word.ArchivedAt == nil {
+			words = append(words, word)
+		}
+	}
+	for _, pattern := range s.patterns {
+		if pattern.ArchivedAt == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return words, patterns, nil
+}
+
+// LexiconSizes counts the unarchived words of every language and part.
+func (s *MemoryStore) LexiconSizes(ctx context.Context) (sizes []LexiconSize, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := map[[2]string]int{}
+	for _, word := range s.words {
+		if word.ArchivedAt == nil {
+			counts[[2]string{word.Language, word.Part}]++
+		}
+	}
+	for key, n := range counts {
+		sizes = append(sizes, LexiconSize{Language: key[0], Part: key[1], Words: n})
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].Language != sizes[j].Language {
+			return sizes[i].Language < sizes[j].Language
+		}
+		return sizes[i].Part < sizes[j].Part
+	})
+	return sizes, nil
+}
+
+// -----------------------------------------------------------------------------
+
+type memoryApiKey struct {
+	ApiKey
+	hash string
+}
+
+func (s *MemoryStore) ApiKeyCreate(ctx context.Context, name string, scopes []string) (apiKey ApiKey, secret string, err error) {
+	apiKey, secret, err = newApiKey(name, scopes)
+	if err != nil {
+		return apiKey, secret, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys[apiKey.ApiKeyID] = memoryApiKey{ApiKey: apiKey, hash: hashApiKey(secret)}
+	return apiKey, secret, nil
+}
+
+func (s *MemoryStore) ApiKeyGet(ctx context.Context, apiKeyID string) (apiKey ApiKey, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.apiKeys[apiKeyID]
+	if !ok {
+		return apiKey, errors.ApiKeyNotFound
+	}
+	return key.ApiKey, nil
+}
+
+// ApiKeyAuthenticate returns the unrevoked key matching secret.
+func (s *MemoryStore) ApiKeyAuthenticate(ctx context.Context, secret string) (apiKey ApiKey, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hash := hashApiKey(secret)
+	for _, key := range s.apiKeys {
+		if key.hash == hash && key.RevokedAt == nil {
+			return key.ApiKey, nil
+		}
+	}
+	return apiKey, errors.AuthInvalid
+}
+
+// setApiKey applies set to a key.
+func (s *MemoryStore) setApiKey(apiKeyID string, set func(apiKey *ApiKey)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[apiKeyID]
+	if !ok {
+		return errors.ApiKeyNotFound
+	}
+	set(&key.ApiKey)
+	s.apiKeys[apiKeyID] = key
+	return nil
+}
+
+func (s *MemoryStore) ApiKeyRevoke(ctx context.Context, apiKeyID string) error {
+	return s.setApiKey(apiKeyID, func(apiKey *ApiKey) {
+		if apiKey.RevokedAt == nil {
+			now := time.Now()
+			apiKey.RevokedAt = &now
+		}
+	})
+}
+
+func (s *MemoryStore) ApiKeySetDailyGenerationQuota(ctx context.Context, apiKeyID string, quota *int) error {
+	if quota != nil {
+		q := *quota
+		quota = &q
+	}
+	return s.setApiKey(apiKeyID, func(apiKey *ApiKey) { apiKey.DailyGenerationQuota = quota })
+}
+
+func (s *MemoryStore) ApiKeyList(ctx context.Context) (apiKeys []ApiKey, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		apiKeys = append(apiKeys, key.ApiKey)
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt) })
+	return apiKeys, nil
+}
+
+// GenerationQuotaConsume records n generated aliases against the key's usage
+// for the UTC day of at, as DBAL.GenerationQuotaConsume does.
+func (s *MemoryStore) GenerationQuotaConsume(ctx context.Context, apiKeyID string, n int, at time.Time) (remaining *int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[apiKeyID]
+	if !ok {
+		return nil, errors.ApiKeyNotFound
+	}
+
+	day := [2]string{apiKeyID, at.UTC().Format("2006-01-02")}
+	used := s.usage[day]
+	quota := key.DailyGenerationQuota
+	if quota != nil && used+n > *quota {
+		left := *quota - used
+		return &left, errors.QuotaExceeded
+	}
+
+	s.usage[day] = used + n
+	if quota != nil {
+		left := *quota - used - n
+		remaining = &left
+	}
+	return remaining, nil
+}
+
+// GenerationQuotaRefund takes back n aliases consumed on the UTC day of at
+// that were never generated.
+func (s *MemoryStore) GenerationQuotaRefund(ctx context.Context, apiKeyID string, n int, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	day := [2]string{apiKeyID, at.UTC().Format("2006-01-02")}
+	used, ok := s.usage[day]
+	if !ok {
+		return nil
+	}
+	if used -= n; used < 0 {
+		used = 0
+	}
+	s.usage[day] = used
+	return nil
+}
+
+// GenerationUsage returns how many aliases the key generated on the UTC day
+// of at.
+func (s *MemoryStore) GenerationUsage(ctx context.Context, apiKeyID string, at time.Time) (used int, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.usage[[2]string{apiKeyID, at.UTC().Format("2006-01-02")}], nil
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/metrics"
+)
+
+var (
+	dbQueries = metrics.NewCounterVec(
+		"aliasgen_db_queries_total",
+		"DBAL method calls by method and outcome.",
+		"method", "outcome",
+	)
+	dbQueryDuration = metrics.NewHistogramVec(
+		"aliasgen_db_query_duration_seconds",
+		"DBAL method latency in seconds.",
+		nil,
+		"method",
+	)
+)
+
+// observeQuery records a DBAL method call. Call it deferred with the method's
+// named error return so unexpected failures are counted.
+func observeQuery(method string, start time.Time, err *error) {
+	outcome := "ok"
+	if err != nil && *err != nil && errors.Unexpected.Equals(*err) {
+		outcome = "error"
+	}
+
+	dbQueries.Inc(method, outcome)
+	dbQueryDuration.Observe(time.Since(start).Seconds(), method)
+}
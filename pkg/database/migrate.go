@@ -1,89 +1,456 @@
 package database
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
-	"github.com/pkg/errors"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"time"
 )
 
+// Migration is a numbered schema change. Down reverts Up, and is empty when
+// the migration can't be reverted.
+type Migration struct {
+	Name string
+	Up   string
+	Down string
+}
+
+// Checksum identifies the SQL of Up and Down, so edits to applied migrations
+// can be detected.
+func (m Migration) Checksum() string {
+	h := sha256.New()
+	io.WriteString(h, m.Up)
+	h.Write([]byte{0})
+	io.WriteString(h, m.Down)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MigrationStatus describes a migration known to the Migrator or recorded in
+// the database. Edited is set when an applied migration's SQL has changed
+// since, and Unknown when an applied migration isn't known to the Migrator.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Edited    bool
+	Unknown   bool
+}
+
+// Migrator applies and reverts migrations, numbered from 1, recording them in
+// the schema_migrations table. Each migration runs in its own transaction,
+// and a session advisory lock keeps concurrent Migrators apart.
+//
+// When DryRun is set, the SQL that would run is written to it instead.
 type Migrator struct {
 	db         *sql.DB
-	migrations []string
+	migrations []Migration
+
+	DryRun io.Writer
 }
 
-func NewMigrator(db *sql.DB, migrations []string) Migrator {
+func NewMigrator(db *sql.DB, migrations []Migration) Migrator {
 	return Migrator{db: db, migrations: migrations}
 }
 
-func (m Migrator) Fresh() error {
-	if err := m.Drop(); err != nil {
+// migrationLockID keys the advisory lock held while migrating.
+const migrationLockID = 4747001
+
+type dbQueryContext interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt *time.Time
+}
+
+func (m Migrator) Fresh(ctx context.Context) error {
+	if err := m.Drop(ctx); err != nil {
 		return err
 	}
 
-	return m.Migrate()
+	return m.Migrate(ctx)
 }
 
-func (m Migrator) Drop() error {
-	rows, err := m.db.Query(`SELECT 'DROP TABLE IF EXISTS "' || tablename || '" CASCADE;' FROM pg_tables WHERE schemaname='public';`)
+func (m Migrator) Drop(ctx context.Context) error {
+	rows, err := m.db.QueryContext(ctx, `SELECT 'DROP TABLE IF EXISTS "' || tablename || '" CASCADE;' FROM pg_tables WHERE schemaname='public';`)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
+	var queries []string
 	for rows.Next() {
 		var query string
-		err := rows.Scan(&query)
-		if err != nil {
+		if err := rows.Scan(&query); err != nil {
 			return err
 		}
-		if _, err := m.db.Exec(query); err != nil {
+		queries = append(queries, query)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, query := range queries {
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (m Migrator) Migrate() (err error) {
-	tx, err := m.db.Begin()
+// Migrate applies every pending migration.
+func (m Migrator) Migrate(ctx context.Context) error {
+	return m.Up(ctx, 0)
+}
+
+// Up applies the next n pending migrations, or all of them when n < 1.
+func (m Migrator) Up(ctx context.Context, n int) error {
+	return m.run(ctx, func(conn *sql.Conn, applied map[int]appliedMigration) error {
+		done := 0
+		for version := 1; version <= len(m.migrations) && (n < 1 || done < n); version++ {
+			if _, ok := applied[version]; ok {
+				continue
+			}
+			if err := m.up(ctx, conn, version); err != nil {
+				return err
+			}
+			done++
+		}
+		return nil
+	})
+}
+
+// Down reverts the last n applied migrations, or the last one when n < 1.
+// Nothing is reverted if any of them has no Down.
+func (m Migrator) Down(ctx context.Context, n int) error {
+	if n < 1 {
+		n = 1
+	}
+	return m.run(ctx, func(conn *sql.Conn, applied map[int]appliedMigration) error {
+		versions := lastApplied(applied, n)
+		for _, version := range versions {
+			if m.migrations[version-1].Down == "" {
+				return fmt.Errorf("migration %d %s can't be reverted", version, m.migrations[version-1].Name)
+			}
+		}
+		for _, version := range versions {
+			if err := m.down(ctx, conn, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Redo reverts and reapplies the last applied migration.
+func (m Migrator) Redo(ctx context.Context) error {
+	return m.run(ctx, func(conn *sql.Conn, applied map[int]appliedMigration) error {
+		versions := lastApplied(applied, 1)
+		if len(versions) == 0 {
+			return nil
+		}
+		version := versions[0]
+		if m.migrations[version-1].Down == "" {
+			return fmt.Errorf("migration %d %s can't be reverted", version, m.migrations[version-1].Name)
+		}
+		if err := m.down(ctx, conn, version); err != nil {
+			return err
+		}
+		return m.up(ctx, conn, version)
+	})
+}
+
+// Status lists the known migrations in order, followed by any applied
+// migrations the Migrator doesn't know.
+func (m Migrator) Status(ctx context.Context) (statuses []MigrationStatus, err error) {
+	applied, err := m.applied(ctx, m.db)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return m.status(applied), nil
+}
+
+// Version returns the highest applied migration, or 0 when none are.
+func (m Migrator) Version(ctx context.Context) (version int, err error) {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return 0, err
+	}
+	for v := range applied {
+		if v > version {
+			version = v
+		}
 	}
+	return version, nil
+}
 
-	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE;"); err != nil {
-		tx.Rollback()
+// Check returns an error when migrations are pending, edited or unknown.
+func (m Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkStatuses(statuses); err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS migrations(version INTEGER NOT NULL);`)
+	pending := 0
+	for _, s := range statuses {
+		if !s.Applied {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d pending migrations", pending)
+	}
+	return nil
+}
+
+func (m Migrator) status(applied map[int]appliedMigration) (statuses []MigrationStatus) {
+	for i, migration := range m.migrations {
+		s := MigrationStatus{Version: i + 1, Name: migration.Name}
+		if a, ok := applied[s.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = a.appliedAt
+			s.Edited = a.checksum != migration.Checksum()
+		}
+		statuses = append(statuses, s)
+	}
+
+	var unknown []int
+	for version := range applied {
+		if version < 1 || version > len(m.migrations) {
+			unknown = append(unknown, version)
+		}
+	}
+	sort.Ints(unknown)
+	for _, version := range unknown {
+		a := applied[version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Name:      a.name,
+			Applied:   true,
+			AppliedAt: a.appliedAt,
+			Unknown:   true,
+		})
+	}
+	return statuses
+}
+
+// checkStatuses refuses to migrate a database whose history doesn't match
+// the migrations, as reverting or building on it could lose data.
+func checkStatuses(statuses []MigrationStatus) error {
+	for _, s := range statuses {
+		switch {
+		case s.Edited:
+			return fmt.Errorf("migration %d %s was edited after it was applied", s.Version, s.Name)
+		case s.Unknown:
+			return fmt.Errorf("migration %d %s is applied but unknown", s.Version, s.Name)
+		}
+	}
+	return nil
+}
+
+// lastApplied returns up to n applied versions, highest first.
+func lastApplied(applied map[int]appliedMigration, n int) (versions []int) {
+	highest := 0
+	for v := range applied {
+		if v > highest {
+			highest = v
+		}
+	}
+	for v := highest; v > 0 && len(versions) < n; v-- {
+		if _, ok := applied[v]; ok {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// run holds the migration lock on a dedicated connection while fn applies or
+// reverts migrations, once the history has been checked.
+func (m Migrator) run(ctx context.Context, fn func(conn *sql.Conn, applied map[int]appliedMigration) error) (err error) {
+	conn, err := m.db.Conn(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer conn.Close()
 
-	var version int
-	row := tx.QueryRow(`SELECT version FROM migrations LIMIT 1;`)
-	if err := row.Scan(&version); err != nil {
-		if errors.Cause(err) != sql.ErrNoRows {
-			tx.Rollback()
+	if m.DryRun == nil {
+		// Migrations may run longer than the statement timeout, as may the
+		// wait for another Migrator's lock.
+		if _, err := conn.ExecContext(ctx, `SET statement_timeout = 0;`); err != nil {
 			return err
 		}
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, migrationLockID); err != nil {
+			conn.ExecContext(context.Background(), `RESET statement_timeout;`)
+			return err
+		}
+		// The connection goes back to the pool, so it's unlocked and reset
+		// even when ctx is done.
+		defer func() {
+			_, unlockErr := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, migrationLockID)
+			if _, resetErr := conn.ExecContext(context.Background(), `RESET statement_timeout;`); unlockErr == nil {
+				unlockErr = resetErr
+			}
+			if err == nil {
+				err = unlockErr
+			}
+		}()
 
-		if _, err := tx.Exec(`INSERT INTO migrations(version) VALUES(0);`); err != nil {
-			tx.Rollback()
+		if err := m.createHistory(ctx, conn); err != nil {
 			return err
 		}
+	}
 
-		version = 0
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if err := checkStatuses(m.status(applied)); err != nil {
+		return err
 	}
 
-	for i := version; i < len(m.migrations); i++ {
-		if _, err := tx.Exec(m.migrations[i]); err != nil {
-			tx.Rollback()
+	return fn(conn, applied)
+}
+
+// createHistory creates the schema_migrations table, importing the version
+// kept by the migrations table of earlier releases.
+func (m Migrator) createHistory(ctx context.Context, conn *sql.Conn) error {
+	return dbConnTX(ctx, conn, func(tx *sql.Tx) error {
+		stmt := `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER NOT NULL PRIMARY KEY,
+			name       TEXT NOT NULL,
+			checksum   TEXT NOT NULL,
+			applied_at TIMESTAMPTZ
+		);`
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
 			return err
 		}
 
-		if _, err = tx.Exec(`UPDATE migrations SET version=$1;`, i+1); err != nil {
-			tx.Rollback()
+		var legacy bool
+		if err := tx.QueryRowContext(ctx, `SELECT to_regclass('migrations') IS NOT NULL;`).Scan(&legacy); err != nil || !legacy {
 			return err
 		}
+
+		var version int
+		err := tx.QueryRowContext(ctx, `SELECT version FROM migrations LIMIT 1;`).Scan(&version)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		for v := 1; v <= version && v <= len(m.migrations); v++ {
+			stmt := `INSERT INTO schema_migrations (version, name, checksum, applied_at)
+				VALUES ($1, $2, $3, NULL) ON CONFLICT DO NOTHING;`
+			if _, err := tx.ExecContext(ctx, stmt, v, m.migrations[v-1].Name, m.migrations[v-1].Checksum()); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `DROP TABLE migrations;`)
+		return err
+	})
+}
+
+// applied reads the migration history. A database still using the legacy
+// migrations table is read as having the first versions applied unedited.
+func (m Migrator) applied(ctx context.Context, db dbQueryContext) (applied map[int]appliedMigration, err error) {
+	applied = map[int]appliedMigration{}
+
+	var history, legacy bool
+	stmt := `SELECT to_regclass('schema_migrations') IS NOT NULL, to_regclass('migrations') IS NOT NULL;`
+	if err := db.QueryRowContext(ctx, stmt).Scan(&history, &legacy); err != nil {
+		return nil, err
 	}
 
-	return tx.Commit()
+	if !history {
+		if !legacy {
+			return applied, nil
+		}
+		var version int
+		err := db.QueryRowContext(ctx, `SELECT version FROM migrations LIMIT 1;`).Scan(&version)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		for v := 1; v <= version && v <= len(m.migrations); v++ {
+			applied[v] = appliedMigration{name: m.migrations[v-1].Name, checksum: m.migrations[v-1].Checksum()}
+		}
+		return applied, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = a
+	}
+	return applied, rows.Err()
+}
+
+func (m Migrator) up(ctx context.Context, conn *sql.Conn, version int) error {
+	migration := m.migrations[version-1]
+	if m.DryRun != nil {
+		_, err := fmt.Fprintf(m.DryRun, "-- %d %s up\n%s\n", version, migration.Name, migration.Up)
+		return err
+	}
+
+	return dbConnTX(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %d %s up: %v", version, migration.Name, err)
+		}
+		stmt := `INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4);`
+		_, err := tx.ExecContext(ctx, stmt, version, migration.Name, migration.Checksum(), time.Now())
+		return err
+	})
+}
+
+func (m Migrator) down(ctx context.Context, conn *sql.Conn, version int) error {
+	migration := m.migrations[version-1]
+	if m.DryRun != nil {
+		_, err := fmt.Fprintf(m.DryRun, "-- %d %s down\n%s\n", version, migration.Name, migration.Down)
+		return err
+	}
+
+	return dbConnTX(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("migration %d %s down: %v", version, migration.Name, err)
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version=$1;`, version)
+		return err
+	})
+}
+
+// dbConnTX is dbTX on a dedicated connection.
+func dbConnTX(ctx context.Context, conn *sql.Conn, txFunc func(*sql.Tx) error) (err error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+	err = txFunc(tx)
+	return err
 }
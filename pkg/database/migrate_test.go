@@ -0,0 +1,263 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+var testMigrations = []Migration{
+	{"create-a", `CREATE TABLE a (id INTEGER);`, `DROP TABLE a;`},
+	{"create-b", `CREATE TABLE b (id INTEGER);`, `DROP TABLE b;`},
+	{"create-c", `CREATE TABLE c (id INTEGER);`, `DROP TABLE c;`},
+}
+
+func newTestMigrator(t *testing.T, migrations []Migration) (m Migrator, close func()) {
+	if tdb == nil {
+		t.Skip("config.test.toml not found")
+	}
+
+	ctx := context.Background()
+	conn, close, err := tdb.NewConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = NewMigrator(conn, migrations)
+	if err := m.Drop(ctx); err != nil {
+		close()
+		t.Fatal(err)
+	}
+	return m, close
+}
+
+func testTableExists(t *testing.T, db *sql.DB, table string) bool {
+	var exists bool
+	if err := db.QueryRow(`SELECT to_regclass($1) IS NOT NULL;`, table).Scan(&exists); err != nil {
+		t.Fatal(err)
+	}
+	return exists
+}
+
+// -----------------------------------------------------------------------------
+// Migrator.Up
+// -----------------------------------------------------------------------------
+func TestMigrator_Up(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	if err := m.Up(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !testTableExists(t, m.db, "b") || testTableExists(t, m.db, "c") {
+		t.Fatal("expected a and b only")
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 3 || !statuses[1].Applied || statuses[1].AppliedAt == nil || statuses[2].Applied {
+		t.Fatal(statuses)
+	}
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != 3 {
+		t.Fatal(version, err)
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrator_Up_Failed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	migrations := append(testMigrations[:2:2], Migration{"broken", `CREATE TABLE a (id INTEGER);`, ``})
+	m, close := newTestMigrator(t, migrations)
+	defer close()
+
+	if err := m.Up(ctx, 0); err == nil {
+		t.Fatal(err)
+	}
+
+	// Earlier migrations stay applied.
+	if version, err := m.Version(ctx); err != nil || version != 2 {
+		t.Fatal(version, err)
+	}
+}
+
+func TestMigrator_Up_DryRun(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	var out bytes.Buffer
+	m.DryRun = &out
+	if err := m.Up(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "-- 1 create-a up") || !strings.Contains(out.String(), testMigrations[0].Up) {
+		t.Fatal(out.String())
+	}
+	if strings.Contains(out.String(), "create-b") || testTableExists(t, m.db, "a") {
+		t.Fatal(out.String())
+	}
+}
+
+func TestMigrator_Up_Legacy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	if _, err := m.db.Exec(`CREATE TABLE migrations(version INTEGER NOT NULL); INSERT INTO migrations VALUES (1); CREATE TABLE a (id INTEGER);`); err != nil {
+		t.Fatal(err)
+	}
+
+	if version, err := m.Version(ctx); err != nil || version != 1 {
+		t.Fatal(version, err)
+	}
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if testTableExists(t, m.db, "migrations") || !testTableExists(t, m.db, "c") {
+		t.Fatal("expected legacy history imported")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Migrator.Down
+// -----------------------------------------------------------------------------
+func TestMigrator_Down(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Down(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !testTableExists(t, m.db, "a") || testTableExists(t, m.db, "b") {
+		t.Fatal("expected a only")
+	}
+	if err := m.Check(ctx); err == nil {
+		t.Fatal(err)
+	}
+
+	// Down defaults to one migration.
+	if err := m.Down(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != 0 {
+		t.Fatal(version, err)
+	}
+}
+
+func TestMigrator_Down_Irreversible(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	migrations := append(testMigrations[:2:2], Migration{"create-c", testMigrations[2].Up, ""})
+	m, close := newTestMigrator(t, migrations)
+	defer close()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Down(ctx, 2); err == nil {
+		t.Fatal(err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != 3 {
+		t.Fatal(version, err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Migrator.Redo
+// -----------------------------------------------------------------------------
+func TestMigrator_Redo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.db.Exec(`INSERT INTO c VALUES (1);`); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Redo(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err := m.db.QueryRow(`SELECT count(*) FROM c;`).Scan(&n); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Migrator.Status
+// -----------------------------------------------------------------------------
+func TestMigrator_Status_Edited(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m, close := newTestMigrator(t, testMigrations)
+	defer close()
+
+	if err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	edited := append([]Migration{}, testMigrations...)
+	edited[1].Up = `CREATE TABLE b (id BIGINT);`
+	m = NewMigrator(m.db, edited[:2])
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 3 || statuses[0].Edited || !statuses[1].Edited || !statuses[2].Unknown || statuses[2].Name != "create-c" {
+		t.Fatal(statuses)
+	}
+
+	if err := m.Up(ctx, 0); err == nil {
+		t.Fatal(err)
+	}
+	if err := m.Down(ctx, 1); err == nil {
+		t.Fatal(err)
+	}
+	if err := m.Check(ctx); err == nil {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Migration.Checksum
+// -----------------------------------------------------------------------------
+func TestMigration_Checksum(t *testing.T) {
+	t.Parallel()
+	migration := testMigrations[0]
+
+	down := migration
+	down.Down = `DROP TABLE IF EXISTS a;`
+	if down.Checksum() == migration.Checksum() {
+		t.Fatal("expected an edited Down to change the checksum")
+	}
+
+	// Both halves are hashed apart, so SQL moved between them is an edit.
+	moved := Migration{Name: migration.Name, Up: migration.Up + migration.Down}
+	if moved.Checksum() == migration.Checksum() {
+		t.Fatal("expected SQL moved from Down to Up to change the checksum")
+	}
+}
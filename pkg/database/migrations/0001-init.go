@@ -28,3 +28,13 @@ archived_at TIMESTAMPTZ,
 CONSTRAINT patterns_pattern_language UNIQUE (pattern, language)
 );
 `
+
+// language=SQL
+const DropWordsTable = `
+DROP TABLE words;
+`
+
+// language=SQL
+const DropPatternsTable = `
+DROP TABLE patterns;
+`
@@ -0,0 +1,20 @@
+package migrations
+
+// language=SQL
+const CreateApiKeysTable = `
+CREATE TABLE api_keys (
+api_key_id  UUID PRIMARY KEY,
+name        TEXT NOT NULL,
+key_hash    TEXT NOT NULL,
+scopes      TEXT[] NOT NULL,
+created_at  TIMESTAMPTZ NOT NULL,
+revoked_at  TIMESTAMPTZ,
+
+CONSTRAINT api_keys_key_hash UNIQUE (key_hash)
+);
+`
+
+// language=SQL
+const DropApiKeysTable = `
+DROP TABLE api_keys;
+`
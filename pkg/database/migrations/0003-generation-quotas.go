@@ -0,0 +1,21 @@
+package migrations
+
+// language=SQL
+const CreateGenerationQuotas = `
+ALTER TABLE api_keys ADD COLUMN daily_generation_quota INTEGER;
+
+CREATE TABLE generation_usage (
+api_key_id  UUID NOT NULL REFERENCES api_keys (api_key_id),
+day         DATE NOT NULL,
+used        INTEGER NOT NULL,
+
+PRIMARY KEY (api_key_id, day)
+);
+`
+
+// language=SQL
+const DropGenerationQuotas = `
+DROP TABLE generation_usage;
+
+ALTER TABLE api_keys DROP COLUMN daily_generation_quota;
+`
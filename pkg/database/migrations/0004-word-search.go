@@ -0,0 +1,18 @@
+package migrations
+
+// language=SQL
+const CreateWordSearchIndexes = `
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX words_word_trgm ON words USING GIN (lower(word) gin_trgm_ops);
+CREATE INDEX words_word_prefix ON words (lower(word) text_pattern_ops);
+`
+
+// DropWordSearchIndexes leaves the pg_trgm extension installed, as other
+// objects in the database may depend on it.
+//
+// language=SQL
+const DropWordSearchIndexes = `
+DROP INDEX words_word_prefix;
+DROP INDEX words_word_trgm;
+`
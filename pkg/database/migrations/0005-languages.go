@@ -0,0 +1,37 @@
+package migrations
+
+// CreateLanguagesTable backfills a language for every code already used by a
+// word or pattern, named after its code, before adding the foreign keys.
+//
+// language=SQL
+const CreateLanguagesTable = `
+CREATE TABLE languages (
+code        TEXT PRIMARY KEY,
+name        TEXT NOT NULL,
+script      TEXT NOT NULL,
+direction   TEXT NOT NULL,
+separator   TEXT NOT NULL,
+created_at  TIMESTAMPTZ NOT NULL,
+updated_at  TIMESTAMPTZ NOT NULL,
+archived_at TIMESTAMPTZ,
+
+CONSTRAINT languages_direction CHECK (direction IN ('ltr', 'rtl'))
+);
+
+INSERT INTO languages (code, name, script, direction, separator, created_at, updated_at)
+SELECT language, language, '', 'ltr', ' ', NOW(), NOW()
+FROM (SELECT language FROM words UNION SELECT language FROM patterns) used;
+
+ALTER TABLE words ADD CONSTRAINT words_language_fkey
+    FOREIGN KEY (language) REFERENCES languages (code);
+ALTER TABLE patterns ADD CONSTRAINT patterns_language_fkey
+    FOREIGN KEY (language) REFERENCES languages (code);
+`
+
+// language=SQL
+const DropLanguagesTable = `
+ALTER TABLE patterns DROP CONSTRAINT patterns_language_fkey;
+ALTER TABLE words DROP CONSTRAINT words_language_fkey;
+
+DROP TABLE languages;
+`
@@ -0,0 +1,36 @@
+package migrations
+
+// CreatePartsTable backfills the parts already used by words and pattern
+// slots before adding the foreign key from words.
+//
+// language=SQL
+const CreatePartsTable = `
+CREATE TABLE parts (
+language   TEXT NOT NULL,
+part       TEXT NOT NULL,
+ud_tag     TEXT NOT NULL,
+created_at TIMESTAMPTZ NOT NULL,
+updated_at TIMESTAMPTZ NOT NULL,
+
+CONSTRAINT parts_pkey PRIMARY KEY (language, part),
+CONSTRAINT parts_language_fkey FOREIGN KEY (language) REFERENCES languages (code)
+);
+
+INSERT INTO parts (language, part, ud_tag, created_at, updated_at)
+SELECT language, part, '', NOW(), NOW()
+FROM (
+    SELECT language, part FROM words
+    UNION
+    SELECT language, unnest(string_to_array(pattern, ',')) FROM patterns
+) used;
+
+ALTER TABLE words ADD CONSTRAINT words_part_fkey
+    FOREIGN KEY (language, part) REFERENCES parts (language, part);
+`
+
+// language=SQL
+const DropPartsTable = `
+ALTER TABLE words DROP CONSTRAINT words_part_fkey;
+
+DROP TABLE parts;
+`
@@ -0,0 +1,32 @@
+package migrations
+
+// CreatePatternSlotsTable backfills a slot for every part in the existing
+// patterns, numbering positions from 0.
+//
+// language=SQL
+const CreatePatternSlotsTable = `
+CREATE TABLE pattern_slots (
+pattern_id UUID NOT NULL,
+position   INTEGER NOT NULL,
+kind       TEXT NOT NULL,
+language   TEXT NOT NULL,
+part       TEXT,
+options    JSONB NOT NULL,
+
+CONSTRAINT pattern_slots_pkey PRIMARY KEY (pattern_id, position),
+CONSTRAINT pattern_slots_pattern_fkey FOREIGN KEY (pattern_id) REFERENCES patterns (pattern_id) ON DELETE CASCADE,
+CONSTRAINT pattern_slots_part_fkey FOREIGN KEY (language, part) REFERENCES parts (language, part),
+CONSTRAINT pattern_slots_kind CHECK (kind = 'part' AND part IS NOT NULL)
+);
+
+CREATE INDEX pattern_slots_language_part ON pattern_slots (language, part);
+
+INSERT INTO pattern_slots (pattern_id, position, kind, language, part, options)
+SELECT pattern_id, s.position - 1, 'part', language, s.slot, '{}'
+FROM patterns, unnest(string_to_array(pattern, ',')) WITH ORDINALITY AS s(slot, position);
+`
+
+// language=SQL
+const DropPatternSlotsTable = `
+DROP TABLE pattern_slots;
+`
@@ -0,0 +1,27 @@
+package migrations
+
+// CreateWordTagsTable also gives patterns a theme, the tag their slots draw
+// words with when generation doesn't ask for any.
+//
+// language=SQL
+const CreateWordTagsTable = `
+CREATE TABLE word_tags (
+word_id    UUID NOT NULL,
+tag        TEXT NOT NULL,
+created_at TIMESTAMPTZ NOT NULL,
+
+CONSTRAINT word_tags_pkey PRIMARY KEY (word_id, tag),
+CONSTRAINT word_tags_word_fkey FOREIGN KEY (word_id) REFERENCES words (word_id) ON DELETE CASCADE
+);
+
+CREATE INDEX word_tags_tag ON word_tags (tag, word_id);
+
+ALTER TABLE patterns ADD COLUMN theme TEXT;
+`
+
+// language=SQL
+const DropWordTagsTable = `
+ALTER TABLE patterns DROP COLUMN theme;
+
+DROP TABLE word_tags;
+`
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+// UDTags are the Universal Dependencies part of speech tags a part may map to.
+var UDTags = []string{
+	"ADJ", "ADP", "ADV", "AUX", "CCONJ", "DET", "INTJ", "NOUN", "NUM",
+	"PART", "PRON", "PROPN", "PUNCT", "SCONJ", "SYM", "VERB", "X",
+}
+
+// Part is a part of speech words of a language are filed under and pattern
+// slots draw from. UDTag is empty when the part isn't mapped.
+type Part struct {
+	Language  string    `json:"language"`
+	Part      string    `json:"part"`
+	UDTag     string    `json:"udTag"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func validatePartName(part string) error {
+	if part == "" || len(part) > 32 {
+		return errors.PartInvalid.WithMsg("part must be 1 to 32 characters")
+	}
+	for _, r := range part {
+		if !validators.Charsets["part"](r) {
+			return errors.PartInvalid.WithMsg("part may only contain a-z, 0-9 and _")
+		}
+	}
+	return nil
+}
+
+func validateUDTag(udTag string) error {
+	if udTag == "" {
+		return nil
+	}
+	for _, tag := range UDTags {
+		if tag == udTag {
+			return nil
+		}
+	}
+	return errors.PartInvalid.WithMsg("unknown UD tag " + udTag)
+}
+
+type dbQueryRow interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// dbCheckPatternParts returns PartNotFound, naming the slot, when a slot of
+// pattern isn't a part of language.
+func dbCheckPatternParts(ctx context.Context, db dbQueryRow, pattern, language string) error {
+	stmt := `SELECT slot
+		FROM unnest(string_to_array($1, ',')) WITH ORDINALITY AS s(slot, position)
+		WHERE NOT EXISTS (SELECT 1 FROM parts WHERE language=$2 AND part=slot)
+		ORDER BY position
+		LIMIT 1;`
+
+	var slot string
+	err := db.QueryRowContext(ctx, stmt, pattern, language).Scan(&slot)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return errors.UnexpectedError(err, "Failed checking pattern parts")
+	}
+	return errors.PartNotFound.WithMsg(slot)
+}
+
+func (dbal *DBAL) PartCreate(ctx context.Context, part_in Part) (part Part, err error) {
+	defer observeQuery("PartCreate", time.Now(), &err)
+
+	part = part_in
+	if err := validatePartName(part.Part); err != nil {
+		return part, err
+	}
+	if err := validateUDTag(part.UDTag); err != nil {
+		return part, err
+	}
+
+	part.CreatedAt = time.Now()
+	part.UpdatedAt = part.CreatedAt
+
+	if err := dbCheckLanguageActive(ctx, dbal, part.Language); err != nil {
+		return part, err
+	}
+
+	stmt := `INSERT INTO parts (
+		language,
+		part,
+		ud_tag,
+		created_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5);`
+
+	_, err = dbal.ExecContext(ctx, stmt,
+		part.Language,
+		part.Part,
+		part.UDTag,
+		part.CreatedAt,
+		part.UpdatedAt,
+	)
+
+	if err == nil {
+		return part, nil
+	}
+
+	if dbIsDuplicateErr(err, "parts_pkey") {
+		return part, errors.PartDuplicate
+	}
+	if dbIsForeignKeyErr(err, "parts_language_fkey") {
+		return part, errors.LanguageNotFound
+	}
+
+	return part, errors.UnexpectedError(err, "Failed creating part")
+}
+
+func (dbal *DBAL) PartGet(ctx context.Context, language, part_in string) (part Part, err error) {
+	defer observeQuery("PartGet", time.Now(), &err)
+
+	stmt := `SELECT
+                language,
+                part,
+                ud_tag,
+                created_at,
+                updated_at FROM parts WHERE language=$1 AND part=$2;`
+
+	err = dbal.QueryRowContext(ctx, stmt, language, part_in).Scan(
+		&part.Language,
+		&part.Part,
+		&part.UDTag,
+		&part.CreatedAt,
+		&part.UpdatedAt,
+	)
+
+	if err == nil {
+		return part, nil
+	}
+
+	if err == sql.ErrNoRows {
+		return part, errors.PartNotFound
+	}
+
+	return part, errors.UnexpectedError(err, "Failed getting part")
+}
+
+// PartList returns the parts of a language ordered by name.
+func (dbal DBAL) PartList(ctx context.Context, language string) (parts []Part, err error) {
+	defer observeQuery("PartList", time.Now(), &err)
+
+	stmt := `SELECT
+                language,
+                part,
+                ud_tag,
+                created_at,
+                updated_at FROM parts WHERE language=$1
+                ORDER BY part;`
+
+	rows, err := dbal.QueryContext(ctx, stmt, language)
+	if err != nil {
+		return parts, errors.UnexpectedError(err, "Failed listing parts")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var part Part
+		if err := rows.Scan(
+			&part.Language,
+			&part.Part,
+			&part.UDTag,
+			&part.CreatedAt,
+			&part.UpdatedAt,
+		); err != nil {
+			return parts, errors.UnexpectedError(err, "Failed scanning parts")
+		}
+		parts = append(parts, part)
+	}
+
+	if err := rows.Err(); err != nil {
+		return parts, errors.UnexpectedError(err, "Failed iterating parts")
+	}
+
+	return parts, nil
+}
+
+// PartSetUDTag maps a part to a Universal Dependencies tag, or unmaps it when
+// udTag is empty.
+func (dbal DBAL) PartSetUDTag(ctx context.Context, language, part, udTag string) (err error) {
+	defer observeQuery("PartSetUDTag", time.Now(), &err)
+
+	if err := validateUDTag(udTag); err != nil {
+		return err
+	}
+
+	stmt := `UPDATE parts SET ud_tag=$1, updated_at=$2 WHERE language=$3 AND part=$4;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, udTag, time.Now(), language, part)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to set UD tag")
+	} else if n == 0 {
+		return errors.PartNotFound
+	}
+
+	return nil
+}
+
+// PartRename renames a part of a language, and the words and pattern slots
+// using it, in one transaction.
+func (dbal DBAL) PartRename(ctx context.Context, language, from, to string) (err error) {
+	defer observeQuery("PartRename", time.Now(), &err)
+
+	if err := validatePartName(to); err != nil {
+		return err
+	}
+
+	return dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		stmt := `INSERT INTO parts (language, part, ud_tag, created_at, updated_at)
+			SELECT language, $3::text, ud_tag, created_at, $4 FROM parts
+			WHERE language=$1 AND part=$2;`
+
+		_, n, err := dbExecOne(ctx, tx, stmt, language, from, to, now)
+		if dbIsDuplicateErr(err, "parts_pkey") {
+			return errors.PartDuplicate
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed to rename part")
+		} else if n == 0 {
+			return errors.PartNotFound
+		}
+
+		stmt = `UPDATE words SET part=$3, updated_at=$4 WHERE language=$1 AND part=$2;`
+		if _, err := tx.ExecContext(ctx, stmt, language, from, to, now); err != nil {
+			return errors.UnexpectedError(err, "Failed to rename part of words")
+		}
+
+		stmt = `UPDATE patterns SET
+			pattern=array_to_string(array_replace(string_to_array(pattern, ','), $2, $3), ','),
+			updated_at=$4
+			WHERE language=$1 AND $2=ANY(string_to_array(pattern, ','));`
+		if _, err := tx.ExecContext(ctx, stmt, language, from, to, now); err != nil {
+			return errors.UnexpectedError(err, "Failed to rename part of patterns")
+		}
+
+		stmt = `UPDATE pattern_slots SET part=$3 WHERE language=$1 AND part=$2;`
+		if _, err := tx.ExecContext(ctx, stmt, language, from, to); err != nil {
+			return errors.UnexpectedError(err, "Failed to rename part of pattern slots")
+		}
+
+		stmt = `DELETE FROM parts WHERE language=$1 AND part=$2;`
+		if _, err := tx.ExecContext(ctx, stmt, language, from); err != nil {
+			return errors.UnexpectedError(err, "Failed to rename part")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.PartCreate
+// -----------------------------------------------------------------------------
+func TestDBAL_PartCreate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if _, err := dbal.PartCreate(ctx, Part{Language: "en", Part: "colour", UDTag: "ADJ"}); err != nil {
+		t.Fatal(err)
+	}
+
+	part, err := dbal.PartGet(ctx, "en", "colour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.UDTag != "ADJ" || !part.CreatedAt.Equal(part.UpdatedAt) {
+		t.Fatal(part)
+	}
+
+	if _, err := dbal.WordCreate(ctx, "Red", "en", "colour"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_PartCreate_Duplicate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, err := dbal.PartCreate(ctx, Part{Language: "en", Part: "noun"})
+	if err != errors.PartDuplicate {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_PartCreate_Invalid(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	for _, part := range []Part{
+		{Language: "en", Part: ""},
+		{Language: "en", Part: "Noun"},
+		{Language: "en", Part: "noun,verb"},
+		{Language: "en", Part: "colour", UDTag: "ADJECTIVE"},
+	} {
+		_, err := dbal.PartCreate(ctx, part)
+		if !errors.PartInvalid.Equals(err) {
+			t.Fatal(part, err)
+		}
+	}
+}
+
+func TestDBAL_PartCreate_LanguageNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	_, err := dbal.PartCreate(ctx, Part{Language: "en", Part: "noun"})
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.PartList
+// -----------------------------------------------------------------------------
+func TestDBAL_PartList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	if _, err := dbal.PartCreate(ctx, Part{Language: "fr", Part: "colour"}); err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := dbal.PartList(ctx, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != len(testParts)+1 || parts[0].Part != "adjective" || parts[2].Part != "colour" {
+		t.Fatal(parts)
+	}
+	for _, part := range parts {
+		if part.Language != "fr" {
+			t.Fatal(part)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.PartSetUDTag
+// -----------------------------------------------------------------------------
+func TestDBAL_PartSetUDTag(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if err := dbal.PartSetUDTag(ctx, "en", "noun", "NOUN"); err != nil {
+		t.Fatal(err)
+	}
+	part, err := dbal.PartGet(ctx, "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.UDTag != "NOUN" {
+		t.Fatal(part)
+	}
+
+	if err := dbal.PartSetUDTag(ctx, "en", "noun", "N"); !errors.PartInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+	if err := dbal.PartSetUDTag(ctx, "en", "animal", "NOUN"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.PartRename
+// -----------------------------------------------------------------------------
+func TestDBAL_PartRename(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	if err := dbal.PartSetUDTag(ctx, "en", "adjective", "ADJ"); err != nil {
+		t.Fatal(err)
+	}
+	word, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	frWord, err := dbal.WordCreate(ctx, "Grand", "fr", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern, err := dbal.PatternCreate(ctx, "adjective,noun,adjective", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbal.PartRename(ctx, "en", "adjective", "adj"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dbal.PartGet(ctx, "en", "adjective"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	part, err := dbal.PartGet(ctx, "en", "adj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.UDTag != "ADJ" {
+		t.Fatal(part)
+	}
+
+	if word, err = dbal.WordGet(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if word.Part != "adj" || !word.UpdatedAt.After(word.CreatedAt) {
+		t.Fatal(word)
+	}
+	if pattern, err = dbal.PatternGet(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if pattern.Pattern != "adj,noun,adj" {
+		t.Fatal(pattern.Pattern)
+	}
+
+	// Other languages keep their part.
+	if frWord, err = dbal.WordGet(ctx, frWord.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if frWord.Part != "adjective" {
+		t.Fatal(frWord)
+	}
+}
+
+func TestDBAL_PartRename_Duplicate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	word, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dbal.PartRename(ctx, "en", "adjective", "noun"); err != errors.PartDuplicate {
+		t.Fatal(err)
+	}
+
+	if word, err = dbal.WordGet(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if word.Part != "adjective" {
+		t.Fatal(word)
+	}
+}
+
+func TestDBAL_PartRename_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if err := dbal.PartRename(ctx, "en", "adj", "adjective2"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	if err := dbal.PartRename(ctx, "en", "adjective", "Adj"); !errors.PartInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+}
@@ -1,8 +1,8 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"time"
 
 	"github.com/timaraxian/alias-gen/pkg/errors"
@@ -13,16 +13,21 @@ import (
 type Pattern struct {
 	PatternID  string     `json:"patternID"`
 	Pattern    string     `json:"pattern"`
-	Language   string     `json:language`
+	Language   string     `json:"language"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	UpdatedAt  time.Time  `json:"updatedAt"`
 	ArchivedAt *time.Time `json:"archivedAt"`
+
+	// Theme is the tag the pattern's slots draw words with when generation
+	// doesn't ask for any, or empty for none.
+	Theme string `json:"theme"`
 }
 
-func (dbal *DBAL) PatternCreate(pattern_in, language string) (pattern Pattern, err error) {
+func (dbal *DBAL) PatternCreate(ctx context.Context, pattern_in, language string) (pattern Pattern, err error) {
+	defer observeQuery("PatternCreate", time.Now(), &err)
+
 	pattern.PatternID = crypto.NewUUID()
 
-	// todo: validate pattern, language
 	pattern.Pattern = pattern_in
 	pattern.Language = language
 
@@ -38,26 +43,38 @@ func (dbal *DBAL) PatternCreate(pattern_in, language string) (pattern Pattern, e
 		archived_at
 	) VALUES ($1, $2, $3, $4, $5, NULL);`
 
-	_, err = dbal.Exec(stmt,
-		pattern.PatternID,
-		pattern.Pattern,
-		pattern.Language,
-		pattern.CreatedAt,
-		pattern.UpdatedAt,
-	)
+	err = dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		if err := dbCheckLanguageActive(ctx, tx, pattern.Language); err != nil {
+			return err
+		}
 
-	if err == nil {
-		return pattern, nil
-	}
+		_, err := tx.ExecContext(ctx, stmt,
+			pattern.PatternID,
+			pattern.Pattern,
+			pattern.Language,
+			pattern.CreatedAt,
+			pattern.UpdatedAt,
+		)
 
-	if dbIsDuplicateErr(err, "patterns_pattern_language") {
-		return pattern, errors.PatternDuplicate
-	}
+		if dbIsDuplicateErr(err, "patterns_pattern_language") {
+			return errors.PatternDuplicate
+		}
+		if dbIsForeignKeyErr(err, "patterns_language_fkey") {
+			return errors.LanguageNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed creating pattern")
+		}
+
+		return dbSetPatternSlots(ctx, tx, pattern.PatternID, pattern.Pattern, pattern.Language)
+	})
 
-	return pattern, errors.UnexpectedError(err, "Failed creating pattern")
+	return pattern, err
 }
 
-func (dbal *DBAL) PatternGet(patternID string) (pattern Pattern, err error) {
+func (dbal *DBAL) PatternGet(ctx context.Context, patternID string) (pattern Pattern, err error) {
+	defer observeQuery("PatternGet", time.Now(), &err)
+
 	if err := validators.UUID(patternID); err != nil {
 		return pattern, errors.PatternNotFound
 	}
@@ -68,15 +85,17 @@ func (dbal *DBAL) PatternGet(patternID string) (pattern Pattern, err error) {
                 language,
                 created_at,
                 updated_at,
-                archived_at FROM patterns WHERE pattern_id=$1;`
+                archived_at,
+                COALESCE(theme, '') FROM patterns WHERE pattern_id=$1;`
 
-	err = dbal.QueryRow(stmt, patternID).Scan(
+	err = dbal.QueryRowContext(ctx, stmt, patternID).Scan(
 		&pattern.PatternID,
 		&pattern.Pattern,
 		&pattern.Language,
 		&pattern.CreatedAt,
 		&pattern.UpdatedAt,
 		&pattern.ArchivedAt,
+		&pattern.Theme,
 	)
 
 	if err == nil {
@@ -90,21 +109,51 @@ func (dbal *DBAL) PatternGet(patternID string) (pattern Pattern, err error) {
 	return pattern, errors.UnexpectedError(err, "Failed getting pattern")
 }
 
-func (dbal DBAL) PatternSetPattern(patternID, pattern string) (err error) {
+func (dbal DBAL) PatternSetPattern(ctx context.Context, patternID, pattern string) (err error) {
+	defer observeQuery("PatternSetPattern", time.Now(), &err)
+
 	if err := validators.UUID(patternID); err != nil {
 		return errors.PatternNotFound
 	}
 
-	// todo: validate pattern
+	stmt := `UPDATE patterns SET pattern=$1, updated_at=$2 WHERE pattern_id=$3 AND archived_at IS NULL
+		RETURNING language;`
 
-	stmt := `UPDATE patterns SET pattern=$1, updated_at=$2 WHERE pattern_id=$3 AND archived_at IS NULL;`
+	return dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		var language string
+		err := tx.QueryRowContext(ctx, stmt, pattern, time.Now(), patternID).Scan(&language)
+		if dbIsDuplicateErr(err, "patterns_pattern_language") {
+			return errors.PatternDuplicate
+		}
+		if err == sql.ErrNoRows {
+			return errors.PatternNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed to set pattern")
+		}
 
-	_, n, err := dbal.ExecOne(stmt, pattern, time.Now(), patternID)
-	if dbIsDuplicateErr(err, "patterns_pattern_language") {
-		return errors.PatternDuplicate
+		return dbSetPatternSlots(ctx, tx, patternID, pattern, language)
+	})
+}
+
+// PatternSetTheme sets the theme of a pattern. An empty theme clears it.
+func (dbal DBAL) PatternSetTheme(ctx context.Context, patternID, theme string) (err error) {
+	defer observeQuery("PatternSetTheme", time.Now(), &err)
+
+	if err := validators.UUID(patternID); err != nil {
+		return errors.PatternNotFound
+	}
+	if theme != "" {
+		if err := ValidateTag(theme); err != nil {
+			return err
+		}
 	}
+
+	stmt := `UPDATE patterns SET theme=NULLIF($1, ''), updated_at=$2 WHERE pattern_id=$3 AND archived_at IS NULL;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, theme, time.Now(), patternID)
 	if err != nil {
-		return errors.UnexpectedError(err, "Failed to set pattern")
+		return errors.UnexpectedError(err, "Failed to set pattern theme")
 	} else if n == 0 {
 		return errors.PatternNotFound
 	}
@@ -112,35 +161,75 @@ func (dbal DBAL) PatternSetPattern(patternID, pattern string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) PatternSetLanguage(patternID, language string) (err error) {
+func (dbal DBAL) PatternSetLanguage(ctx context.Context, patternID, language string) (err error) {
+	defer observeQuery("PatternSetLanguage", time.Now(), &err)
+
 	if err := validators.UUID(patternID); err != nil {
 		return errors.PatternNotFound
 	}
 
-	// todo: validate language
-	stmt := `UPDATE patterns SET language=$1, updated_at=$2 WHERE pattern_id=$3 AND archived_at IS NULL;`
+	stmt := `UPDATE patterns SET language=$1, updated_at=$2 WHERE pattern_id=$3 AND archived_at IS NULL
+		RETURNING pattern;`
 
-	_, n, err := dbal.ExecOne(stmt, language, time.Now(), patternID)
-	if dbIsDuplicateErr(err, "patterns_pattern_language") {
-		return errors.PatternDuplicate
-	}
-	if err != nil {
-		return errors.UnexpectedError(err, "Failed to set language")
-	} else if n == 0 {
+	return dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		var pattern string
+		err := tx.QueryRowContext(ctx, stmt, language, time.Now(), patternID).Scan(&pattern)
+		if dbIsDuplicateErr(err, "patterns_pattern_language") {
+			return errors.PatternDuplicate
+		}
+		if dbIsForeignKeyErr(err, "patterns_language_fkey") {
+			return errors.LanguageNotFound
+		}
+		if err == sql.ErrNoRows {
+			return errors.PatternNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed to set language")
+		}
+
+		return dbSetPatternSlots(ctx, tx, patternID, pattern, language)
+	})
+}
+
+// PatternUpdate sets the pattern and language of the unarchived pattern with
+// pattern.PatternID, and its slots, in one transaction.
+func (dbal DBAL) PatternUpdate(ctx context.Context, pattern Pattern) (err error) {
+	defer observeQuery("PatternUpdate", time.Now(), &err)
+
+	if err := validators.UUID(pattern.PatternID); err != nil {
 		return errors.PatternNotFound
 	}
 
-	return nil
+	stmt := `UPDATE patterns SET pattern=$1, language=$2, updated_at=$3 WHERE pattern_id=$4 AND archived_at IS NULL;`
+
+	return dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		_, n, err := dbExecOne(ctx, tx, stmt, pattern.Pattern, pattern.Language, time.Now(), pattern.PatternID)
+		if dbIsDuplicateErr(err, "patterns_pattern_language") {
+			return errors.PatternDuplicate
+		}
+		if dbIsForeignKeyErr(err, "patterns_language_fkey") {
+			return errors.LanguageNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed to update pattern")
+		} else if n == 0 {
+			return errors.PatternNotFound
+		}
+
+		return dbSetPatternSlots(ctx, tx, pattern.PatternID, pattern.Pattern, pattern.Language)
+	})
 }
 
-func (dbal DBAL) PatternSetArchive(patternID string) (err error) {
+func (dbal DBAL) PatternSetArchive(ctx context.Context, patternID string) (err error) {
+	defer observeQuery("PatternSetArchive", time.Now(), &err)
+
 	if err := validators.UUID(patternID); err != nil {
 		return errors.PatternNotFound
 	}
 
 	stmt := `UPDATE patterns SET archived_at=COALESCE(archived_at, NOW()) WHERE pattern_id=$1;`
 
-	_, n, err := dbal.ExecOne(stmt, patternID)
+	_, n, err := dbal.ExecOne(ctx, stmt, patternID)
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to archive pattern")
 	} else if n == 0 {
@@ -150,14 +239,16 @@ func (dbal DBAL) PatternSetArchive(patternID string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) PatternSetUnArchive(patternID string) (err error) {
+func (dbal DBAL) PatternSetUnArchive(ctx context.Context, patternID string) (err error) {
+	defer observeQuery("PatternSetUnArchive", time.Now(), &err)
+
 	if err := validators.UUID(patternID); err != nil {
 		return errors.PatternNotFound
 	}
 
 	stmt := `UPDATE patterns SET archived_at=NULL WHERE pattern_id=$1;`
 
-	_, n, err := dbal.ExecOne(stmt, patternID)
+	_, n, err := dbal.ExecOne(ctx, stmt, patternID)
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to archive pattern")
 	} else if n == 0 {
@@ -167,101 +258,142 @@ func (dbal DBAL) PatternSetUnArchive(patternID string) (err error) {
 	return nil
 }
 
-type PatternListArgs struct {
-	Limit            *int
-	Offset           *int
-	OrderByPattern   *bool
-	DescPattern      *bool
-	OrderByLanguage  *bool
-	DescLanguage     *bool
-	OrderByUpdatedAt *bool
-	DescUpdatedAt    *bool
-	OrderByCreatedAt *bool
-	DescCreatedAt    *bool
-	ShowArchived     *bool
+// PatternFilter narrows a pattern list. Empty fields don't filter.
+type PatternFilter struct {
+	Language string `json:"language"`
+
+	// Part matches patterns with a slot for the part.
+	Part            string `json:"part"`
+	PatternContains string `json:"patternContains"`
+
+	// Created and updated times are from After, inclusive, to Before.
+	CreatedAfter  *time.Time `json:"createdAfter"`
+	CreatedBefore *time.Time `json:"createdBefore"`
+	UpdatedAfter  *time.Time `json:"updatedAfter"`
+	UpdatedBefore *time.Time `json:"updatedBefore"`
+
+	// Archived is exclude (the default), include or only.
+	Archived string `json:"archived"`
 }
 
-func (dbal DBAL) PatternList(listArgs PatternListArgs) (patterns []Pattern, err error) {
-	// ------ build statement
-	stmt := `SELECT 
-		pattern_id,
-		pattern,
-		language,
-		created_at,
-		updated_at,
-		archived_at FROM patterns %s %s %s %s;`
+// ParsePatternFilter reads a filter written as comma separated key=value
+// pairs, keyed by the JSON field names, e.g. "language=en,part=noun".
+func ParsePatternFilter(spec string) (f PatternFilter, err error) {
+	err = parseFilterSpec(spec, map[string]func(string) error{
+		"language":        setString(&f.Language),
+		"part":            setString(&f.Part),
+		"patternContains": setString(&f.PatternContains),
+		"createdAfter":    setTime(&f.CreatedAfter),
+		"createdBefore":   setTime(&f.CreatedBefore),
+		"updatedAfter":    setTime(&f.UpdatedAfter),
+		"updatedBefore":   setTime(&f.UpdatedBefore),
+		"archived":        setString(&f.Archived),
+	})
+	return f, err
+}
 
-	// %s(1) show archived or not
-	showArchived := ""
-	if listArgs.ShowArchived != nil && !*listArgs.ShowArchived {
-		showArchived = "WHERE archived_at IS NULL"
+func (f PatternFilter) apply(q *dbQuery) error {
+	if f.Language != "" {
+		q.where("language = " + q.arg(f.Language))
 	}
+	switch {
+	case f.Part == "":
+	case q.sqlite:
+		// SQLite keeps no pattern_slots; find the part between the commas.
+		q.where("instr(',' || pattern || ',', " + q.arg(","+f.Part+",") + ") > 0")
+	default:
+		q.where("EXISTS (SELECT 1 FROM pattern_slots WHERE pattern_slots.pattern_id = patterns.pattern_id AND pattern_slots.part = " + q.arg(f.Part) + ")")
+	}
+	switch {
+	case f.PatternContains == "":
+	case q.sqlite:
+		// SQLite's LIKE ignores case, Postgres's doesn't.
+		q.where("instr(pattern, " + q.arg(f.PatternContains) + ") > 0")
+	default:
+		q.where("pattern LIKE " + q.arg("%"+dbLikeEscape(f.PatternContains)+"%"))
+	}
+	return timeFilter{
+		createdAfter:  f.CreatedAfter,
+		createdBefore: f.CreatedBefore,
+		updatedAfter:  f.UpdatedAfter,
+		updatedBefore: f.UpdatedBefore,
+		archived:      f.Archived,
+	}.apply(q)
+}
 
-	// %s(2) orderbys
-	orderBy := "ORDER BY "
-	if listArgs.OrderByPattern != nil && *listArgs.OrderByPattern {
-		orderBy += "pattern"
+// PatternSortKeys maps the keys patterns can be ordered by to their columns.
+var PatternSortKeys = map[string]string{
+	"pattern":   "pattern",
+	"language":  "language",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
 
-		if listArgs.DescPattern != nil && *listArgs.DescPattern {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
-	}
-	if listArgs.OrderByLanguage != nil && *listArgs.OrderByLanguage {
-		orderBy += "language"
+type PatternListArgs struct {
+	Filter PatternFilter
 
-		if listArgs.DescLanguage != nil && *listArgs.DescLanguage {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
-	}
-	if listArgs.OrderByUpdatedAt != nil && *listArgs.OrderByUpdatedAt {
-		orderBy += "updated_at"
+	// OrderBy keys come from PatternSortKeys, most significant first. Ties
+	// are broken by pattern_id.
+	OrderBy []OrderBy
 
-		if listArgs.DescUpdatedAt != nil && *listArgs.DescUpdatedAt {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
-	}
-	if listArgs.OrderByCreatedAt != nil && *listArgs.OrderByCreatedAt {
-		orderBy += "created_at"
+	// Limit defaults to DefaultListLimit and is capped at MaxListLimit.
+	Limit int
 
-		if listArgs.DescCreatedAt != nil && *listArgs.DescCreatedAt {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
-	}
-	if orderBy == "ORDER BY " {
-		orderBy = ""
-	} else {
-		orderBy = orderBy[:len(orderBy)-2]
+	// Cursor is the NextCursor or PrevCursor of a page listed with the same
+	// ordering.
+	Cursor string
+}
+
+func (pattern Pattern) sortValue(key string) string {
+	switch key {
+	case "pattern":
+		return pattern.Pattern
+	case "language":
+		return pattern.Language
+	case "updatedAt":
+		return cursorTime(pattern.UpdatedAt)
+	case "createdAt":
+		return cursorTime(pattern.CreatedAt)
 	}
+	panic("unknown pattern sort key: " + key)
+}
+
+// PatternList returns a page of the patterns matching the filter, and the
+// cursors of the pages either side.
+func (dbal DBAL) PatternList(ctx context.Context, listArgs PatternListArgs) (patterns []Pattern, page Page, err error) {
+	defer observeQuery("PatternList", time.Now(), &err)
 
-	// %s(3) limit
-	limit := ""
-	if listArgs.Limit != nil && *listArgs.Limit > 0 {
-		limit = fmt.Sprintf("LIMIT %d", *listArgs.Limit)
-	} else {
-		limit = "LIMIT 50"
+	if err := checkOrderBy(listArgs.OrderBy, PatternSortKeys); err != nil {
+		return patterns, page, err
 	}
+	cursor, err := decodeCursor(listArgs.Cursor, listArgs.OrderBy)
+	if err != nil {
+		return patterns, page, err
+	}
+	limit := listLimit(listArgs.Limit)
 
-	// %s(4) offset
-	offset := ""
-	if listArgs.Offset != nil && *listArgs.Offset > 0 {
-		offset = fmt.Sprintf("OFFSET %d", *listArgs.Offset)
+	// ------ build statement
+	q := &dbQuery{}
+	if err := listArgs.Filter.apply(q); err != nil {
+		return patterns, page, err
 	}
+	orderBy := keyset(listArgs.OrderBy, PatternSortKeys, "pattern_id", cursor, q)
 
-	stmt = fmt.Sprintf(stmt, showArchived, orderBy, limit, offset)
+	// One extra row tells whether there's another page.
+	stmt := `SELECT
+		pattern_id,
+		pattern,
+		language,
+		created_at,
+		updated_at,
+		archived_at,
+		COALESCE(theme, '') FROM patterns ` + q.whereClause() + ` ` + orderBy + ` LIMIT ` + q.arg(limit+1) + `;`
 
 	// ------- statement built
 
-	rows, err := dbal.Query(stmt)
+	rows, err := dbal.QueryContext(ctx, stmt, q.args...)
 	if err != nil {
-		return patterns, errors.UnexpectedError(err, "Failed listing patterns")
+		return patterns, page, errors.UnexpectedError(err, "Failed listing patterns")
 	}
 	defer rows.Close()
 
@@ -274,21 +406,42 @@ func (dbal DBAL) PatternList(listArgs PatternListArgs) (patterns []Pattern, err
 			&pattern.CreatedAt,
 			&pattern.UpdatedAt,
 			&pattern.ArchivedAt,
+			&pattern.Theme,
 		); err != nil {
-			return patterns, errors.UnexpectedError(err, "Failed scanning patterns")
+			return patterns, page, errors.UnexpectedError(err, "Failed scanning patterns")
 		}
 
 		patterns = append(patterns, pattern)
 	}
 
 	if err := rows.Err(); err != nil {
-		return patterns, errors.UnexpectedError(err, "Failed iterating pattern rows")
+		return patterns, page, errors.UnexpectedError(err, "Failed iterating pattern rows")
 	}
 
-	return patterns, err
+	more := len(patterns) > limit
+	if more {
+		patterns = patterns[:limit]
+	}
+	// Pages before the cursor are read backwards.
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(patterns)-1; i < j; i, j = i+1, j-1 {
+			patterns[i], patterns[j] = patterns[j], patterns[i]
+		}
+	}
+
+	var first, last *listCursor
+	if len(patterns) > 0 {
+		f := newListCursor(listArgs.OrderBy, patterns[0].sortValue, patterns[0].PatternID, true)
+		l := newListCursor(listArgs.OrderBy, patterns[len(patterns)-1].sortValue, patterns[len(patterns)-1].PatternID, false)
+		first, last = &f, &l
+	}
+
+	return patterns, pageCursors(cursor, more, first, last), nil
 }
 
-func (dbal DBAL) PatternRandom(language string) (pattern Pattern, err error) {
+func (dbal DBAL) PatternRandom(ctx context.Context, language string) (pattern Pattern, err error) {
+	defer observeQuery("PatternRandom", time.Now(), &err)
+
 	// todo: validate language
 
 	stmt := `SELECT
@@ -297,15 +450,17 @@ func (dbal DBAL) PatternRandom(language string) (pattern Pattern, err error) {
                 language,
                 created_at,
                 updated_at,
-                archived_at FROM patterns WHERE language=$1 AND archived_at IS NULL ORDER BY RANDOM() LIMIT 1;`
+                archived_at,
+                COALESCE(theme, '') FROM patterns WHERE language=$1 AND archived_at IS NULL ORDER BY RANDOM() LIMIT 1;`
 
-	err = dbal.QueryRow(stmt, language).Scan(
+	err = dbal.QueryRowContext(ctx, stmt, language).Scan(
 		&pattern.PatternID,
 		&pattern.Pattern,
 		&pattern.Language,
 		&pattern.CreatedAt,
 		&pattern.UpdatedAt,
 		&pattern.ArchivedAt,
+		&pattern.Theme,
 	)
 
 	if err == nil {
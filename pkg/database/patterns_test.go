@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -14,11 +15,13 @@ import (
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternCreate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
 	before := time.Now().Round(time.Microsecond)
-	pattern, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -45,20 +48,39 @@ func TestDBAL_PatternCreate(t *testing.T) {
 	}
 }
 
+func TestDBAL_PatternCreate_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, err := dbal.PatternCreate(ctx, "adjective,animal", "en")
+	if !errors.PartNotFound.Equals(err) || err.(errors.Error).Msg() != "animal" {
+		t.Fatal(err)
+	}
+
+	if _, err := dbal.PatternRandom(ctx, "en"); err != errors.PatternNotFound {
+		t.Fatal("pattern was created", err)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // DBAL.PatternGet
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternGet(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	pattern_out, err := dbal.PatternGet(pattern_in.PatternID)
+	pattern_out, err := dbal.PatternGet(ctx, pattern_in.PatternID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -73,20 +95,22 @@ func TestDBAL_PatternGet(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternSetPattern(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetPattern(pattern_in.PatternID, "adjective,adjective")
+	err = dbal.PatternSetPattern(ctx, pattern_in.PatternID, "adjective,adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	pattern_out, err := dbal.PatternGet(pattern_in.PatternID)
+	pattern_out, err := dbal.PatternGet(ctx, pattern_in.PatternID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -100,21 +124,49 @@ func TestDBAL_PatternSetPattern(t *testing.T) {
 	}
 }
 
+func TestDBAL_PatternSetPattern_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	pattern, err := dbal.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dbal.PatternSetPattern(ctx, pattern.PatternID, "adjective,animal")
+	if !errors.PartNotFound.Equals(err) {
+		t.Fatal(err)
+	}
+
+	pattern, err = dbal.PatternGet(ctx, pattern.PatternID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pattern.Pattern != "adjective,noun" {
+		t.Fatal(pattern.Pattern)
+	}
+}
+
 func TestDBAL_PatternSetPattern_Duplicate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.PatternCreate("adjective,adjective", "en")
+	_, err = dbal.PatternCreate(ctx, "adjective,adjective", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetPattern(pattern_in.PatternID, "adjective,adjective")
+	err = dbal.PatternSetPattern(ctx, pattern_in.PatternID, "adjective,adjective")
 	if err != errors.PatternDuplicate {
 		t.Fatal(err)
 	}
@@ -122,10 +174,11 @@ func TestDBAL_PatternSetPattern_Duplicate(t *testing.T) {
 
 func TestDBAL_PatternSetPattern_PatternNotFound_validUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.PatternSetPattern(crypto.NewUUID(), "article,adjective,noun")
+	err := dbal.PatternSetPattern(ctx, crypto.NewUUID(), "article,adjective,noun")
 	if err != errors.PatternNotFound {
 		t.Fatal(err)
 	}
@@ -133,10 +186,11 @@ func TestDBAL_PatternSetPattern_PatternNotFound_validUUID(t *testing.T) {
 
 func TestDBAL_PatternSetPattern_PatternNotFound_invalidUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.PatternSetPattern("invalidUUID", "article,adjective,noun")
+	err := dbal.PatternSetPattern(ctx, "invalidUUID", "article,adjective,noun")
 	if err != errors.PatternNotFound {
 		t.Fatal(err)
 	}
@@ -147,20 +201,22 @@ func TestDBAL_PatternSetPattern_PatternNotFound_invalidUUID(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternSetLanguage(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetLanguage(pattern_in.PatternID, "fr")
+	err = dbal.PatternSetLanguage(ctx, pattern_in.PatternID, "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	pattern_out, err := dbal.PatternGet(pattern_in.PatternID)
+	pattern_out, err := dbal.PatternGet(ctx, pattern_in.PatternID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -173,21 +229,44 @@ func TestDBAL_PatternSetLanguage(t *testing.T) {
 	}
 }
 
+func TestDBAL_PatternSetLanguage_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	if _, err := dbal.LanguageCreate(ctx, Language{Code: "fr", Name: "French"}); err != nil {
+		t.Fatal(err)
+	}
+	pattern, err := dbal.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dbal.PatternSetLanguage(ctx, pattern.PatternID, "fr")
+	if !errors.PartNotFound.Equals(err) {
+		t.Fatal(err)
+	}
+}
+
 func TestDBAL_PatternSetLanguage_Duplicate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.PatternCreate("article,adjective,noun", "fr")
+	_, err = dbal.PatternCreate(ctx, "article,adjective,noun", "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetLanguage(pattern_in.PatternID, "fr")
+	err = dbal.PatternSetLanguage(ctx, pattern_in.PatternID, "fr")
 	if err != errors.PatternDuplicate {
 		t.Fatal(err)
 	}
@@ -195,10 +274,11 @@ func TestDBAL_PatternSetLanguage_Duplicate(t *testing.T) {
 
 func TestDBAL_PatternSetLanguage_PatternNotFound_validUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.PatternSetLanguage(crypto.NewUUID(), "fr")
+	err := dbal.PatternSetLanguage(ctx, crypto.NewUUID(), "fr")
 	if err != errors.PatternNotFound {
 		t.Fatal(err)
 	}
@@ -206,10 +286,11 @@ func TestDBAL_PatternSetLanguage_PatternNotFound_validUUID(t *testing.T) {
 
 func TestDBAL_PatternSetLanguage_PatternNotFound_invalidUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.PatternSetLanguage("invalidUUID", "fr")
+	err := dbal.PatternSetLanguage(ctx, "invalidUUID", "fr")
 	if err != errors.PatternNotFound {
 		t.Fatal(err)
 	}
@@ -220,17 +301,19 @@ func TestDBAL_PatternSetLanguage_PatternNotFound_invalidUUID(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternSetArchive(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetArchive(pattern_in.PatternID)
+	err = dbal.PatternSetArchive(ctx, pattern_in.PatternID)
 
-	pattern_out, err := dbal.PatternGet(pattern_in.PatternID)
+	pattern_out, err := dbal.PatternGet(ctx, pattern_in.PatternID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -245,17 +328,19 @@ func TestDBAL_PatternSetArchive(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternSetUnarchive(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	pattern_in, err := dbal.PatternCreate("article,adjective,noun", "en")
+	pattern_in, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.PatternSetUnArchive(pattern_in.PatternID)
+	err = dbal.PatternSetUnArchive(ctx, pattern_in.PatternID)
 
-	pattern_out, err := dbal.PatternGet(pattern_in.PatternID)
+	pattern_out, err := dbal.PatternGet(ctx, pattern_in.PatternID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -270,35 +355,34 @@ func TestDBAL_PatternSetUnarchive(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternList(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	p1, err := dbal.PatternCreate("article,adjective,place,noun", "en")
+	p1, err := dbal.PatternCreate(ctx, "article,adjective,place,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	p2, err := dbal.PatternCreate("adjective,noun", "en")
+	p2, err := dbal.PatternCreate(ctx, "adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	p3, err := dbal.PatternCreate("place,article,noun", "en")
+	p3, err := dbal.PatternCreate(ctx, "place,article,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	p4, err := dbal.PatternCreate("adjective,noun", "fr")
+	p4, err := dbal.PatternCreate(ctx, "adjective,noun", "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	limit := 10
-	trueVar := true
 	listargs := PatternListArgs{
-		Limit:           &limit,
-		OrderByPattern:  &trueVar,
-		OrderByLanguage: &trueVar,
+		Limit:   10,
+		OrderBy: []OrderBy{{Key: "pattern"}, {Key: "language"}},
 	}
 
-	results, err := dbal.PatternList(listargs)
+	results, _, err := dbal.PatternList(ctx, listargs)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -317,30 +401,75 @@ func TestDBAL_PatternList(t *testing.T) {
 	}
 }
 
+func TestDBAL_PatternList_Filter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	p1, err := dbal.PatternCreate(ctx, "article,adjective,place,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.PatternCreate(ctx, "adjective,noun", "en"); err != nil {
+		t.Fatal(err)
+	}
+	p3, err := dbal.PatternCreate(ctx, "article,place", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dbal.PatternSetArchive(ctx, p3.PatternID); err != nil {
+		t.Fatal(err)
+	}
+
+	results, _, err := dbal.PatternList(ctx, PatternListArgs{
+		Filter:  PatternFilter{Part: "place", Archived: ArchivedInclude},
+		OrderBy: []OrderBy{{Key: "pattern", Desc: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].PatternID != p3.PatternID || results[1].PatternID != p1.PatternID {
+		t.Fatal(results)
+	}
+
+	// "article" isn't matched by "art".
+	results, _, err = dbal.PatternList(ctx, PatternListArgs{Filter: PatternFilter{Part: "art"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatal(results)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // DBAL.PatternRandom
 // -----------------------------------------------------------------------------
 func TestDBAL_PatternRandom(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	p1, err := dbal.PatternCreate("adjective,noun", "en")
+	p1, err := dbal.PatternCreate(ctx, "adjective,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	p2, err := dbal.PatternCreate("adjective,place,noun", "en")
+	p2, err := dbal.PatternCreate(ctx, "adjective,place,noun", "en")
 	if err != nil {
 		t.Fatal(err)
 	}
-	p3, err := dbal.PatternCreate("adjective,noun", "fr")
+	p3, err := dbal.PatternCreate(ctx, "adjective,noun", "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	outIDs := map[string]int{}
 	for i := 0; i < 100; i++ {
-		p_out, err := dbal.PatternRandom("en")
+		p_out, err := dbal.PatternRandom(ctx, "en")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -364,10 +493,11 @@ func TestDBAL_PatternRandom(t *testing.T) {
 
 func TestDBAL_PatternRandom_PatternNotFound(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	_, err := dbal.PatternRandom("en")
+	_, err := dbal.PatternRandom(ctx, "en")
 	if err != errors.PatternNotFound {
 		t.Fatal(err)
 	}
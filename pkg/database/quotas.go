@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+// GenerationQuotaConsume records n generated aliases against the key's usage
+// for the UTC day of at. It fails with errors.QuotaExceeded, recording
+// nothing, when that would take the key over its daily quota.
+func (dbal *DBAL) GenerationQuotaConsume(ctx context.Context, apiKeyID string, n int, at time.Time) (remaining *int, err error) {
+	defer observeQuery("GenerationQuotaConsume", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return nil, errors.ApiKeyNotFound
+	}
+
+	day := at.UTC().Format("2006-01-02")
+
+	err = dbTX(ctx, dbal.DB, func(tx *sql.Tx) error {
+		var quota *int
+		err := tx.QueryRowContext(ctx, `SELECT daily_generation_quota FROM api_keys WHERE api_key_id=$1 FOR UPDATE;`, apiKeyID).Scan(&quota)
+		if err == sql.ErrNoRows {
+			return errors.ApiKeyNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed getting generation quota")
+		}
+
+		var used int
+		err = tx.QueryRowContext(ctx, `SELECT used FROM generation_usage WHERE api_key_id=$1 AND day=$2;`, apiKeyID, day).Scan(&used)
+		if err != nil && err != sql.ErrNoRows {
+			return errors.UnexpectedError(err, "Failed getting generation usage")
+		}
+
+		if quota != nil && used+n > *quota {
+			left := *quota - used
+			remaining = &left
+			return errors.QuotaExceeded
+		}
+
+		stmt := `INSERT INTO generation_usage (api_key_id, day, used) VALUES ($1, $2, $3)
+			ON CONFLICT (api_key_id, day) DO UPDATE SET used = generation_usage.used + EXCLUDED.used;`
+		if _, err := tx.ExecContext(ctx, stmt, apiKeyID, day, n); err != nil {
+			return errors.UnexpectedError(err, "Failed recording generation usage")
+		}
+
+		if quota != nil {
+			left := *quota - used - n
+			remaining = &left
+		}
+		return nil
+	})
+
+	return remaining, err
+}
+
+// GenerationQuotaRefund takes back n aliases consumed on the UTC day of at
+// that were never generated. Usage doesn't go below zero.
+func (dbal *DBAL) GenerationQuotaRefund(ctx context.Context, apiKeyID string, n int, at time.Time) (err error) {
+	defer observeQuery("GenerationQuotaRefund", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return errors.ApiKeyNotFound
+	}
+
+	stmt := `UPDATE generation_usage SET used = GREATEST(used - $3, 0) WHERE api_key_id=$1 AND day=$2;`
+
+	if _, err := dbal.ExecContext(ctx, stmt, apiKeyID, at.UTC().Format("2006-01-02"), n); err != nil {
+		return errors.UnexpectedError(err, "Failed refunding generation usage")
+	}
+	return nil
+}
+
+// GenerationUsage returns how many aliases the key generated on the UTC day
+// of at.
+func (dbal *DBAL) GenerationUsage(ctx context.Context, apiKeyID string, at time.Time) (used int, err error) {
+	defer observeQuery("GenerationUsage", time.Now(), &err)
+
+	if err := validators.UUID(apiKeyID); err != nil {
+		return 0, errors.ApiKeyNotFound
+	}
+
+	stmt := `SELECT used FROM generation_usage WHERE api_key_id=$1 AND day=$2;`
+
+	err = dbal.QueryRowContext(ctx, stmt, apiKeyID, at.UTC().Format("2006-01-02")).Scan(&used)
+	if err == nil || err == sql.ErrNoRows {
+		return used, nil
+	}
+
+	return 0, errors.UnexpectedError(err, "Failed getting generation usage")
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// -----------------------------------------------------------------------------
+// KeyStore.GenerationQuotaConsume
+// -----------------------------------------------------------------------------
+func testStoreGenerationQuota(t *testing.T, store Store) {
+	ctx := context.Background()
+	keys := store.(KeyStore)
+
+	apiKey, _, err := keys.ApiKeyCreate(ctx, "provisioner", []string{ScopeGenerate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota := 5
+	if err := keys.ApiKeySetDailyGenerationQuota(ctx, apiKey.ApiKeyID, &quota); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	remaining, err := keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, 3, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining == nil || *remaining != 2 {
+		t.Fatal(remaining)
+	}
+
+	if _, err := keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, 3, now); err != errors.QuotaExceeded {
+		t.Fatal(err)
+	}
+
+	used, err := keys.GenerationUsage(ctx, apiKey.ApiKeyID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used != 3 {
+		t.Fatal(used)
+	}
+
+	// Refunds free the quota again, without going below zero.
+	if err := keys.GenerationQuotaRefund(ctx, apiKey.ApiKeyID, 2, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, 4, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.GenerationQuotaRefund(ctx, apiKey.ApiKeyID, 10, now); err != nil {
+		t.Fatal(err)
+	}
+	if used, err := keys.GenerationUsage(ctx, apiKey.ApiKeyID, now); err != nil || used != 0 {
+		t.Fatal(used, err)
+	}
+
+	// a new day starts with a fresh quota
+	if _, err := keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, 5, now.Add(24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keys.GenerationQuotaConsume(ctx, crypto.NewUUID(), 1, now); err != errors.ApiKeyNotFound {
+		t.Fatal(err)
+	}
+
+	// Without a quota, any number may be generated.
+	if err := keys.ApiKeySetDailyGenerationQuota(ctx, apiKey.ApiKeyID, nil); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err = keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, 1000, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining != nil {
+		t.Fatal(*remaining)
+	}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+const (
+	// DefaultSearchLimit is the number of matches a search returns when it
+	// doesn't set one.
+	DefaultSearchLimit = 20
+
+	// MaxSearchLimit caps the number of matches a search returns.
+	MaxSearchLimit = 100
+)
+
+type WordSearchArgs struct {
+	Query string
+
+	// Language and Part narrow the search when set.
+	Language string
+	Part     string
+
+	// Limit defaults to DefaultSearchLimit and is capped at MaxSearchLimit.
+	Limit int
+
+	ShowArchived bool
+}
+
+// WordMatch is a word found by a search. Score is the trigram similarity of
+// the word to the query, from 0 to 1. Prefix is set when the word starts with
+// the query.
+type WordMatch struct {
+	Word   Word    `json:"word"`
+	Score  float64 `json:"score"`
+	Prefix bool    `json:"prefix"`
+}
+
+// WordSearch finds words that start with the query or are spelled like it,
+// ignoring case, across languages and parts. Exact matches rank first, then
+// prefix matches, then the rest by similarity.
+func (dbal DBAL) WordSearch(ctx context.Context, args WordSearchArgs) (matches []WordMatch, err error) {
+	defer observeQuery("WordSearch", time.Now(), &err)
+
+	query := strings.ToLower(strings.TrimSpace(args.Query))
+	if query == "" {
+		return matches, nil
+	}
+
+	limit := args.Limit
+	switch {
+	case limit <= 0:
+		limit = DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		limit = MaxSearchLimit
+	}
+
+	// ------ build statement
+	q := &dbQuery{}
+	queryArg := q.arg(query)
+	prefixArg := q.arg(dbLikeEscape(query) + "%")
+
+	// % is pg_trgm's similarity operator, which the trigram index serves.
+	q.where("(lower(word) % " + queryArg + " OR lower(word) LIKE " + prefixArg + ")")
+	if args.Language != "" {
+		q.where("language = " + q.arg(args.Language))
+	}
+	if args.Part != "" {
+		q.where("part = " + q.arg(args.Part))
+	}
+	if !args.ShowArchived {
+		q.where("archived_at IS NULL")
+	}
+
+	stmt := `SELECT
+		word_id,
+		word,
+		language,
+		part,
+		created_at,
+		updated_at,
+		archived_at,
+		` + dbWordTagsColumn + `,
+		similarity(lower(word), ` + queryArg + `) AS score,
+		lower(word) LIKE ` + prefixArg + ` AS prefix
+		FROM words ` + q.whereClause() + `
+		ORDER BY lower(word) = ` + queryArg + ` DESC, prefix DESC, score DESC, word, word_id
+		LIMIT ` + q.arg(limit) + `;`
+
+	// ------- statement built
+
+	rows, err := dbal.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return matches, errors.UnexpectedError(err, "Failed searching words")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		match := WordMatch{}
+		if err := rows.Scan(
+			&match.Word.WordID,
+			&match.Word.Word,
+			&match.Word.Language,
+			&match.Word.Part,
+			&match.Word.CreatedAt,
+			&match.Word.UpdatedAt,
+			&match.Word.ArchivedAt,
+			pq.Array(&match.Word.Tags),
+			&match.Score,
+			&match.Prefix,
+		); err != nil {
+			return matches, errors.UnexpectedError(err, "Failed scanning word matches")
+		}
+
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return matches, errors.UnexpectedError(err, "Failed iterating word match rows")
+	}
+
+	return matches, nil
+}
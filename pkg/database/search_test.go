@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// DBAL.WordSearch
+// -----------------------------------------------------------------------------
+func TestDBAL_WordSearch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en-US", "en")
+
+	color, err := dbal.WordCreate(ctx, "Color", "en-US", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colour, err := dbal.WordCreate(ctx, "Colour", "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	colourful, err := dbal.WordCreate(ctx, "Colourful", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.WordCreate(ctx, "Hotel", "en", "noun"); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := dbal.WordSearch(ctx, WordSearchArgs{Query: "colour"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 3 {
+		t.Fatal(matches)
+	}
+	// Exact, then prefix, then similar.
+	if matches[0].Word.WordID != colour.WordID || matches[0].Score != 1 || !matches[0].Prefix {
+		t.Fatal(matches[0])
+	}
+	if matches[1].Word.WordID != colourful.WordID || !matches[1].Prefix {
+		t.Fatal(matches[1])
+	}
+	if matches[2].Word.WordID != color.WordID || matches[2].Prefix || matches[2].Score <= 0 {
+		t.Fatal(matches[2])
+	}
+
+	matches, err = dbal.WordSearch(ctx, WordSearchArgs{Query: "COLO", Language: "en", Part: "noun"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Word.WordID != colour.WordID {
+		t.Fatal(matches)
+	}
+
+	if err := dbal.WordSetArchive(ctx, colour.WordID); err != nil {
+		t.Fatal(err)
+	}
+	matches, err = dbal.WordSearch(ctx, WordSearchArgs{Query: "colour", Language: "en", Part: "noun"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Fatal(matches)
+	}
+
+	if matches, err := dbal.WordSearch(ctx, WordSearchArgs{Query: "  "}); err != nil || matches != nil {
+		t.Fatal(matches, err)
+	}
+}
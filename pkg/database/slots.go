@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+// Slot kinds. A part slot is filled by a word of its part.
+const (
+	SlotKindPart = "part"
+)
+
+// PatternSlot is a slot of a pattern, kept in sync with the pattern's text.
+// Position counts from 0.
+type PatternSlot struct {
+	PatternID string          `json:"patternID"`
+	Position  int             `json:"position"`
+	Kind      string          `json:"kind"`
+	Language  string          `json:"language"`
+	Part      string          `json:"part"`
+	Options   json.RawMessage `json:"options"`
+}
+
+// dbSyncPatternSlots replaces the slots of a pattern with those of its text.
+func dbSyncPatternSlots(ctx context.Context, tx dbExec, patternID, pattern, language string) error {
+	stmt := `DELETE FROM pattern_slots WHERE pattern_id=$1;`
+	if _, err := tx.ExecContext(ctx, stmt, patternID); err != nil {
+		return errors.UnexpectedError(err, "Failed clearing pattern slots")
+	}
+
+	stmt = `INSERT INTO pattern_slots (pattern_id, position, kind, language, part, options)
+		SELECT $1::uuid, s.position - 1, $4::text, $3::text, s.slot, '{}'
+		FROM unnest(string_to_array($2::text, ',')) WITH ORDINALITY AS s(slot, position);`
+
+	_, err := tx.ExecContext(ctx, stmt, patternID, pattern, language, SlotKindPart)
+	if dbIsForeignKeyErr(err, "pattern_slots_part_fkey") {
+		return errors.PartNotFound
+	}
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed creating pattern slots")
+	}
+	return nil
+}
+
+// dbSetPatternSlots checks the parts of a pattern's slots, then syncs them.
+func dbSetPatternSlots(ctx context.Context, tx *sql.Tx, patternID, pattern, language string) error {
+	if err := dbCheckPatternParts(ctx, tx, pattern, language); err != nil {
+		return err
+	}
+	return dbSyncPatternSlots(ctx, tx, patternID, pattern, language)
+}
+
+func (dbal DBAL) PatternSlots(ctx context.Context, patternID string) (slots []PatternSlot, err error) {
+	defer observeQuery("PatternSlots", time.Now(), &err)
+
+	if err := validators.UUID(patternID); err != nil {
+		return slots, errors.PatternNotFound
+	}
+
+	stmt := `SELECT
+                pattern_id,
+                position,
+                kind,
+                language,
+                COALESCE(part, ''),
+                options FROM pattern_slots WHERE pattern_id=$1
+                ORDER BY position;`
+
+	rows, err := dbal.QueryContext(ctx, stmt, patternID)
+	if err != nil {
+		return slots, errors.UnexpectedError(err, "Failed getting pattern slots")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot PatternSlot
+		if err := rows.Scan(
+			&slot.PatternID,
+			&slot.Position,
+			&slot.Kind,
+			&slot.Language,
+			&slot.Part,
+			&slot.Options,
+		); err != nil {
+			return slots, errors.UnexpectedError(err, "Failed scanning pattern slots")
+		}
+		slots = append(slots, slot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return slots, errors.UnexpectedError(err, "Failed iterating pattern slot rows")
+	}
+
+	return slots, nil
+}
+
+// PatternsUsingPart returns the patterns with a slot for a part, ordered by
+// pattern.
+func (dbal DBAL) PatternsUsingPart(ctx context.Context, language, part string, showArchived bool) (patterns []Pattern, err error) {
+	defer observeQuery("PatternsUsingPart", time.Now(), &err)
+
+	stmt := `SELECT
+                pattern_id,
+                pattern,
+                language,
+                created_at,
+                updated_at,
+                archived_at,
+                COALESCE(theme, '') FROM patterns p
+                WHERE ($3 OR archived_at IS NULL) AND EXISTS (
+                    SELECT 1 FROM pattern_slots s
+                    WHERE s.pattern_id=p.pattern_id AND s.language=$1 AND s.part=$2
+                )
+                ORDER BY pattern, pattern_id;`
+
+	return dbal.queryPatterns(ctx, "Failed getting patterns using part", stmt, language, part, showArchived)
+}
+
+// WordArchiveBreaks returns the unarchived patterns that would be left with a
+// slot no word can fill if the word were archived, because it's the last
+// unarchived word of its part. It's empty when archiving the word is safe.
+func (dbal DBAL) WordArchiveBreaks(ctx context.Context, wordID string) (patterns []Pattern, err error) {
+	defer observeQuery("WordArchiveBreaks", time.Now(), &err)
+
+	if err := validators.UUID(wordID); err != nil {
+		return patterns, errors.WordNotFound
+	}
+
+	word, err := dbal.WordGet(ctx, wordID)
+	if err != nil {
+		return patterns, err
+	}
+	if word.ArchivedAt != nil {
+		return patterns, nil
+	}
+
+	stmt := `SELECT
+                pattern_id,
+                pattern,
+                language,
+                created_at,
+                updated_at,
+                archived_at,
+                COALESCE(theme, '') FROM patterns p
+                WHERE archived_at IS NULL AND EXISTS (
+                    SELECT 1 FROM pattern_slots s
+                    WHERE s.pattern_id=p.pattern_id AND s.language=$1 AND s.part=$2
+                ) AND NOT EXISTS (
+                    SELECT 1 FROM words w
+                    WHERE w.language=$1 AND w.part=$2 AND w.archived_at IS NULL AND w.word_id<>$3
+                )
+                ORDER BY pattern, pattern_id;`
+
+	return dbal.queryPatterns(ctx, "Failed getting patterns broken by archiving word", stmt, word.Language, word.Part, wordID)
+}
+
+func (dbal DBAL) queryPatterns(ctx context.Context, msg, stmt string, args ...interface{}) (patterns []Pattern, err error) {
+	rows, err := dbal.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return patterns, errors.UnexpectedError(err, msg)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pattern Pattern
+		if err := rows.Scan(
+			&pattern.PatternID,
+			&pattern.Pattern,
+			&pattern.Language,
+			&pattern.CreatedAt,
+			&pattern.UpdatedAt,
+			&pattern.ArchivedAt,
+			&pattern.Theme,
+		); err != nil {
+			return patterns, errors.UnexpectedError(err, msg)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := rows.Err(); err != nil {
+		return patterns, errors.UnexpectedError(err, msg)
+	}
+
+	return patterns, nil
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+func slotParts(t *testing.T, dbal *DBAL, patternID string) (parts []string) {
+	ctx := context.Background()
+	slots, err := dbal.PatternSlots(ctx, patternID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, slot := range slots {
+		if slot.Position != i || slot.Kind != SlotKindPart || string(slot.Options) != "{}" {
+			t.Fatal(slot)
+		}
+		parts = append(parts, slot.Language+":"+slot.Part)
+	}
+	return parts
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.PatternSlots
+// -----------------------------------------------------------------------------
+func TestDBAL_PatternSlots(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	pattern, err := dbal.PatternCreate(ctx, "article,adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts := slotParts(t, dbal, pattern.PatternID); len(parts) != 3 ||
+		parts[0] != "en:article" || parts[1] != "en:adjective" || parts[2] != "en:noun" {
+		t.Fatal(parts)
+	}
+
+	if err := dbal.PatternSetPattern(ctx, pattern.PatternID, "noun,place"); err != nil {
+		t.Fatal(err)
+	}
+	if parts := slotParts(t, dbal, pattern.PatternID); len(parts) != 2 ||
+		parts[0] != "en:noun" || parts[1] != "en:place" {
+		t.Fatal(parts)
+	}
+
+	if err := dbal.PatternSetLanguage(ctx, pattern.PatternID, "fr"); err != nil {
+		t.Fatal(err)
+	}
+	if parts := slotParts(t, dbal, pattern.PatternID); len(parts) != 2 ||
+		parts[0] != "fr:noun" || parts[1] != "fr:place" {
+		t.Fatal(parts)
+	}
+
+	if err := dbal.PartRename(ctx, "fr", "place", "lieu"); err != nil {
+		t.Fatal(err)
+	}
+	if parts := slotParts(t, dbal, pattern.PatternID); len(parts) != 2 || parts[1] != "fr:lieu" {
+		t.Fatal(parts)
+	}
+
+	results, _, err := dbal.LexiconImport(ctx, []LexiconRow{
+		{Kind: RowKindPattern, Pattern: "adjective,place", Language: "en"},
+	}, ImportFail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts := slotParts(t, dbal, results[0].ID); len(parts) != 2 || parts[1] != "en:place" {
+		t.Fatal(parts)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DBAL.PatternsUsingPart
+// -----------------------------------------------------------------------------
+func TestDBAL_PatternsUsingPart(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	nounPlace, err := dbal.PatternCreate(ctx, "noun,place", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	adjNoun, err := dbal.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.PatternCreate(ctx, "article,adjective", "en"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.PatternCreate(ctx, "adjective,noun", "fr"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbal.PatternSetArchive(ctx, nounPlace.PatternID); err != nil {
+		t.Fatal(err)
+	}
+
+	patterns, err := dbal.PatternsUsingPart(ctx, "en", "noun", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].PatternID != adjNoun.PatternID {
+		t.Fatal(patterns)
+	}
+
+	patterns, err = dbal.PatternsUsingPart(ctx, "en", "noun", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 2 || patterns[0].PatternID != adjNoun.PatternID || patterns[1].PatternID != nounPlace.PatternID {
+		t.Fatal(patterns)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Store.WordArchiveBreaks
+// -----------------------------------------------------------------------------
+func testStoreWordArchiveBreaks(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	grand, err := store.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pink, err := store.WordCreate(ctx, "Pink", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern, err := store.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := store.PatternCreate(ctx, "adjective,adjective", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetArchive(ctx, archived.PatternID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another adjective is left.
+	patterns, err := store.WordArchiveBreaks(ctx, grand.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 0 {
+		t.Fatal(patterns)
+	}
+
+	if err := store.WordSetArchive(ctx, pink.WordID); err != nil {
+		t.Fatal(err)
+	}
+	patterns, err = store.WordArchiveBreaks(ctx, grand.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].PatternID != pattern.PatternID {
+		t.Fatal(patterns)
+	}
+
+	// Archiving an archived word breaks nothing new.
+	patterns, err = store.WordArchiveBreaks(ctx, pink.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 0 {
+		t.Fatal(patterns)
+	}
+
+	if _, err := store.WordArchiveBreaks(ctx, "invalidUUID"); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+}
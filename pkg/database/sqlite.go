@@ -0,0 +1,1227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// SQLiteStore is a Store kept in an embedded SQLite file, for single-user and
+// offline setups. It checks the constraints the Postgres schema enforces
+// itself, as SQLite doesn't name the foreign keys it finds broken.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+var (
+	_ Store    = (*SQLiteStore)(nil)
+	_ KeyStore = (*SQLiteStore)(nil)
+)
+
+// language=SQLite
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS languages (
+code        TEXT PRIMARY KEY,
+name        TEXT NOT NULL,
+script      TEXT NOT NULL,
+direction   TEXT NOT NULL CHECK (direction IN ('ltr', 'rtl')),
+separator   TEXT NOT NULL,
+created_at  TIMESTAMP NOT NULL,
+updated_at  TIMESTAMP NOT NULL,
+archived_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS parts (
+language   TEXT NOT NULL REFERENCES languages (code),
+part       TEXT NOT NULL,
+ud_tag     TEXT NOT NULL,
+created_at TIMESTAMP NOT NULL,
+updated_at TIMESTAMP NOT NULL,
+
+PRIMARY KEY (language, part)
+);
+
+CREATE TABLE IF NOT EXISTS words (
+word_id     TEXT PRIMARY KEY,
+word        TEXT NOT NULL,
+language    TEXT NOT NULL REFERENCES languages (code),
+part        TEXT NOT NULL,
+created_at  TIMESTAMP NOT NULL,
+updated_at  TIMESTAMP NOT NULL,
+archived_at TIMESTAMP,
+
+UNIQUE (language, part, word),
+FOREIGN KEY (language, part) REFERENCES parts (language, part)
+);
+
+CREATE TABLE IF NOT EXISTS patterns (
+pattern_id  TEXT PRIMARY KEY,
+pattern     TEXT NOT NULL,
+language    TEXT NOT NULL REFERENCES languages (code),
+created_at  TIMESTAMP NOT NULL,
+updated_at  TIMESTAMP NOT NULL,
+archived_at TIMESTAMP,
+
+UNIQUE (pattern, language)
+);
+`
+
+// sqliteUpgrades bring files created by earlier releases up to date, in
+// order. The file's user_version counts those it has.
+//
+// language=SQLite
+var sqliteUpgrades = []string{`
+CREATE TABLE word_tags (
+word_id    TEXT NOT NULL REFERENCES words (word_id) ON DELETE CASCADE,
+tag        TEXT NOT NULL,
+created_at TIMESTAMP NOT NULL,
+
+PRIMARY KEY (word_id, tag)
+);
+
+CREATE INDEX word_tags_tag ON word_tags (tag, word_id);
+
+ALTER TABLE patterns ADD COLUMN theme TEXT;
+`, `
+CREATE TABLE api_keys (
+api_key_id             TEXT PRIMARY KEY,
+name                   TEXT NOT NULL,
+key_hash               TEXT NOT NULL UNIQUE,
+scopes                 TEXT NOT NULL,
+created_at             TIMESTAMP NOT NULL,
+revoked_at             TIMESTAMP,
+daily_generation_quota INTEGER
+);
+
+CREATE TABLE generation_usage (
+api_key_id TEXT NOT NULL REFERENCES api_keys (api_key_id),
+day        TEXT NOT NULL,
+used       INTEGER NOT NULL,
+
+PRIMARY KEY (api_key_id, day)
+);
+`}
+
+// OpenSQLite opens the SQLite database at path, creating it and its tables as
+// needed. A path of ":memory:" keeps the database in memory.
+func OpenSQLite(path string) (s *SQLiteStore, err error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=1&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+
+	// One connection serialises writes, and keeps a ":memory:" database
+	// from being opened once per connection.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := sqliteUpgrade(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func sqliteUpgrade(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version;`).Scan(&version); err != nil {
+		return err
+	}
+
+	for ; version < len(sqliteUpgrades); version++ {
+		err := dbTX(context.Background(), db, func(tx *sql.Tx) error {
+			if _, err := tx.Exec(sqliteUpgrades[version]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`PRAGMA user_version = ` + strconv.Itoa(version+1) + `;`)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) PingContext(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// sqliteNow is the time rows are stamped with. SQLite compares times as
+// text, which only orders them when they're all in the same zone.
+func sqliteNow() time.Time {
+	return time.Now().UTC()
+}
+
+// sqliteCursor returns c with its times written as SQLite stores them, for
+// keyset to compare them as text.
+func sqliteCursor(c *listCursor) *listCursor {
+	if c == nil {
+		return nil
+	}
+
+	sc := *c
+	sc.Values = append([]string{}, c.Values...)
+	for i, key := range c.Keys {
+		switch strings.TrimPrefix(key, "-") {
+		case "createdAt", "updatedAt":
+			if t, err := time.Parse(time.RFC3339Nano, c.Values[i]); err == nil {
+				sc.Values[i] = t.UTC().Format(sqlite3.SQLiteTimestampFormats[0])
+			}
+		}
+	}
+	return &sc
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// sqliteExists runs a SELECT EXISTS statement.
+func sqliteExists(ctx context.Context, tx *sql.Tx, stmt string, args ...interface{}) (exists bool, err error) {
+	err = tx.QueryRowContext(ctx, stmt, args...).Scan(&exists)
+	return exists, err
+}
+
+// -----------------------------------------------------------------------------
+
+const sqliteWordColumns = `word_id, word, language, part, created_at, updated_at, archived_at`
+
+// sqliteWordSelect adds the word's tags, comma separated, to its columns.
+const sqliteWordSelect = sqliteWordColumns + `,
+	(SELECT group_concat(tag, ',') FROM word_tags t WHERE t.word_id=words.word_id)`
+
+func scanWord(row rowScanner) (word Word, err error) {
+	var tags sql.NullString
+	err = row.Scan(
+		&word.WordID,
+		&word.Word,
+		&word.Language,
+		&word.Part,
+		&word.CreatedAt,
+		&word.UpdatedAt,
+		&word.ArchivedAt,
+		&tags,
+	)
+
+	word.Tags = []string{}
+	if tags.String != "" {
+		word.Tags = strings.Split(tags.String, ",")
+		sort.Strings(word.Tags)
+	}
+	return word, err
+}
+
+func (s *SQLiteStore) queryWords(ctx context.Context, msg, stmt string, args ...interface{}) (words []Word, err error) {
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return words, errors.UnexpectedError(err, msg)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return words, errors.UnexpectedError(err, msg)
+		}
+		words = append(words, word)
+	}
+	if err := rows.Err(); err != nil {
+		return words, errors.UnexpectedError(err, msg)
+	}
+	return words, nil
+}
+
+// checkWord returns the error the words table's constraints raise in
+// Postgres for word, ignoring the row with word.WordID.
+func (s *SQLiteStore) checkWord(ctx context.Context, tx *sql.Tx, word Word) error {
+	for _, check := range []struct {
+		stmt   string
+		args   []interface{}
+		exists bool
+		err    error
+	}{
+		{`SELECT EXISTS (SELECT 1 FROM words WHERE word_id<>? AND language=? AND part=? AND word=?);`,
+			[]interface{}{word.WordID, word.Language, word.Part, word.Word}, true, errors.WordDuplicate},
+		{`SELECT EXISTS (SELECT 1 FROM languages WHERE code=?);`,
+			[]interface{}{word.Language}, false, errors.LanguageNotFound},
+		{`SELECT EXISTS (SELECT 1 FROM parts WHERE language=? AND part=?);`,
+			[]interface{}{word.Language, word.Part}, false, errors.PartNotFound},
+	} {
+		exists, err := sqliteExists(ctx, tx, check.stmt, check.args...)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking word")
+		}
+		if exists == check.exists {
+			return check.err
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) WordCreate(ctx context.Context, word_in, language, part string) (word Word, err error) {
+	word.WordID = crypto.NewUUID()
+	word.Word = word_in
+	word.Language = language
+	word.Part = part
+	word.CreatedAt = sqliteNow()
+	word.UpdatedAt = word.CreatedAt
+	word.Tags = []string{}
+
+	stmt := `INSERT INTO words (` + sqliteWordColumns + `) VALUES (?, ?, ?, ?, ?, ?, NULL);`
+
+	err = dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		if err := dbCheckLanguageActive(ctx, tx, word.Language); err != nil {
+			return err
+		}
+		if err := s.checkWord(ctx, tx, word); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, stmt, word.WordID, word.Word, word.Language, word.Part, word.CreatedAt, word.UpdatedAt)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed creating word")
+		}
+		return nil
+	})
+	return word, err
+}
+
+func (s *SQLiteStore) WordGet(ctx context.Context, wordID string) (word Word, err error) {
+	stmt := `SELECT ` + sqliteWordSelect + ` FROM words WHERE word_id=?;`
+
+	word, err = scanWord(s.db.QueryRowContext(ctx, stmt, wordID))
+	if err == sql.ErrNoRows {
+		return word, errors.WordNotFound
+	}
+	if err != nil {
+		return word, errors.UnexpectedError(err, "Failed getting word")
+	}
+	return word, nil
+}
+
+// setWord applies set to an unarchived word, if the result is valid.
+func (s *SQLiteStore) setWord(ctx context.Context, wordID string, set func(word *Word)) error {
+	stmt := `SELECT ` + sqliteWordSelect + ` FROM words WHERE word_id=? AND archived_at IS NULL;`
+
+	return dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		word, err := scanWord(tx.QueryRowContext(ctx, stmt, wordID))
+		if err == sql.ErrNoRows {
+			return errors.WordNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed getting word")
+		}
+
+		set(&word)
+		word.UpdatedAt = sqliteNow()
+		if err := s.checkWord(ctx, tx, word); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE words SET word=?, language=?, part=?, updated_at=? WHERE word_id=?;`,
+			word.Word, word.Language, word.Part, word.UpdatedAt, word.WordID)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed updating word")
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) WordSetWord(ctx context.Context, wordID, word string) error {
+	return s.setWord(ctx, wordID, func(w *Word) { w.Word = word })
+}
+
+func (s *SQLiteStore) WordSetLanguage(ctx context.Context, wordID, language string) error {
+	return s.setWord(ctx, wordID, func(w *Word) { w.Language = language })
+}
+
+func (s *SQLiteStore) WordSetPart(ctx context.Context, wordID, part string) error {
+	return s.setWord(ctx, wordID, func(w *Word) { w.Part = part })
+}
+
+func (s *SQLiteStore) WordUpdate(ctx context.Context, word Word) error {
+	return s.setWord(ctx, word.WordID, func(w *Word) {
+		w.Word, w.Language, w.Part = word.Word, word.Language, word.Part
+	})
+}
+
+func (s *SQLiteStore) WordSetArchive(ctx context.Context, wordID string) error {
+	return s.execOne(ctx, errors.WordNotFound, "Failed to archive word",
+		`UPDATE words SET archived_at=COALESCE(archived_at, ?) WHERE word_id=?;`, sqliteNow(), wordID)
+}
+
+func (s *SQLiteStore) WordSetUnArchive(ctx context.Context, wordID string) error {
+	return s.execOne(ctx, errors.WordNotFound, "Failed to unarchive word",
+		`UPDATE words SET archived_at=NULL WHERE word_id=?;`, wordID)
+}
+
+// WordList pages words in SQL, as DBAL.WordList does.
+func (s *SQLiteStore) WordList(ctx context.Context, listArgs WordListArgs) (words []Word, page Page, err error) {
+	if err := checkOrderBy(listArgs.OrderBy, WordSortKeys); err != nil {
+		return words, page, err
+	}
+	cursor, err := decodeCursor(listArgs.Cursor, listArgs.OrderBy)
+	if err != nil {
+		return words, page, err
+	}
+	limit := listLimit(listArgs.Limit)
+
+	q := &dbQuery{sqlite: true}
+	if err := listArgs.Filter.apply(q); err != nil {
+		return words, page, err
+	}
+	orderBy := keyset(listArgs.OrderBy, WordSortKeys, "word_id", sqliteCursor(cursor), q)
+
+	// One extra row tells whether there's another page.
+	stmt := `SELECT ` + sqliteWordSelect + ` FROM words ` + q.whereClause() + ` ` + orderBy + ` LIMIT ` + q.arg(limit+1) + `;`
+
+	words, err = s.queryWords(ctx, "Failed listing words", stmt, q.args...)
+	if err != nil {
+		return words, page, err
+	}
+
+	more := len(words) > limit
+	if more {
+		words = words[:limit]
+	}
+	// Pages before the cursor are read backwards.
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+			words[i], words[j] = words[j], words[i]
+		}
+	}
+
+	var first, last *listCursor
+	if len(words) > 0 {
+		f := newListCursor(listArgs.OrderBy, words[0].sortValue, words[0].WordID, true)
+		l := newListCursor(listArgs.OrderBy, words[len(words)-1].sortValue, words[len(words)-1].WordID, false)
+		first, last = &f, &l
+	}
+
+	return words, pageCursors(cursor, more, first, last), nil
+}
+
+func (s *SQLiteStore) WordRandom(ctx context.Context, language, part string, tags TagFilter) (word Word, err error) {
+	q := &dbQuery{sqlite: true}
+	q.where("language = " + q.arg(language))
+	q.where("part = " + q.arg(part))
+	q.where("archived_at IS NULL")
+	dbTagFilter(tags, q)
+
+	stmt := `SELECT ` + sqliteWordSelect + ` FROM words ` + q.whereClause() + ` ORDER BY RANDOM() LIMIT 1;`
+
+	word, err = scanWord(s.db.QueryRowContext(ctx, stmt, q.args...))
+	if err == sql.ErrNoRows {
+		return word, errors.WordNotFound
+	}
+	if err != nil {
+		return word, errors.UnexpectedError(err, "Failed getting word")
+	}
+	return word, nil
+}
+
+func (s *SQLiteStore) WordTag(ctx context.Context, wordID, tag string) error {
+	if err := ValidateTag(tag); err != nil {
+		return err
+	}
+
+	return dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM words WHERE word_id=?);`, wordID)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking word")
+		} else if !exists {
+			return errors.WordNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO word_tags (word_id, tag, created_at) VALUES (?, ?, ?);`,
+			wordID, tag, sqliteNow())
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed tagging word")
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) WordUntag(ctx context.Context, wordID, tag string) error {
+	return dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM words WHERE word_id=?);`, wordID)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking word")
+		} else if !exists {
+			return errors.WordNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM word_tags WHERE word_id=? AND tag=?;`, wordID, tag)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed untagging word")
+		}
+		return nil
+	})
+}
+
+// WordFacets counts the words matching filter in SQL, grouped by language,
+// part and archived state.
+func (s *SQLiteStore) WordFacets(ctx context.Context, filter WordFilter) (facets Facets, err error) {
+	q := &dbQuery{sqlite: true}
+	if err := filter.apply(q); err != nil {
+		return facets, err
+	}
+
+	stmt := `SELECT language, part, archived_at IS NOT NULL, COUNT(*) FROM words ` + q.whereClause() + `
+		GROUP BY language, part, archived_at IS NOT NULL;`
+
+	rows, err := s.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return facets, errors.UnexpectedError(err, "Failed counting words")
+	}
+	defer rows.Close()
+
+	facets = newFacets()
+	for rows.Next() {
+		var language, part string
+		var archived bool
+		var n int
+		if err := rows.Scan(&language, &part, &archived, &n); err != nil {
+			return facets, errors.UnexpectedError(err, "Failed scanning word counts")
+		}
+		facets.add(language, []string{part}, archived, n)
+	}
+	if err := rows.Err(); err != nil {
+		return facets, errors.UnexpectedError(err, "Failed iterating word counts")
+	}
+	return facets, nil
+}
+
+// WordArchiveBreaks returns the unarchived patterns archiving the word would
+// leave with a slot no word can fill, as DBAL.WordArchiveBreaks does.
+func (s *SQLiteStore) WordArchiveBreaks(ctx context.Context, wordID string) (patterns []Pattern, err error) {
+	word, err := s.WordGet(ctx, wordID)
+	if err != nil {
+		return patterns, err
+	}
+	if word.ArchivedAt != nil {
+		return patterns, nil
+	}
+
+	stmt := `SELECT ` + sqlitePatternSelect + ` FROM patterns
+		WHERE language=?1 AND archived_at IS NULL AND instr(',' || pattern || ',', ?2) > 0
+		AND NOT EXISTS (
+			SELECT 1 FROM words w
+			WHERE w.language=?1 AND w.part=?3 AND w.archived_at IS NULL AND w.word_id<>?4
+		)
+		ORDER BY pattern, pattern_id;`
+
+	return s.queryPatterns(ctx, "Failed getting patterns broken by archiving word", stmt,
+		word.Language, ","+word.Part+",", word.Part, wordID)
+}
+
+// -----------------------------------------------------------------------------
+
+const sqlitePatternColumns = `pattern_id, pattern, language, created_at, updated_at, archived_at`
+
+const sqlitePatternSelect = sqlitePatternColumns + `, COALESCE(theme, '')`
+
+func scanPattern(row rowScanner) (pattern Pattern, err error) {
+	err = row.Scan(
+		&pattern.PatternID,
+		&pattern.Pattern,
+		&pattern.Language,
+		&pattern.CreatedAt,
+		&pattern.UpdatedAt,
+		&pattern.ArchivedAt,
+		&pattern.Theme,
+	)
+	return pattern, err
+}
+
+func (s *SQLiteStore) queryPatterns(ctx context.Context, msg, stmt string, args ...interface{}) (patterns []Pattern, err error) {
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return patterns, errors.UnexpectedError(err, msg)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pattern, err := scanPattern(rows)
+		if err != nil {
+			return patterns, errors.UnexpectedError(err, msg)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := rows.Err(); err != nil {
+		return patterns, errors.UnexpectedError(err, msg)
+	}
+	return patterns, nil
+}
+
+// checkPattern returns the error the patterns table's constraints, and the
+// check of its slots' parts, raise in Postgres for pattern, ignoring the row
+// with pattern.PatternID.
+func (s *SQLiteStore) checkPattern(ctx context.Context, tx *sql.Tx, pattern Pattern) error {
+	exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM patterns WHERE pattern_id<>? AND pattern=? AND language=?);`,
+		pattern.PatternID, pattern.Pattern, pattern.Language)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed checking pattern")
+	} else if exists {
+		return errors.PatternDuplicate
+	}
+
+	exists, err = sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM languages WHERE code=?);`, pattern.Language)
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed checking pattern")
+	} else if !exists {
+		return errors.LanguageNotFound
+	}
+
+	for _, slot := range strings.Split(pattern.Pattern, ",") {
+		exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM parts WHERE language=? AND part=?);`, pattern.Language, slot)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking pattern parts")
+		} else if !exists {
+			return errors.PartNotFound.WithMsg(slot)
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) PatternCreate(ctx context.Context, pattern_in, language string) (pattern Pattern, err error) {
+	pattern.PatternID = crypto.NewUUID()
+	pattern.Pattern = pattern_in
+	pattern.Language = language
+	pattern.CreatedAt = sqliteNow()
+	pattern.UpdatedAt = pattern.CreatedAt
+
+	stmt := `INSERT INTO patterns (` + sqlitePatternColumns + `) VALUES (?, ?, ?, ?, ?, NULL);`
+
+	err = dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		if err := dbCheckLanguageActive(ctx, tx, pattern.Language); err != nil {
+			return err
+		}
+		if err := s.checkPattern(ctx, tx, pattern); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, stmt, pattern.PatternID, pattern.Pattern, pattern.Language, pattern.CreatedAt, pattern.UpdatedAt)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed creating pattern")
+		}
+		return nil
+	})
+	return pattern, err
+}
+
+func (s *SQLiteStore) PatternGet(ctx context.Context, patternID string) (pattern Pattern, err error) {
+	stmt := `SELECT ` + sqlitePatternSelect + ` FROM patterns WHERE pattern_id=?;`
+
+	pattern, err = scanPattern(s.db.QueryRowContext(ctx, stmt, patternID))
+	if err == sql.ErrNoRows {
+		return pattern, errors.PatternNotFound
+	}
+	if err != nil {
+		return pattern, errors.UnexpectedError(err, "Failed getting pattern")
+	}
+	return pattern, nil
+}
+
+// setPattern applies set to an unarchived pattern, if the result is valid.
+func (s *SQLiteStore) setPattern(ctx context.Context, patternID string, set func(pattern *Pattern)) error {
+	stmt := `SELECT ` + sqlitePatternSelect + ` FROM patterns WHERE pattern_id=? AND archived_at IS NULL;`
+
+	return dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		pattern, err := scanPattern(tx.QueryRowContext(ctx, stmt, patternID))
+		if err == sql.ErrNoRows {
+			return errors.PatternNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed getting pattern")
+		}
+
+		set(&pattern)
+		pattern.UpdatedAt = sqliteNow()
+		if err := s.checkPattern(ctx, tx, pattern); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE patterns SET pattern=?, language=?, updated_at=? WHERE pattern_id=?;`,
+			pattern.Pattern, pattern.Language, pattern.UpdatedAt, pattern.PatternID)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed updating pattern")
+		}
+		return nil
+	})
+}
+
+func (s *SQLiteStore) PatternSetPattern(ctx context.Context, patternID, pattern string) error {
+	return s.setPattern(ctx, patternID, func(p *Pattern) { p.Pattern = pattern })
+}
+
+func (s *SQLiteStore) PatternSetLanguage(ctx context.Context, patternID, language string) error {
+	return s.setPattern(ctx, patternID, func(p *Pattern) { p.Language = language })
+}
+
+func (s *SQLiteStore) PatternUpdate(ctx context.Context, pattern Pattern) error {
+	return s.setPattern(ctx, pattern.PatternID, func(p *Pattern) {
+		p.Pattern, p.Language = pattern.Pattern, pattern.Language
+	})
+}
+
+func (s *SQLiteStore) PatternSetTheme(ctx context.Context, patternID, theme string) error {
+	if theme != "" {
+		if err := ValidateTag(theme); err != nil {
+			return err
+		}
+	}
+	return s.execOne(ctx, errors.PatternNotFound, "Failed to set pattern theme",
+		`UPDATE patterns SET theme=NULLIF(?, ''), updated_at=? WHERE pattern_id=? AND archived_at IS NULL;`, theme, sqliteNow(), patternID)
+}
+
+func (s *SQLiteStore) PatternSetArchive(ctx context.Context, patternID string) error {
+	return s.execOne(ctx, errors.PatternNotFound, "Failed to archive pattern",
+		`UPDATE patterns SET archived_at=COALESCE(archived_at, ?) WHERE pattern_id=?;`, sqliteNow(), patternID)
+}
+
+func (s *SQLiteStore) PatternSetUnArchive(ctx context.Context, patternID string) error {
+	return s.execOne(ctx, errors.PatternNotFound, "Failed to unarchive pattern",
+		`UPDATE patterns SET archived_at=NULL WHERE pattern_id=?;`, patternID)
+}
+
+// PatternList pages patterns in SQL, as DBAL.PatternList does.
+func (s *SQLiteStore) PatternList(ctx context.Context, listArgs PatternListArgs) (patterns []Pattern, page Page, err error) {
+	if err := checkOrderBy(listArgs.OrderBy, PatternSortKeys); err != nil {
+		return patterns, page, err
+	}
+	cursor, err := decodeCursor(listArgs.Cursor, listArgs.OrderBy)
+	if err != nil {
+		return patterns, page, err
+	}
+	limit := listLimit(listArgs.Limit)
+
+	q := &dbQuery{sqlite: true}
+	if err := listArgs.Filter.apply(q); err != nil {
+		return patterns, page, err
+	}
+	orderBy := keyset(listArgs.OrderBy, PatternSortKeys, "pattern_id", sqliteCursor(cursor), q)
+
+	// One extra row tells whether there's another page.
+	stmt := `SELECT ` + sqlitePatternSelect + ` FROM patterns ` + q.whereClause() + ` ` + orderBy + ` LIMIT ` + q.arg(limit+1) + `;`
+
+	patterns, err = s.queryPatterns(ctx, "Failed listing patterns", stmt, q.args...)
+	if err != nil {
+		return patterns, page, err
+	}
+
+	more := len(patterns) > limit
+	if more {
+		patterns = patterns[:limit]
+	}
+	// Pages before the cursor are read backwards.
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(patterns)-1; i < j; i, j = i+1, j-1 {
+			patterns[i], patterns[j] = patterns[j], patterns[i]
+		}
+	}
+
+	var first, last *listCursor
+	if len(patterns) > 0 {
+		f := newListCursor(listArgs.OrderBy, patterns[0].sortValue, patterns[0].PatternID, true)
+		l := newListCursor(listArgs.OrderBy, patterns[len(patterns)-1].sortValue, patterns[len(patterns)-1].PatternID, false)
+		first, last = &f, &l
+	}
+
+	return patterns, pageCursors(cursor, more, first, last), nil
+}
+
+func (s *SQLiteStore) PatternRandom(ctx context.Context, language string) (pattern Pattern, err error) {
+	stmt := `SELECT ` + sqlitePatternSelect + ` FROM patterns
+		WHERE language=? AND archived_at IS NULL ORDER BY RANDOM() LIMIT 1;`
+
+	pattern, err = scanPattern(s.db.QueryRowContext(ctx, stmt, language))
+	if err == sql.ErrNoRows {
+		return pattern, errors.PatternNotFound
+	}
+	if err != nil {
+		return pattern, errors.UnexpectedError(err, "Failed getting pattern")
+	}
+	return pattern, nil
+}
+
+// PatternFacets counts the patterns matching filter. Slots are counted in Go,
+// from the patterns grouped by spelling.
+func (s *SQLiteStore) PatternFacets(ctx context.Context, filter PatternFilter) (facets Facets, err error) {
+	q := &dbQuery{sqlite: true}
+	if err := filter.apply(q); err != nil {
+		return facets, err
+	}
+
+	stmt := `SELECT pattern, language, archived_at IS NOT NULL, COUNT(*) FROM patterns ` + q.whereClause() + `
+		GROUP BY pattern, language, archived_at IS NOT NULL;`
+
+	rows, err := s.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return facets, errors.UnexpectedError(err, "Failed counting patterns")
+	}
+	defer rows.Close()
+
+	facets = newFacets()
+	for rows.Next() {
+		var pattern, language string
+		var archived bool
+		var n int
+		if err := rows.Scan(&pattern, &language, &archived, &n); err != nil {
+			return facets, errors.UnexpectedError(err, "Failed scanning pattern counts")
+		}
+		facets.add(language, strings.Split(pattern, ","), archived, n)
+	}
+	if err := rows.Err(); err != nil {
+		return facets, errors.UnexpectedError(err, "Failed iterating pattern counts")
+	}
+	return facets, nil
+}
+
+// -----------------------------------------------------------------------------
+
+const sqliteLanguageColumns = `code, name, script, direction, separator, created_at, updated_at, archived_at`
+
+func scanLanguage(row rowScanner) (language Language, err error) {
+	err = row.Scan(
+		&language.Code,
+		&language.Name,
+		&language.Script,
+		&language.Direction,
+		&language.Separator,
+		&language.CreatedAt,
+		&language.UpdatedAt,
+		&language.ArchivedAt,
+	)
+	return language, err
+}
+
+func (s *SQLiteStore) LanguageCreate(ctx context.Context, language_in Language) (language Language, err error) {
+	language = language_in
+	if language.Direction == "" {
+		language.Direction = DirectionLTR
+	}
+	if err := validateLanguage(language); err != nil {
+		return language, err
+	}
+
+	language.CreatedAt = sqliteNow()
+	language.UpdatedAt = language.CreatedAt
+	language.ArchivedAt = nil
+
+	stmt := `INSERT INTO languages (` + sqliteLanguageColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, NULL);`
+
+	err = dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM languages WHERE code=?);`, language.Code)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking language")
+		} else if exists {
+			return errors.LanguageDuplicate
+		}
+
+		_, err = tx.ExecContext(ctx, stmt,
+			language.Code,
+			language.Name,
+			language.Script,
+			language.Direction,
+			language.Separator,
+			language.CreatedAt,
+			language.UpdatedAt,
+		)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed creating language")
+		}
+		return nil
+	})
+	return language, err
+}
+
+func (s *SQLiteStore) LanguageGet(ctx context.Context, code string) (language Language, err error) {
+	stmt := `SELECT ` + sqliteLanguageColumns + ` FROM languages WHERE code=?;`
+
+	language, err = scanLanguage(s.db.QueryRowContext(ctx, stmt, code))
+	if err == sql.ErrNoRows {
+		return language, errors.LanguageNotFound
+	}
+	if err != nil {
+		return language, errors.UnexpectedError(err, "Failed getting language")
+	}
+	return language, nil
+}
+
+func (s *SQLiteStore) LanguageList(ctx context.Context, showArchived bool) (languages []Language, err error) {
+	stmt := `SELECT ` + sqliteLanguageColumns + ` FROM languages
+		WHERE ? OR archived_at IS NULL ORDER BY code;`
+
+	rows, err := s.db.QueryContext(ctx, stmt, showArchived)
+	if err != nil {
+		return languages, errors.UnexpectedError(err, "Failed listing languages")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		language, err := scanLanguage(rows)
+		if err != nil {
+			return languages, errors.UnexpectedError(err, "Failed scanning languages")
+		}
+		languages = append(languages, language)
+	}
+	if err := rows.Err(); err != nil {
+		return languages, errors.UnexpectedError(err, "Failed iterating languages")
+	}
+	return languages, nil
+}
+
+func (s *SQLiteStore) LanguageUpdate(ctx context.Context, language Language) error {
+	if err := validateLanguage(language); err != nil {
+		return err
+	}
+
+	return s.execOne(ctx, errors.LanguageNotFound, "Failed to update language",
+		`UPDATE languages SET name=?, script=?, direction=?, separator=?, updated_at=?
+		WHERE code=? AND archived_at IS NULL;`,
+		language.Name,
+		language.Script,
+		language.Direction,
+		language.Separator,
+		sqliteNow(),
+		language.Code,
+	)
+}
+
+func (s *SQLiteStore) LanguageSetArchive(ctx context.Context, code string) error {
+	return s.execOne(ctx, errors.LanguageNotFound, "Failed to archive language",
+		`UPDATE languages SET archived_at=COALESCE(archived_at, ?) WHERE code=?;`, sqliteNow(), code)
+}
+
+func (s *SQLiteStore) LanguageSetUnArchive(ctx context.Context, code string) error {
+	return s.execOne(ctx, errors.LanguageNotFound, "Failed to unarchive language",
+		`UPDATE languages SET archived_at=NULL WHERE code=?;`, code)
+}
+
+func (s *SQLiteStore) GetDistinctLanguages(ctx context.Context) (codes []string, err error) {
+	languages, err := s.LanguageList(ctx, false)
+	for _, language := range languages {
+		codes = append(codes, language.Code)
+	}
+	return codes, err
+}
+
+// -----------------------------------------------------------------------------
+
+const sqlitePartColumns = `language, part, ud_tag, created_at, updated_at`
+
+func scanPart(row rowScanner) (part Part, err error) {
+	err = row.Scan(
+		&part.Language,
+		&part.Part,
+		&part.UDTag,
+		&part.CreatedAt,
+		&part.UpdatedAt,
+	)
+	return part, err
+}
+
+func (s *SQLiteStore) PartCreate(ctx context.Context, part_in Part) (part Part, err error) {
+	part = part_in
+	if err := validatePartName(part.Part); err != nil {
+		return part, err
+	}
+	if err := validateUDTag(part.UDTag); err != nil {
+		return part, err
+	}
+
+	part.CreatedAt = sqliteNow()
+	part.UpdatedAt = part.CreatedAt
+
+	stmt := `INSERT INTO parts (` + sqlitePartColumns + `) VALUES (?, ?, ?, ?, ?);`
+
+	err = dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		exists, err := sqliteExists(ctx, tx, `SELECT EXISTS (SELECT 1 FROM parts WHERE language=? AND part=?);`, part.Language, part.Part)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed checking part")
+		} else if exists {
+			return errors.PartDuplicate
+		}
+
+		if err := dbCheckLanguageActive(ctx, tx, part.Language); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, stmt, part.Language, part.Part, part.UDTag, part.CreatedAt, part.UpdatedAt)
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed creating part")
+		}
+		return nil
+	})
+	return part, err
+}
+
+func (s *SQLiteStore) PartGet(ctx context.Context, language, part_in string) (part Part, err error) {
+	stmt := `SELECT ` + sqlitePartColumns + ` FROM parts WHERE language=? AND part=?;`
+
+	part, err = scanPart(s.db.QueryRowContext(ctx, stmt, language, part_in))
+	if err == sql.ErrNoRows {
+		return part, errors.PartNotFound
+	}
+	if err != nil {
+		return part, errors.UnexpectedError(err, "Failed getting part")
+	}
+	return part, nil
+}
+
+func (s *SQLiteStore) PartList(ctx context.Context, language string) (parts []Part, err error) {
+	stmt := `SELECT ` + sqlitePartColumns + ` FROM parts WHERE language=? ORDER BY part;`
+
+	rows, err := s.db.QueryContext(ctx, stmt, language)
+	if err != nil {
+		return parts, errors.UnexpectedError(err, "Failed listing parts")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		part, err := scanPart(rows)
+		if err != nil {
+			return parts, errors.UnexpectedError(err, "Failed scanning parts")
+		}
+		parts = append(parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return parts, errors.UnexpectedError(err, "Failed iterating parts")
+	}
+	return parts, nil
+}
+
+// -----------------------------------------------------------------------------
+
+func (s *SQLiteStore) LexiconLoad(ctx context.Context) (words []Word, patterns []Pattern, err error) {
+	words, err = s.queryWords(ctx, "Failed loading words",
+		`SELECT `+sqliteWordSelect+` FROM words WHERE archived_at IS NULL;`)
+	if err != nil {
+		return words, patterns, err
+	}
+	patterns, err = s.queryPatterns(ctx, "Failed loading patterns",
+		`SELECT `+sqlitePatternSelect+` FROM patterns WHERE archived_at IS NULL;`)
+	return words, patterns, err
+}
+
+// LexiconSizes counts the unarchived words of every language and part.
+func (s *SQLiteStore) LexiconSizes(ctx context.Context) (sizes []LexiconSize, err error) {
+	stmt := `SELECT language, part, COUNT(*) FROM words
+		WHERE archived_at IS NULL
+		GROUP BY language, part
+		ORDER BY language, part;`
+
+	rows, err := s.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return sizes, errors.UnexpectedError(err, "Failed counting lexicon")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		size := LexiconSize{}
+		if err := rows.Scan(&size.Language, &size.Part, &size.Words); err != nil {
+			return sizes, errors.UnexpectedError(err, "Failed scanning lexicon sizes")
+		}
+		sizes = append(sizes, size)
+	}
+	if err := rows.Err(); err != nil {
+		return sizes, errors.UnexpectedError(err, "Failed iterating lexicon sizes")
+	}
+	return sizes, nil
+}
+
+// execOne runs an update of one row, returning notFound when there's no row
+// to update.
+func (s *SQLiteStore) execOne(ctx context.Context, notFound error, msg, stmt string, args ...interface{}) error {
+	_, n, err := dbExecOne(ctx, s.db, stmt, args...)
+	if err != nil {
+		return errors.UnexpectedError(err, msg)
+	} else if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+
+// sqliteApiKeyColumns are scanned by scanApiKey. Scopes are kept comma
+// separated.
+const sqliteApiKeyColumns = `api_key_id, name, scopes, created_at, revoked_at, daily_generation_quota`
+
+func scanApiKey(row rowScanner) (apiKey ApiKey, err error) {
+	var scopes string
+	err = row.Scan(
+		&apiKey.ApiKeyID,
+		&apiKey.Name,
+		&scopes,
+		&apiKey.CreatedAt,
+		&apiKey.RevokedAt,
+		&apiKey.DailyGenerationQuota,
+	)
+
+	apiKey.Scopes = []string{}
+	if scopes != "" {
+		apiKey.Scopes = strings.Split(scopes, ",")
+	}
+	return apiKey, err
+}
+
+func (s *SQLiteStore) ApiKeyCreate(ctx context.Context, name string, scopes []string) (apiKey ApiKey, secret string, err error) {
+	apiKey, secret, err = newApiKey(name, scopes)
+	if err != nil {
+		return apiKey, secret, err
+	}
+	apiKey.CreatedAt = sqliteNow()
+
+	stmt := `INSERT INTO api_keys (api_key_id, name, key_hash, scopes, created_at) VALUES (?, ?, ?, ?, ?);`
+
+	_, err = s.db.ExecContext(ctx, stmt,
+		apiKey.ApiKeyID,
+		apiKey.Name,
+		hashApiKey(secret),
+		strings.Join(apiKey.Scopes, ","),
+		apiKey.CreatedAt,
+	)
+	if err != nil {
+		return apiKey, "", errors.UnexpectedError(err, "Failed creating api key")
+	}
+	return apiKey, secret, nil
+}
+
+func (s *SQLiteStore) ApiKeyGet(ctx context.Context, apiKeyID string) (apiKey ApiKey, err error) {
+	stmt := `SELECT ` + sqliteApiKeyColumns + ` FROM api_keys WHERE api_key_id=?;`
+
+	apiKey, err = scanApiKey(s.db.QueryRowContext(ctx, stmt, apiKeyID))
+	if err == sql.ErrNoRows {
+		return apiKey, errors.ApiKeyNotFound
+	}
+	if err != nil {
+		return apiKey, errors.UnexpectedError(err, "Failed getting api key")
+	}
+	return apiKey, nil
+}
+
+// ApiKeyAuthenticate returns the unrevoked key matching secret.
+func (s *SQLiteStore) ApiKeyAuthenticate(ctx context.Context, secret string) (apiKey ApiKey, err error) {
+	stmt := `SELECT ` + sqliteApiKeyColumns + ` FROM api_keys WHERE key_hash=? AND revoked_at IS NULL;`
+
+	apiKey, err = scanApiKey(s.db.QueryRowContext(ctx, stmt, hashApiKey(secret)))
+	if err == sql.ErrNoRows {
+		return apiKey, errors.AuthInvalid
+	}
+	if err != nil {
+		return apiKey, errors.UnexpectedError(err, "Failed authenticating api key")
+	}
+	return apiKey, nil
+}
+
+func (s *SQLiteStore) ApiKeyRevoke(ctx context.Context, apiKeyID string) error {
+	return s.execOne(ctx, errors.ApiKeyNotFound, "Failed to revoke api key",
+		`UPDATE api_keys SET revoked_at=COALESCE(revoked_at, ?) WHERE api_key_id=?;`, sqliteNow(), apiKeyID)
+}
+
+func (s *SQLiteStore) ApiKeySetDailyGenerationQuota(ctx context.Context, apiKeyID string, quota *int) error {
+	return s.execOne(ctx, errors.ApiKeyNotFound, "Failed to set daily generation quota",
+		`UPDATE api_keys SET daily_generation_quota=? WHERE api_key_id=?;`, quota, apiKeyID)
+}
+
+func (s *SQLiteStore) ApiKeyList(ctx context.Context) (apiKeys []ApiKey, err error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+sqliteApiKeyColumns+` FROM api_keys ORDER BY created_at;`)
+	if err != nil {
+		return apiKeys, errors.UnexpectedError(err, "Failed listing api keys")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return apiKeys, errors.UnexpectedError(err, "Failed scanning api keys")
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return apiKeys, errors.UnexpectedError(err, "Failed iterating api key rows")
+	}
+	return apiKeys, nil
+}
+
+// GenerationQuotaConsume records n generated aliases against the key's usage
+// for the UTC day of at, as DBAL.GenerationQuotaConsume does. The store's one
+// connection keeps concurrent calls from both passing the check.
+func (s *SQLiteStore) GenerationQuotaConsume(ctx context.Context, apiKeyID string, n int, at time.Time) (remaining *int, err error) {
+	day := at.UTC().Format("2006-01-02")
+
+	err = dbTX(ctx, s.db, func(tx *sql.Tx) error {
+		var quota *int
+		err := tx.QueryRowContext(ctx, `SELECT daily_generation_quota FROM api_keys WHERE api_key_id=?;`, apiKeyID).Scan(&quota)
+		if err == sql.ErrNoRows {
+			return errors.ApiKeyNotFound
+		}
+		if err != nil {
+			return errors.UnexpectedError(err, "Failed getting generation quota")
+		}
+
+		var used int
+		err = tx.QueryRowContext(ctx, `SELECT used FROM generation_usage WHERE api_key_id=? AND day=?;`, apiKeyID, day).Scan(&used)
+		if err != nil && err != sql.ErrNoRows {
+			return errors.UnexpectedError(err, "Failed getting generation usage")
+		}
+
+		if quota != nil && used+n > *quota {
+			left := *quota - used
+			remaining = &left
+			return errors.QuotaExceeded
+		}
+
+		stmt := `INSERT INTO generation_usage (api_key_id, day, used) VALUES (?, ?, ?)
+			ON CONFLICT (api_key_id, day) DO UPDATE SET used = used + excluded.used;`
+		if _, err := tx.ExecContext(ctx, stmt, apiKeyID, day, n); err != nil {
+			return errors.UnexpectedError(err, "Failed recording generation usage")
+		}
+
+		if quota != nil {
+			left := *quota - used - n
+			remaining = &left
+		}
+		return nil
+	})
+
+	return remaining, err
+}
+
+// GenerationQuotaRefund takes back n aliases consumed on the UTC day of at
+// that were never generated.
+func (s *SQLiteStore) GenerationQuotaRefund(ctx context.Context, apiKeyID string, n int, at time.Time) error {
+	stmt := `UPDATE generation_usage SET used = MAX(used - ?3, 0) WHERE api_key_id=?1 AND day=?2;`
+
+	if _, err := s.db.ExecContext(ctx, stmt, apiKeyID, at.UTC().Format("2006-01-02"), n); err != nil {
+		return errors.UnexpectedError(err, "Failed refunding generation usage")
+	}
+	return nil
+}
+
+// GenerationUsage returns how many aliases the key generated on the UTC day
+// of at.
+func (s *SQLiteStore) GenerationUsage(ctx context.Context, apiKeyID string, at time.Time) (used int, err error) {
+	stmt := `SELECT used FROM generation_usage WHERE api_key_id=? AND day=?;`
+
+	err = s.db.QueryRowContext(ctx, stmt, apiKeyID, at.UTC().Format("2006-01-02")).Scan(&used)
+	if err == nil || err == sql.ErrNoRows {
+		return used, nil
+	}
+	return 0, errors.UnexpectedError(err, "Failed getting generation usage")
+}
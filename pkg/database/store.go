@@ -0,0 +1,337 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Store holds the lexicon: languages, their parts, and the words and patterns
+// aliases are built from. *DBAL is the Postgres implementation; MemoryStore
+// and SQLiteStore serve single-user and offline setups.
+type Store interface {
+	WordCreate(ctx context.Context, word, language, part string) (Word, error)
+	WordGet(ctx context.Context, wordID string) (Word, error)
+	WordSetWord(ctx context.Context, wordID, word string) error
+	WordSetLanguage(ctx context.Context, wordID, language string) error
+	WordSetPart(ctx context.Context, wordID, part string) error
+	WordUpdate(ctx context.Context, word Word) error
+	WordSetArchive(ctx context.Context, wordID string) error
+	WordSetUnArchive(ctx context.Context, wordID string) error
+	WordList(ctx context.Context, listArgs WordListArgs) ([]Word, Page, error)
+	WordRandom(ctx context.Context, language, part string, tags TagFilter) (Word, error)
+	WordTag(ctx context.Context, wordID, tag string) error
+	WordUntag(ctx context.Context, wordID, tag string) error
+	WordFacets(ctx context.Context, filter WordFilter) (Facets, error)
+	WordArchiveBreaks(ctx context.Context, wordID string) ([]Pattern, error)
+
+	PatternCreate(ctx context.Context, pattern, language string) (Pattern, error)
+	PatternGet(ctx context.Context, patternID string) (Pattern, error)
+	PatternSetPattern(ctx context.Context, patternID, pattern string) error
+	PatternSetLanguage(ctx context.Context, patternID, language string) error
+	PatternUpdate(ctx context.Context, pattern Pattern) error
+	PatternSetTheme(ctx context.Context, patternID, theme string) error
+	PatternSetArchive(ctx context.Context, patternID string) error
+	PatternSetUnArchive(ctx context.Context, patternID string) error
+	PatternList(ctx context.Context, listArgs PatternListArgs) ([]Pattern, Page, error)
+	PatternRandom(ctx context.Context, language string) (Pattern, error)
+	PatternFacets(ctx context.Context, filter PatternFilter) (Facets, error)
+
+	LanguageCreate(ctx context.Context, language Language) (Language, error)
+	LanguageGet(ctx context.Context, code string) (Language, error)
+	LanguageList(ctx context.Context, showArchived bool) ([]Language, error)
+	LanguageUpdate(ctx context.Context, language Language) error
+	LanguageSetArchive(ctx context.Context, code string) error
+	LanguageSetUnArchive(ctx context.Context, code string) error
+	GetDistinctLanguages(ctx context.Context) ([]string, error)
+
+	PartCreate(ctx context.Context, part Part) (Part, error)
+	PartGet(ctx context.Context, language, part string) (Part, error)
+	PartList(ctx context.Context, language string) ([]Part, error)
+
+	LexiconLoad(ctx context.Context) (words []Word, patterns []Pattern, err error)
+	LexiconSizes(ctx context.Context) ([]LexiconSize, error)
+
+	Close() error
+}
+
+var _ Store = (*DBAL)(nil)
+
+// KeyStore holds API keys and their generation usage.
+type KeyStore interface {
+	ApiKeyCreate(ctx context.Context, name string, scopes []string) (apiKey ApiKey, secret string, err error)
+	ApiKeyGet(ctx context.Context, apiKeyID string) (ApiKey, error)
+	ApiKeyAuthenticate(ctx context.Context, secret string) (ApiKey, error)
+	ApiKeyRevoke(ctx context.Context, apiKeyID string) error
+	ApiKeySetDailyGenerationQuota(ctx context.Context, apiKeyID string, quota *int) error
+	ApiKeyList(ctx context.Context) ([]ApiKey, error)
+
+	GenerationQuotaConsume(ctx context.Context, apiKeyID string, n int, at time.Time) (remaining *int, err error)
+	GenerationQuotaRefund(ctx context.Context, apiKeyID string, n int, at time.Time) error
+	GenerationUsage(ctx context.Context, apiKeyID string, at time.Time) (used int, err error)
+}
+
+var _ KeyStore = (*DBAL)(nil)
+
+// KeyedStore is a Store that also holds the API keys, as every store
+// OpenStore opens does.
+type KeyedStore interface {
+	Store
+	KeyStore
+}
+
+// Searcher is a Store that can search words by spelling. Only Postgres
+// can, with pg_trgm.
+type Searcher interface {
+	WordSearch(ctx context.Context, args WordSearchArgs) ([]WordMatch, error)
+}
+
+// PartRenamer is a Store that can rename a part along with its words and
+// pattern slots.
+type PartRenamer interface {
+	PartRename(ctx context.Context, language, from, to string) error
+}
+
+// LexiconPorter is a Store that can import and export lexicon files.
+type LexiconPorter interface {
+	LexiconImport(ctx context.Context, rows []LexiconRow, strategy string) (results []ImportResult, index int, err error)
+	LexiconExport(ctx context.Context, language string, archived bool, fn func(row LexiconRow) error) error
+}
+
+var (
+	_ Searcher      = (*DBAL)(nil)
+	_ PartRenamer   = (*DBAL)(nil)
+	_ LexiconPorter = (*DBAL)(nil)
+)
+
+// Store drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+)
+
+// OpenStore opens the store config.Driver names, Postgres by default.
+// Postgres is bootstrapped as by Bootstrap; SQLite opens or creates the file
+// at config.Path.
+func OpenStore(config Config) (KeyedStore, error) {
+	switch config.Driver {
+	case "", DriverPostgres:
+		return Bootstrap(config)
+	case DriverMemory:
+		return NewMemoryStore(), nil
+	case DriverSQLite:
+		return OpenSQLite(config.Path)
+	}
+	return nil, errors.Unexpected.WithMsg("unknown store driver " + config.Driver)
+}
+
+// -----------------------------------------------------------------------------
+
+func checkArchivedFilter(archived string) error {
+	switch archived {
+	case "", ArchivedExclude, ArchivedOnly, ArchivedInclude:
+		return nil
+	}
+	return errors.InvalidFilter
+}
+
+// match applies the filters words and patterns share to a row, as apply does
+// in SQL.
+func (f timeFilter) match(createdAt, updatedAt time.Time, archivedAt *time.Time) bool {
+	switch f.archived {
+	case "", ArchivedExclude:
+		if archivedAt != nil {
+			return false
+		}
+	case ArchivedOnly:
+		if archivedAt == nil {
+			return false
+		}
+	}
+
+	return (f.createdAfter == nil || !createdAt.Before(*f.createdAfter)) &&
+		(f.createdBefore == nil || createdAt.Before(*f.createdBefore)) &&
+		(f.updatedAfter == nil || !updatedAt.Before(*f.updatedAfter)) &&
+		(f.updatedBefore == nil || updatedAt.Before(*f.updatedBefore))
+}
+
+func (f WordFilter) match(word Word) bool {
+	ok := timeFilter{
+		createdAfter:  f.CreatedAfter,
+		createdBefore: f.CreatedBefore,
+		updatedAfter:  f.UpdatedAfter,
+		updatedBefore: f.UpdatedBefore,
+		archived:      f.Archived,
+	}.match(word.CreatedAt, word.UpdatedAt, word.ArchivedAt)
+
+	lower := strings.ToLower(word.Word)
+	return ok &&
+		(f.Language == "" || word.Language == f.Language) &&
+		(f.Part == "" || word.Part == f.Part) &&
+		strings.HasPrefix(lower, strings.ToLower(f.WordPrefix)) &&
+		strings.Contains(lower, strings.ToLower(f.WordContains)) &&
+		(f.Tag == "" || TagFilter{IncludeTags: []string{f.Tag}}.Match(word.Tags))
+}
+
+func (f PatternFilter) match(pattern Pattern) bool {
+	ok := timeFilter{
+		createdAfter:  f.CreatedAfter,
+		createdBefore: f.CreatedBefore,
+		updatedAfter:  f.UpdatedAfter,
+		updatedBefore: f.UpdatedBefore,
+		archived:      f.Archived,
+	}.match(pattern.CreatedAt, pattern.UpdatedAt, pattern.ArchivedAt)
+
+	hasPart := f.Part == ""
+	for _, slot := range strings.Split(pattern.Pattern, ",") {
+		hasPart = hasPart || slot == f.Part
+	}
+	return ok && hasPart &&
+		(f.Language == "" || pattern.Language == f.Language) &&
+		strings.Contains(pattern.Pattern, f.PatternContains)
+}
+
+// compareSortValues orders two values of a sort key. Times are compared as
+// times, as their cursor form isn't fixed width.
+func compareSortValues(key, a, b string) int {
+	if key == "createdAt" || key == "updatedAt" {
+		ta, _ := time.Parse(time.RFC3339Nano, a)
+		tb, _ := time.Parse(time.RFC3339Nano, b)
+		switch {
+		case ta.Before(tb):
+			return -1
+		case ta.After(tb):
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// listRows pages n rows in Go the way keyset pages them in SQL, for stores
+// that list from rows they hold or have loaded. It returns the indices of the
+// page's rows in list order.
+func listRows(n int, sorts []OrderBy, keys map[string]string, value func(i int, key string) string, id func(i int) string, cursor_in string, limit_in int) (rows []int, page Page, err error) {
+	if err := checkOrderBy(sorts, keys); err != nil {
+		return nil, page, err
+	}
+	cursor, err := decodeCursor(cursor_in, sorts)
+	if err != nil {
+		return nil, page, err
+	}
+	limit := listLimit(limit_in)
+	backward := cursor != nil && cursor.Before
+
+	// compare orders a row against another row's, or the cursor's, values.
+	compare := func(i int, values func(key string) string, valueID string) int {
+		for _, s := range sorts {
+			c := compareSortValues(s.Key, value(i, s.Key), values(s.Key))
+			if s.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(id(i), valueID)
+	}
+
+	for i := 0; i < n; i++ {
+		if cursor != nil {
+			c := compare(i, cursor.value, cursor.ID)
+			if backward && c >= 0 || !backward && c <= 0 {
+				continue
+			}
+		}
+		rows = append(rows, i)
+	}
+	sort.Slice(rows, func(a, b int) bool {
+		c := compare(rows[a], func(key string) string { return value(rows[b], key) }, id(rows[b]))
+		if backward {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	var first, last *listCursor
+	if len(rows) > 0 {
+		firstRow, lastRow := rows[0], rows[len(rows)-1]
+		f := newListCursor(sorts, func(key string) string { return value(firstRow, key) }, id(firstRow), true)
+		l := newListCursor(sorts, func(key string) string { return value(lastRow, key) }, id(lastRow), false)
+		first, last = &f, &l
+	}
+
+	return rows, pageCursors(cursor, more, first, last), nil
+}
+
+// value returns the cursor's value for a sort key.
+func (c listCursor) value(key string) string {
+	for i, k := range c.Keys {
+		if strings.TrimPrefix(k, "-") == key {
+			return c.Values[i]
+		}
+	}
+	return ""
+}
+
+// listWords pages words with listRows.
+func listWords(words []Word, listArgs WordListArgs) (result []Word, page Page, err error) {
+	if err := checkArchivedFilter(listArgs.Filter.Archived); err != nil {
+		return nil, page, err
+	}
+
+	var matched []Word
+	for _, word := range words {
+		if listArgs.Filter.match(word) {
+			matched = append(matched, word)
+		}
+	}
+
+	rows, page, err := listRows(len(matched), listArgs.OrderBy, WordSortKeys,
+		func(i int, key string) string { return matched[i].sortValue(key) },
+		func(i int) string { return matched[i].WordID },
+		listArgs.Cursor, listArgs.Limit,
+	)
+	for _, i := range rows {
+		result = append(result, matched[i])
+	}
+	return result, page, err
+}
+
+// listPatterns pages patterns with listRows.
+func listPatterns(patterns []Pattern, listArgs PatternListArgs) (result []Pattern, page Page, err error) {
+	if err := checkArchivedFilter(listArgs.Filter.Archived); err != nil {
+		return nil, page, err
+	}
+
+	var matched []Pattern
+	for _, pattern := range patterns {
+		if listArgs.Filter.match(pattern) {
+			matched = append(matched, pattern)
+		}
+	}
+
+	rows, page, err := listRows(len(matched), listArgs.OrderBy, PatternSortKeys,
+		func(i int, key string) string { return matched[i].sortValue(key) },
+		func(i int) string { return matched[i].PatternID },
+		listArgs.Cursor, listArgs.Limit,
+	)
+	for _, i := range rows {
+		result = append(result, matched[i])
+	}
+	return result, page, err
+}
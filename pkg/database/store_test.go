@@ -0,0 +1,668 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// -----------------------------------------------------------------------------
+// Store backends
+// -----------------------------------------------------------------------------
+func TestDBAL_Store(t *testing.T) {
+	testStore(t, func(t *testing.T) (Store, func()) {
+		return NewTestDBAL(t)
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(t *testing.T) (Store, func()) {
+		return NewMemoryStore(), func() {}
+	})
+}
+
+func TestSQLiteStore(t *testing.T) {
+	testStore(t, func(t *testing.T) (Store, func()) {
+		store, err := OpenSQLite(":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store, func() { store.Close() }
+	})
+}
+
+// testStore runs the contract every Store must meet, opening a fresh store
+// for each case.
+func testStore(t *testing.T, open func(t *testing.T) (Store, func())) {
+	for _, c := range []struct {
+		name string
+		fn   func(t *testing.T, store Store)
+	}{
+		{"Languages", testStoreLanguages},
+		{"Parts", testStoreParts},
+		{"Words", testStoreWords},
+		{"WordSet", testStoreWordSet},
+		{"Patterns", testStorePatterns},
+		{"Random", testStoreRandom},
+		{"WordList", testStoreWordList},
+		{"PatternList", testStorePatternList},
+		{"LexiconLoad", testStoreLexiconLoad},
+		{"Tags", testStoreTags},
+		{"Theme", testStoreTheme},
+		{"WordFacets", testStoreWordFacets},
+		{"PatternFacets", testStorePatternFacets},
+		{"WordArchiveBreaks", testStoreWordArchiveBreaks},
+		{"LexiconSizes", testStoreLexiconSizes},
+		{"ApiKeys", testStoreApiKeys},
+		{"GenerationQuota", testStoreGenerationQuota},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			store, close := open(t)
+			defer close()
+			c.fn(t, store)
+		})
+	}
+}
+
+func testStoreLanguages(t *testing.T, store Store) {
+	ctx := context.Background()
+	language, err := store.LanguageCreate(ctx, Language{Code: "ar", Name: "Arabic", Script: "Arab", Direction: DirectionRTL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if language.ArchivedAt != nil || !language.CreatedAt.Equal(language.UpdatedAt) {
+		t.Fatal(language)
+	}
+	if _, err := store.LanguageCreate(ctx, Language{Code: "ar", Name: "Arabic"}); err != errors.LanguageDuplicate {
+		t.Fatal(err)
+	}
+	if _, err := store.LanguageCreate(ctx, Language{Code: "1a", Name: "Digit"}); !errors.LanguageInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+
+	en, err := store.LanguageCreate(ctx, Language{Code: "en", Name: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if en.Direction != DirectionLTR {
+		t.Fatal(en)
+	}
+	if err := store.LanguageUpdate(ctx, Language{Code: "en", Name: "English", Direction: DirectionLTR, Separator: "-"}); err != nil {
+		t.Fatal(err)
+	}
+	if en, err = store.LanguageGet(ctx, "en"); err != nil {
+		t.Fatal(err)
+	}
+	if en.Name != "English" || en.Separator != "-" || !en.UpdatedAt.After(en.CreatedAt) {
+		t.Fatal(en)
+	}
+	if _, err := store.LanguageGet(ctx, "fr"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+
+	if err := store.LanguageSetArchive(ctx, "ar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.LanguageUpdate(ctx, Language{Code: "ar", Name: "Arabic", Direction: DirectionRTL}); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if _, err := store.PartCreate(ctx, Part{Language: "ar", Part: "noun"}); err != errors.LanguageArchived {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "kitab", "ar", "noun"); err != errors.LanguageArchived {
+		t.Fatal(err)
+	}
+	if _, err := store.PatternCreate(ctx, "noun", "ar"); err != errors.LanguageArchived {
+		t.Fatal(err)
+	}
+	languages, err := store.LanguageList(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 1 || languages[0].Code != "en" {
+		t.Fatal(languages)
+	}
+	languages, err = store.LanguageList(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 2 || languages[0].Code != "ar" || languages[0].ArchivedAt == nil {
+		t.Fatal(languages)
+	}
+	codes, err := store.GetDistinctLanguages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 1 || codes[0] != "en" {
+		t.Fatal(codes)
+	}
+
+	if err := store.LanguageSetUnArchive(ctx, "ar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.LanguageSetArchive(ctx, "fr"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if err := store.LanguageSetUnArchive(ctx, "fr"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+func testStoreParts(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "colour", UDTag: "ADJ"}); err != nil {
+		t.Fatal(err)
+	}
+	part, err := store.PartGet(ctx, "en", "colour")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.UDTag != "ADJ" {
+		t.Fatal(part)
+	}
+
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "noun"}); err != errors.PartDuplicate {
+		t.Fatal(err)
+	}
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "Noun"}); !errors.PartInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "animal", UDTag: "ANIMAL"}); !errors.PartInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+	if _, err := store.PartCreate(ctx, Part{Language: "fr", Part: "noun"}); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if _, err := store.PartGet(ctx, "en", "animal"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+
+	parts, err := store.PartList(ctx, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != len(testParts)+1 || parts[0].Part != "adjective" || parts[2].Part != "colour" {
+		t.Fatal(parts)
+	}
+}
+
+func testStoreWords(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	word, err := store.WordCreate(ctx, "grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.WordGet(ctx, word.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.WordID != word.WordID || got.Word != "grand" || got.Language != "en" || got.Part != "adjective" || got.ArchivedAt != nil {
+		t.Fatal(got)
+	}
+
+	if _, err := store.WordCreate(ctx, "grand", "en", "adjective"); err != errors.WordDuplicate {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "grand", "fr", "adjective"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "grand", "en", "adj"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	for _, wordID := range []string{crypto.NewUUID(), "not-a-uuid"} {
+		if _, err := store.WordGet(ctx, wordID); err != errors.WordNotFound {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.WordSetArchive(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = store.WordGet(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if got.ArchivedAt == nil {
+		t.Fatal(got)
+	}
+	// Archived words can't be edited, and still count as duplicates.
+	if err := store.WordSetWord(ctx, word.WordID, "big"); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "grand", "en", "adjective"); err != errors.WordDuplicate {
+		t.Fatal(err)
+	}
+
+	if err := store.WordSetUnArchive(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = store.WordGet(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if got.ArchivedAt != nil {
+		t.Fatal(got)
+	}
+	if err := store.WordSetArchive(ctx, crypto.NewUUID()); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+}
+
+func testStoreWordSet(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en", "fr")
+
+	word, err := store.WordCreate(ctx, "grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.WordCreate(ctx, "big", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "size"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.WordSetWord(ctx, word.WordID, "big"); err != errors.WordDuplicate {
+		t.Fatal(err)
+	}
+	if err := store.WordSetWord(ctx, word.WordID, "large"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetPart(ctx, word.WordID, "size"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetPart(ctx, word.WordID, "colour"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	// fr has no "size" part.
+	if err := store.WordSetLanguage(ctx, word.WordID, "fr"); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	if err := store.WordSetLanguage(ctx, word.WordID, "de"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if err := store.WordSetPart(ctx, word.WordID, "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetLanguage(ctx, word.WordID, "fr"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.WordGet(ctx, word.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Word != "large" || got.Language != "fr" || got.Part != "adjective" || !got.UpdatedAt.After(got.CreatedAt) {
+		t.Fatal(got)
+	}
+	if err := store.WordSetWord(ctx, crypto.NewUUID(), "large"); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+
+	// WordUpdate moves language and part together; en has no "shade" part
+	// and fr no "size" part, so neither setter alone could make this move.
+	if _, err := store.PartCreate(ctx, Part{Language: "fr", Part: "shade"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordUpdate(ctx, Word{WordID: word.WordID, Word: "large", Language: "en", Part: "size"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordUpdate(ctx, Word{WordID: word.WordID, Word: "vaste", Language: "fr", Part: "shade"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordUpdate(ctx, Word{WordID: word.WordID, Word: "large", Language: "en", Part: "shade"}); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	if got, err = store.WordGet(ctx, word.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Word != "vaste" || got.Language != "fr" || got.Part != "shade" {
+		t.Fatal(got)
+	}
+	if err := store.WordUpdate(ctx, Word{WordID: crypto.NewUUID(), Word: "large", Language: "en", Part: "size"}); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+}
+
+func testStorePatterns(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en", "fr")
+
+	pattern, err := store.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.PatternGet(ctx, pattern.PatternID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Pattern != "adjective,noun" || got.Language != "en" || got.ArchivedAt != nil {
+		t.Fatal(got)
+	}
+
+	if _, err := store.PatternCreate(ctx, "adjective,noun", "en"); err != errors.PatternDuplicate {
+		t.Fatal(err)
+	}
+	if _, err := store.PatternCreate(ctx, "adjective,noun", "de"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	_, err = store.PatternCreate(ctx, "adjective,animal,colour", "en")
+	if !errors.PartNotFound.Equals(err) || err.(errors.Error).Msg() != "animal" {
+		t.Fatal(err)
+	}
+	if _, err := store.PatternGet(ctx, crypto.NewUUID()); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+
+	if err := store.PatternSetPattern(ctx, pattern.PatternID, "noun,animal"); !errors.PartNotFound.Equals(err) {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetPattern(ctx, pattern.PatternID, "article,noun"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetLanguage(ctx, pattern.PatternID, "de"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetLanguage(ctx, pattern.PatternID, "fr"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = store.PatternGet(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Pattern != "article,noun" || got.Language != "fr" || !got.UpdatedAt.After(got.CreatedAt) {
+		t.Fatal(got)
+	}
+
+	// PatternUpdate checks the new pattern against the new language.
+	if _, err := store.PartCreate(ctx, Part{Language: "en", Part: "animal"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternUpdate(ctx, Pattern{PatternID: pattern.PatternID, Pattern: "noun,animal", Language: "en"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternUpdate(ctx, Pattern{PatternID: pattern.PatternID, Pattern: "noun", Language: "de"}); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if got, err = store.PatternGet(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Pattern != "noun,animal" || got.Language != "en" {
+		t.Fatal(got)
+	}
+
+	if err := store.PatternSetArchive(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetPattern(ctx, pattern.PatternID, "noun"); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetUnArchive(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetArchive(ctx, crypto.NewUUID()); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+}
+
+func testStoreRandom(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	word, err := store.WordCreate(ctx, "grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := store.WordCreate(ctx, "big", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+	pattern, err := store.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		got, err := store.WordRandom(ctx, "en", "adjective", TagFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.WordID != word.WordID {
+			t.Fatal(got)
+		}
+	}
+	if _, err := store.WordRandom(ctx, "en", "noun", TagFilter{}); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+
+	got, err := store.PatternRandom(ctx, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PatternID != pattern.PatternID {
+		t.Fatal(got)
+	}
+	if err := store.PatternSetArchive(ctx, pattern.PatternID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PatternRandom(ctx, "en"); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+}
+
+func testStoreWordList(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en", "fr")
+
+	for _, w := range []struct{ word, language, part string }{
+		{"amber", "en", "adjective"},
+		{"grand", "en", "adjective"},
+		{"green", "en", "adjective"},
+		{"house", "en", "noun"},
+		{"maison", "fr", "noun"},
+	} {
+		if _, err := store.WordCreate(ctx, w.word, w.language, w.part); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archived, err := store.WordCreate(ctx, "gross", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+
+	wordsOf := func(words []Word) string {
+		var s []string
+		for _, word := range words {
+			s = append(s, word.Word)
+		}
+		return strings.Join(s, ",")
+	}
+
+	args := WordListArgs{
+		Filter:  WordFilter{Language: "en"},
+		OrderBy: []OrderBy{{Key: "word", Desc: true}},
+		Limit:   2,
+	}
+	words, page, err := store.WordList(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "house,green" || page.NextCursor == "" || page.PrevCursor != "" {
+		t.Fatal(wordsOf(words), page)
+	}
+
+	args.Cursor = page.NextCursor
+	words, page, err = store.WordList(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "grand,amber" || page.NextCursor != "" || page.PrevCursor == "" {
+		t.Fatal(wordsOf(words), page)
+	}
+
+	args.Cursor = page.PrevCursor
+	words, _, err = store.WordList(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "house,green" {
+		t.Fatal(wordsOf(words))
+	}
+
+	words, _, err = store.WordList(ctx, WordListArgs{
+		Filter:  WordFilter{WordPrefix: "G", Archived: ArchivedInclude},
+		OrderBy: []OrderBy{{Key: "word"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "grand,green,gross" {
+		t.Fatal(wordsOf(words))
+	}
+
+	words, _, err = store.WordList(ctx, WordListArgs{
+		Filter:  WordFilter{Part: "noun", WordContains: "s"},
+		OrderBy: []OrderBy{{Key: "language"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "house,maison" {
+		t.Fatal(wordsOf(words))
+	}
+
+	// newest first, a word at a time, then back again
+	args = WordListArgs{
+		Filter:  WordFilter{Language: "en", Part: "adjective"},
+		OrderBy: []OrderBy{{Key: "createdAt", Desc: true}},
+		Limit:   1,
+	}
+	var seen []string
+	for {
+		words, page, err = store.WordList(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, wordsOf(words))
+		if page.NextCursor == "" {
+			break
+		}
+		args.Cursor = page.NextCursor
+	}
+	if strings.Join(seen, ",") != "green,grand,amber" {
+		t.Fatal(seen)
+	}
+	args.Cursor = page.PrevCursor
+	words, _, err = store.WordList(ctx, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wordsOf(words) != "grand" {
+		t.Fatal(wordsOf(words))
+	}
+
+	if _, _, err := store.WordList(ctx, WordListArgs{OrderBy: []OrderBy{{Key: "wordID"}}}); err != errors.InvalidOrderBy {
+		t.Fatal(err)
+	}
+	if _, _, err := store.WordList(ctx, WordListArgs{Filter: WordFilter{Archived: "all"}}); err != errors.InvalidFilter {
+		t.Fatal(err)
+	}
+	if _, _, err := store.WordList(ctx, WordListArgs{Cursor: "garbage"}); err != errors.InvalidCursor {
+		t.Fatal(err)
+	}
+}
+
+func testStorePatternList(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	for _, pattern := range []string{"adjective,noun", "article,noun", "verb,place"} {
+		if _, err := store.PatternCreate(ctx, pattern, "en"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	patterns, page, err := store.PatternList(ctx, PatternListArgs{
+		Filter:  PatternFilter{Part: "noun"},
+		OrderBy: []OrderBy{{Key: "createdAt"}},
+		Limit:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].Pattern != "adjective,noun" || page.NextCursor == "" {
+		t.Fatal(patterns, page)
+	}
+
+	patterns, page, err = store.PatternList(ctx, PatternListArgs{
+		Filter:  PatternFilter{Part: "noun"},
+		OrderBy: []OrderBy{{Key: "createdAt"}},
+		Limit:   1,
+		Cursor:  page.NextCursor,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].Pattern != "article,noun" || page.NextCursor != "" {
+		t.Fatal(patterns, page)
+	}
+
+	patterns, _, err = store.PatternList(ctx, PatternListArgs{Filter: PatternFilter{PatternContains: "place"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].Pattern != "verb,place" {
+		t.Fatal(patterns)
+	}
+
+	// pattern matching is case sensitive, and parts match whole slots
+	for _, f := range []PatternFilter{{PatternContains: "Place"}, {Part: "nou"}} {
+		patterns, _, err = store.PatternList(ctx, PatternListArgs{Filter: f})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(patterns) != 0 {
+			t.Fatal(f, patterns)
+		}
+	}
+}
+
+func testStoreLexiconLoad(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	if _, err := store.WordCreate(ctx, "grand", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := store.WordCreate(ctx, "big", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordSetArchive(ctx, archived.WordID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PatternCreate(ctx, "adjective,noun", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	words, patterns, err := store.LexiconLoad(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 1 || words[0].Word != "grand" || len(patterns) != 1 {
+		t.Fatal(words, patterns)
+	}
+}
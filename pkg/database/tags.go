@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+)
+
+// dbWordTagsColumn selects the tags of the row of words, sorted, as an array.
+const dbWordTagsColumn = `ARRAY(SELECT tag FROM word_tags t WHERE t.word_id=words.word_id ORDER BY tag COLLATE "C")`
+
+// ValidateTag returns TagInvalid unless tag is 1 to 32 characters from the
+// tag charset. Tags and themes are checked with it wherever they come from.
+func ValidateTag(tag string) error {
+	if tag == "" || len(tag) > 32 {
+		return errors.TagInvalid.WithMsg("tag must be 1 to 32 characters")
+	}
+	for _, r := range tag {
+		if !validators.Charsets["tag"](r) {
+			return errors.TagInvalid.WithMsg("tag may only contain a-z, 0-9, - and _")
+		}
+	}
+	return nil
+}
+
+// TagFilter narrows the words drawn for an alias by their tags. A word
+// matches when it has one of IncludeTags, or IncludeTags is empty, and none
+// of ExcludeTags.
+type TagFilter struct {
+	IncludeTags []string `json:"includeTags"`
+	ExcludeTags []string `json:"excludeTags"`
+}
+
+// Match reports whether a word with tags passes the filter.
+func (f TagFilter) Match(tags []string) bool {
+	has := func(want []string) bool {
+		for _, w := range want {
+			for _, tag := range tags {
+				if tag == w {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return (len(f.IncludeTags) == 0 || has(f.IncludeTags)) && !has(f.ExcludeTags)
+}
+
+// WordTag tags a word. Tagging it again with the same tag does nothing.
+func (dbal DBAL) WordTag(ctx context.Context, wordID, tag string) (err error) {
+	defer observeQuery("WordTag", time.Now(), &err)
+
+	if err := validators.UUID(wordID); err != nil {
+		return errors.WordNotFound
+	}
+	if err := ValidateTag(tag); err != nil {
+		return err
+	}
+
+	stmt := `INSERT INTO word_tags (word_id, tag, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (word_id, tag) DO NOTHING;`
+
+	_, err = dbal.ExecContext(ctx, stmt, wordID, tag, time.Now())
+	if dbIsForeignKeyErr(err, "word_tags_word_fkey") {
+		return errors.WordNotFound
+	}
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed tagging word")
+	}
+	return nil
+}
+
+// WordUntag removes a tag from a word, if it has it.
+func (dbal DBAL) WordUntag(ctx context.Context, wordID, tag string) (err error) {
+	defer observeQuery("WordUntag", time.Now(), &err)
+
+	if err := validators.UUID(wordID); err != nil {
+		return errors.WordNotFound
+	}
+
+	stmt := `WITH deleted AS (DELETE FROM word_tags WHERE word_id=$1 AND tag=$2)
+		SELECT EXISTS (SELECT 1 FROM words WHERE word_id=$1);`
+
+	var exists bool
+	if err := dbal.QueryRowContext(ctx, stmt, wordID, tag).Scan(&exists); err != nil {
+		return errors.UnexpectedError(err, "Failed untagging word")
+	}
+	if !exists {
+		return errors.WordNotFound
+	}
+	return nil
+}
+
+// dbTagFilter adds to q the conditions selecting the rows of words that
+// match f.
+func dbTagFilter(f TagFilter, q *dbQuery) {
+	if len(f.IncludeTags) > 0 {
+		q.where(`EXISTS (SELECT 1 FROM word_tags t WHERE t.word_id=words.word_id AND ` +
+			q.in("t.tag", f.IncludeTags) + `)`)
+	}
+	if len(f.ExcludeTags) > 0 {
+		q.where(`NOT EXISTS (SELECT 1 FROM word_tags t WHERE t.word_id=words.word_id AND ` +
+			q.in("t.tag", f.ExcludeTags) + `)`)
+	}
+}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
+)
+
+// -----------------------------------------------------------------------------
+// TagFilter.Match
+// -----------------------------------------------------------------------------
+func TestTagFilter_Match(t *testing.T) {
+	t.Parallel()
+	for _, c := range []struct {
+		filter TagFilter
+		tags   []string
+		match  bool
+	}{
+		{TagFilter{}, nil, true},
+		{TagFilter{}, []string{"space"}, true},
+		{TagFilter{IncludeTags: []string{"space"}}, nil, false},
+		{TagFilter{IncludeTags: []string{"fantasy", "space"}}, []string{"space"}, true},
+		{TagFilter{ExcludeTags: []string{"rude"}}, []string{"rude", "space"}, false},
+		{TagFilter{IncludeTags: []string{"space"}, ExcludeTags: []string{"rude"}}, []string{"space"}, true},
+	} {
+		if c.filter.Match(c.tags) != c.match {
+			t.Fatal(c)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Store tags and themes
+// -----------------------------------------------------------------------------
+func testStoreTags(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	dragon, err := store.WordCreate(ctx, "dragon", "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hotel, err := store.WordCreate(ctx, "hotel", "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dragon.Tags) != 0 {
+		t.Fatal(dragon.Tags)
+	}
+
+	for _, tag := range []string{"fantasy", "animals", "fantasy"} {
+		if err := store.WordTag(ctx, dragon.WordID, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.WordTag(ctx, hotel.WordID, "corporate-safe"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordTag(ctx, hotel.WordID, "Not a tag"); !errors.TagInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+	if err := store.WordTag(ctx, crypto.NewUUID(), "fantasy"); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+
+	got, err := store.WordGet(ctx, dragon.WordID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got.Tags, ",") != "animals,fantasy" {
+		t.Fatal(got.Tags)
+	}
+
+	words, _, err := store.WordList(ctx, WordListArgs{Filter: WordFilter{Tag: "fantasy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 1 || words[0].WordID != dragon.WordID {
+		t.Fatal(words)
+	}
+
+	for i := 0; i < 10; i++ {
+		word, err := store.WordRandom(ctx, "en", "noun", TagFilter{ExcludeTags: []string{"animals"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if word.WordID != hotel.WordID {
+			t.Fatal(word)
+		}
+	}
+	word, err := store.WordRandom(ctx, "en", "noun", TagFilter{IncludeTags: []string{"space", "fantasy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if word.WordID != dragon.WordID {
+		t.Fatal(word)
+	}
+
+	if err := store.WordUntag(ctx, dragon.WordID, "fantasy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordUntag(ctx, dragon.WordID, "fantasy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WordUntag(ctx, crypto.NewUUID(), "fantasy"); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+	if _, err := store.WordRandom(ctx, "en", "noun", TagFilter{IncludeTags: []string{"fantasy"}}); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+
+	words, _, err = store.LexiconLoad(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range words {
+		if w.WordID == dragon.WordID && strings.Join(w.Tags, ",") != "animals" {
+			t.Fatal(w.Tags)
+		}
+	}
+}
+
+func testStoreTheme(t *testing.T, store Store) {
+	ctx := context.Background()
+	newTestLanguages(t, store, "en")
+
+	pattern, err := store.PatternCreate(ctx, "adjective,noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pattern.Theme != "" {
+		t.Fatal(pattern.Theme)
+	}
+
+	if err := store.PatternSetTheme(ctx, pattern.PatternID, "fantasy"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.PatternGet(ctx, pattern.PatternID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Theme != "fantasy" {
+		t.Fatal(got.Theme)
+	}
+	_, patterns, err := store.LexiconLoad(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].Theme != "fantasy" {
+		t.Fatal(patterns)
+	}
+
+	if err := store.PatternSetTheme(ctx, pattern.PatternID, "Fantasy!"); !errors.TagInvalid.Equals(err) {
+		t.Fatal(err)
+	}
+	if err := store.PatternSetTheme(ctx, crypto.NewUUID(), "fantasy"); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+
+	if err := store.PatternSetTheme(ctx, pattern.PatternID, ""); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.PatternGet(ctx, pattern.PatternID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Theme != "" {
+		t.Fatal(got.Theme)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// SQLiteStore upgrades
+// -----------------------------------------------------------------------------
+func TestSQLiteStore_Upgrade(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "alias-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "lexicon.db")
+
+	// A file from before the upgrades.
+	db, err := sql.Open("sqlite3", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	for i := 0; i < 2; i++ {
+		store, err := OpenSQLite(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var version int
+		if err := store.db.QueryRow(`PRAGMA user_version;`).Scan(&version); err != nil {
+			t.Fatal(err)
+		}
+		if version != len(sqliteUpgrades) {
+			t.Fatal(version)
+		}
+		if _, err := store.db.Exec(`SELECT theme FROM patterns;`); err != nil {
+			t.Fatal(err)
+		}
+		store.Close()
+	}
+}
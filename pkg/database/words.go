@@ -1,10 +1,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/timaraxian/alias-gen/pkg/errors"
 	"github.com/timaraxian/alias-gen/pkg/helpers/crypto"
 	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
@@ -13,23 +14,33 @@ import (
 type Word struct {
 	WordID     string     `json:"wordID"`
 	Word       string     `json:"word"`
-	Language   string     `json:language`
-	Part       string     `json:part`
+	Language   string     `json:"language"`
+	Part       string     `json:"part"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	UpdatedAt  time.Time  `json:"updatedAt"`
 	ArchivedAt *time.Time `json:"archivedAt"`
+
+	// Tags are sorted.
+	Tags []string `json:"tags"`
 }
 
-func (dbal *DBAL) WordCreate(word_in, language, part string) (word Word, err error) {
+func (dbal *DBAL) WordCreate(ctx context.Context, word_in, language, part string) (word Word, err error) {
+	defer observeQuery("WordCreate", time.Now(), &err)
+
 	word.WordID = crypto.NewUUID()
 
-	// todo: validate word, language, part
+	// todo: validate word
 	word.Word = word_in
 	word.Language = language
 	word.Part = part
 
 	word.CreatedAt = time.Now()
 	word.UpdatedAt = word.CreatedAt
+	word.Tags = []string{}
+
+	if err := dbCheckLanguageActive(ctx, dbal, language); err != nil {
+		return word, err
+	}
 
 	stmt := `INSERT INTO words (
 		word_id,
@@ -41,7 +52,7 @@ func (dbal *DBAL) WordCreate(word_in, language, part string) (word Word, err err
 		archived_at
 	) VALUES ($1, $2, $3, $4, $5, $6, NULL);`
 
-	_, err = dbal.Exec(stmt,
+	_, err = dbal.ExecContext(ctx, stmt,
 		word.WordID,
 		word.Word,
 		word.Language,
@@ -57,11 +68,19 @@ func (dbal *DBAL) WordCreate(word_in, language, part string) (word Word, err err
 	if dbIsDuplicateErr(err, "words_language_part_word") {
 		return word, errors.WordDuplicate
 	}
+	if dbIsForeignKeyErr(err, "words_language_fkey") {
+		return word, errors.LanguageNotFound
+	}
+	if dbIsForeignKeyErr(err, "words_part_fkey") {
+		return word, errors.PartNotFound
+	}
 
 	return word, errors.UnexpectedError(err, "Failed creating word")
 }
 
-func (dbal *DBAL) WordGet(wordID string) (word Word, err error) {
+func (dbal *DBAL) WordGet(ctx context.Context, wordID string) (word Word, err error) {
+	defer observeQuery("WordGet", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return word, errors.WordNotFound
 	}
@@ -73,9 +92,10 @@ func (dbal *DBAL) WordGet(wordID string) (word Word, err error) {
                 part,
                 created_at,
                 updated_at,
-                archived_at FROM words WHERE word_id=$1;`
+                archived_at,
+                ` + dbWordTagsColumn + ` FROM words WHERE word_id=$1;`
 
-	err = dbal.QueryRow(stmt, wordID).Scan(
+	err = dbal.QueryRowContext(ctx, stmt, wordID).Scan(
 		&word.WordID,
 		&word.Word,
 		&word.Language,
@@ -83,6 +103,7 @@ func (dbal *DBAL) WordGet(wordID string) (word Word, err error) {
 		&word.CreatedAt,
 		&word.UpdatedAt,
 		&word.ArchivedAt,
+		pq.Array(&word.Tags),
 	)
 
 	if err == nil {
@@ -96,7 +117,9 @@ func (dbal *DBAL) WordGet(wordID string) (word Word, err error) {
 	return word, errors.UnexpectedError(err, "Failed getting word")
 }
 
-func (dbal DBAL) WordSetWord(wordID, word string) (err error) {
+func (dbal DBAL) WordSetWord(ctx context.Context, wordID, word string) (err error) {
+	defer observeQuery("WordSetWord", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return errors.WordNotFound
 	}
@@ -105,7 +128,7 @@ func (dbal DBAL) WordSetWord(wordID, word string) (err error) {
 
 	stmt := `UPDATE words SET word=$1, updated_at=$2 WHERE word_id=$3 AND archived_at IS NULL;`
 
-	_, n, err := dbal.ExecOne(stmt, word, time.Now(), wordID)
+	_, n, err := dbal.ExecOne(ctx, stmt, word, time.Now(), wordID)
 	if dbIsDuplicateErr(err, "words_language_part_word") {
 		return errors.WordDuplicate
 	}
@@ -118,18 +141,25 @@ func (dbal DBAL) WordSetWord(wordID, word string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) WordSetLanguage(wordID, language string) (err error) {
+func (dbal DBAL) WordSetLanguage(ctx context.Context, wordID, language string) (err error) {
+	defer observeQuery("WordSetLanguage", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return errors.WordNotFound
 	}
 
-	// todo: validate language
 	stmt := `UPDATE words SET language=$1, updated_at=$2 WHERE word_id=$3 AND archived_at IS NULL;`
 
-	_, n, err := dbal.ExecOne(stmt, language, time.Now(), wordID)
+	_, n, err := dbal.ExecOne(ctx, stmt, language, time.Now(), wordID)
 	if dbIsDuplicateErr(err, "words_language_part_word") {
 		return errors.WordDuplicate
 	}
+	if dbIsForeignKeyErr(err, "words_language_fkey") {
+		return errors.LanguageNotFound
+	}
+	if dbIsForeignKeyErr(err, "words_part_fkey") {
+		return errors.PartNotFound
+	}
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to set language")
 	} else if n == 0 {
@@ -139,18 +169,22 @@ func (dbal DBAL) WordSetLanguage(wordID, language string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) WordSetPart(wordID, part string) (err error) {
+func (dbal DBAL) WordSetPart(ctx context.Context, wordID, part string) (err error) {
+	defer observeQuery("WordSetPart", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return errors.WordNotFound
 	}
 
-	// todo: validate part
 	stmt := `UPDATE words SET part=$1, updated_at=$2 WHERE word_id=$3 AND archived_at IS NULL;`
 
-	_, n, err := dbal.ExecOne(stmt, part, time.Now(), wordID)
+	_, n, err := dbal.ExecOne(ctx, stmt, part, time.Now(), wordID)
 	if dbIsDuplicateErr(err, "words_language_part_word") {
 		return errors.WordDuplicate
 	}
+	if dbIsForeignKeyErr(err, "words_part_fkey") {
+		return errors.PartNotFound
+	}
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to set part")
 	} else if n == 0 {
@@ -160,14 +194,48 @@ func (dbal DBAL) WordSetPart(wordID, part string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) WordSetArchive(wordID string) (err error) {
+// WordUpdate sets the word, language and part of the unarchived word with
+// word.WordID in one statement, so a word can move to a part that only
+// exists in its new language.
+func (dbal DBAL) WordUpdate(ctx context.Context, word Word) (err error) {
+	defer observeQuery("WordUpdate", time.Now(), &err)
+
+	if err := validators.UUID(word.WordID); err != nil {
+		return errors.WordNotFound
+	}
+
+	stmt := `UPDATE words SET word=$1, language=$2, part=$3, updated_at=$4
+		WHERE word_id=$5 AND archived_at IS NULL;`
+
+	_, n, err := dbal.ExecOne(ctx, stmt, word.Word, word.Language, word.Part, time.Now(), word.WordID)
+	if dbIsDuplicateErr(err, "words_language_part_word") {
+		return errors.WordDuplicate
+	}
+	if dbIsForeignKeyErr(err, "words_language_fkey") {
+		return errors.LanguageNotFound
+	}
+	if dbIsForeignKeyErr(err, "words_part_fkey") {
+		return errors.PartNotFound
+	}
+	if err != nil {
+		return errors.UnexpectedError(err, "Failed to update word")
+	} else if n == 0 {
+		return errors.WordNotFound
+	}
+
+	return nil
+}
+
+func (dbal DBAL) WordSetArchive(ctx context.Context, wordID string) (err error) {
+	defer observeQuery("WordSetArchive", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return errors.WordNotFound
 	}
 
 	stmt := `UPDATE words SET archived_at=COALESCE(archived_at, NOW()) WHERE word_id=$1;`
 
-	_, n, err := dbal.ExecOne(stmt, wordID)
+	_, n, err := dbal.ExecOne(ctx, stmt, wordID)
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to archive word")
 	} else if n == 0 {
@@ -177,14 +245,16 @@ func (dbal DBAL) WordSetArchive(wordID string) (err error) {
 	return nil
 }
 
-func (dbal DBAL) WordSetUnArchive(wordID string) (err error) {
+func (dbal DBAL) WordSetUnArchive(ctx context.Context, wordID string) (err error) {
+	defer observeQuery("WordSetUnArchive", time.Now(), &err)
+
 	if err := validators.UUID(wordID); err != nil {
 		return errors.WordNotFound
 	}
 
 	stmt := `UPDATE words SET archived_at=NULL WHERE word_id=$1;`
 
-	_, n, err := dbal.ExecOne(stmt, wordID)
+	_, n, err := dbal.ExecOne(ctx, stmt, wordID)
 	if err != nil {
 		return errors.UnexpectedError(err, "Failed to archive word")
 	} else if n == 0 {
@@ -194,113 +264,146 @@ func (dbal DBAL) WordSetUnArchive(wordID string) (err error) {
 	return nil
 }
 
-type WordListArgs struct {
-	Limit            *int
-	Offset           *int
-	OrderByWord      *bool
-	DescWord         *bool
-	OrderByLanguage  *bool
-	DescLanguage     *bool
-	OrderByPart      *bool
-	DescPart         *bool
-	OrderByUpdatedAt *bool
-	DescUpdatedAt    *bool
-	OrderByCreatedAt *bool
-	DescCreatedAt    *bool
-	ShowArchived     *bool
-}
+// WordFilter narrows a word list. Empty fields don't filter.
+type WordFilter struct {
+	Language     string `json:"language"`
+	Part         string `json:"part"`
+	WordPrefix   string `json:"wordPrefix"`
+	WordContains string `json:"wordContains"`
 
-func (dbal DBAL) WordList(listArgs WordListArgs) (words []Word, err error) {
-	// ------ build statement
-	stmt := `SELECT 
-		word_id,
-		word,
-		language,
-		part,
-		created_at,
-		updated_at,
-		archived_at FROM words %s %s %s %s;`
+	// Tag lists the words with the tag.
+	Tag string `json:"tag"`
 
-	// %s(1) show archived or not
-	showArchived := ""
-	if listArgs.ShowArchived != nil && !*listArgs.ShowArchived {
-		showArchived = "WHERE archived_at IS NULL"
-	}
+	// Created and updated times are from After, inclusive, to Before.
+	CreatedAfter  *time.Time `json:"createdAfter"`
+	CreatedBefore *time.Time `json:"createdBefore"`
+	UpdatedAfter  *time.Time `json:"updatedAfter"`
+	UpdatedBefore *time.Time `json:"updatedBefore"`
 
-	// %s(2) orderbys
-	orderBy := "ORDER BY "
-	if listArgs.OrderByWord != nil && *listArgs.OrderByWord {
-		orderBy += "word"
+	// Archived is exclude (the default), include or only.
+	Archived string `json:"archived"`
+}
 
-		if listArgs.DescWord != nil && *listArgs.DescWord {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
-	}
-	if listArgs.OrderByLanguage != nil && *listArgs.OrderByLanguage {
-		orderBy += "language"
+// ParseWordFilter reads a filter written as comma separated key=value pairs,
+// keyed by the JSON field names, e.g. "language=en,wordPrefix=gr".
+func ParseWordFilter(spec string) (f WordFilter, err error) {
+	err = parseFilterSpec(spec, map[string]func(string) error{
+		"language":      setString(&f.Language),
+		"part":          setString(&f.Part),
+		"wordPrefix":    setString(&f.WordPrefix),
+		"wordContains":  setString(&f.WordContains),
+		"tag":           setString(&f.Tag),
+		"createdAfter":  setTime(&f.CreatedAfter),
+		"createdBefore": setTime(&f.CreatedBefore),
+		"updatedAfter":  setTime(&f.UpdatedAfter),
+		"updatedBefore": setTime(&f.UpdatedBefore),
+		"archived":      setString(&f.Archived),
+	})
+	return f, err
+}
 
-		if listArgs.DescLanguage != nil && *listArgs.DescLanguage {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
+func (f WordFilter) apply(q *dbQuery) error {
+	if f.Language != "" {
+		q.where("language = " + q.arg(f.Language))
 	}
-	if listArgs.OrderByPart != nil && *listArgs.OrderByPart {
-		orderBy += "part"
-
-		if listArgs.DescPart != nil && *listArgs.DescPart {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
+	if f.Part != "" {
+		q.where("part = " + q.arg(f.Part))
 	}
-	if listArgs.OrderByUpdatedAt != nil && *listArgs.OrderByUpdatedAt {
-		orderBy += "updated_at"
-
-		if listArgs.DescUpdatedAt != nil && *listArgs.DescUpdatedAt {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
+	if f.WordPrefix != "" {
+		q.where(q.ilike("word", dbLikeEscape(f.WordPrefix)+"%"))
 	}
-	if listArgs.OrderByCreatedAt != nil && *listArgs.OrderByCreatedAt {
-		orderBy += "created_at"
-
-		if listArgs.DescCreatedAt != nil && *listArgs.DescCreatedAt {
-			orderBy += " desc, "
-		} else {
-			orderBy += ", "
-		}
+	if f.WordContains != "" {
+		q.where(q.ilike("word", "%"+dbLikeEscape(f.WordContains)+"%"))
 	}
-	if orderBy == "ORDER BY " {
-		orderBy = ""
-	} else {
-		orderBy = orderBy[:len(orderBy)-2]
+	if f.Tag != "" {
+		dbTagFilter(TagFilter{IncludeTags: []string{f.Tag}}, q)
 	}
+	return timeFilter{
+		createdAfter:  f.CreatedAfter,
+		createdBefore: f.CreatedBefore,
+		updatedAfter:  f.UpdatedAfter,
+		updatedBefore: f.UpdatedBefore,
+		archived:      f.Archived,
+	}.apply(q)
+}
+
+// WordSortKeys maps the keys words can be ordered by to their columns.
+var WordSortKeys = map[string]string{
+	"word":      "word",
+	"language":  "language",
+	"part":      "part",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+type WordListArgs struct {
+	Filter WordFilter
+
+	// OrderBy keys come from WordSortKeys, most significant first. Ties are
+	// broken by word_id.
+	OrderBy []OrderBy
+
+	// Limit defaults to DefaultListLimit and is capped at MaxListLimit.
+	Limit int
+
+	// Cursor is the NextCursor or PrevCursor of a page listed with the same
+	// ordering.
+	Cursor string
+}
 
-	// %s(3) limit
-	limit := ""
-	if listArgs.Limit != nil && *listArgs.Limit > 0 {
-		limit = fmt.Sprintf("LIMIT %d", *listArgs.Limit)
-	} else {
-		limit = "LIMIT 50"
+func (word Word) sortValue(key string) string {
+	switch key {
+	case "word":
+		return word.Word
+	case "language":
+		return word.Language
+	case "part":
+		return word.Part
+	case "updatedAt":
+		return cursorTime(word.UpdatedAt)
+	case "createdAt":
+		return cursorTime(word.CreatedAt)
+	}
+	panic("unknown word sort key: " + key)
+}
+
+// WordList returns a page of the words matching the filter, and the cursors
+// of the pages either side.
+func (dbal DBAL) WordList(ctx context.Context, listArgs WordListArgs) (words []Word, page Page, err error) {
+	defer observeQuery("WordList", time.Now(), &err)
+
+	if err := checkOrderBy(listArgs.OrderBy, WordSortKeys); err != nil {
+		return words, page, err
 	}
+	cursor, err := decodeCursor(listArgs.Cursor, listArgs.OrderBy)
+	if err != nil {
+		return words, page, err
+	}
+	limit := listLimit(listArgs.Limit)
 
-	// %s(4) offset
-	offset := ""
-	if listArgs.Offset != nil && *listArgs.Offset > 0 {
-		offset = fmt.Sprintf("OFFSET %d", *listArgs.Offset)
+	// ------ build statement
+	q := &dbQuery{}
+	if err := listArgs.Filter.apply(q); err != nil {
+		return words, page, err
 	}
+	orderBy := keyset(listArgs.OrderBy, WordSortKeys, "word_id", cursor, q)
 
-	stmt = fmt.Sprintf(stmt, showArchived, orderBy, limit, offset)
+	// One extra row tells whether there's another page.
+	stmt := `SELECT
+		word_id,
+		word,
+		language,
+		part,
+		created_at,
+		updated_at,
+		archived_at,
+		` + dbWordTagsColumn + ` FROM words ` + q.whereClause() + ` ` + orderBy + ` LIMIT ` + q.arg(limit+1) + `;`
 
 	// ------- statement built
 
-	rows, err := dbal.Query(stmt)
+	rows, err := dbal.QueryContext(ctx, stmt, q.args...)
 	if err != nil {
-		return words, errors.UnexpectedError(err, "Failed listing words")
+		return words, page, errors.UnexpectedError(err, "Failed listing words")
 	}
 	defer rows.Close()
 
@@ -314,23 +417,51 @@ func (dbal DBAL) WordList(listArgs WordListArgs) (words []Word, err error) {
 			&word.CreatedAt,
 			&word.UpdatedAt,
 			&word.ArchivedAt,
+			pq.Array(&word.Tags),
 		); err != nil {
-			return words, errors.UnexpectedError(err, "Failed scanning words")
+			return words, page, errors.UnexpectedError(err, "Failed scanning words")
 		}
 
 		words = append(words, word)
 	}
 
 	if err := rows.Err(); err != nil {
-		return words, errors.UnexpectedError(err, "Failed iterating word rows")
+		return words, page, errors.UnexpectedError(err, "Failed iterating word rows")
+	}
+
+	more := len(words) > limit
+	if more {
+		words = words[:limit]
+	}
+	// Pages before the cursor are read backwards.
+	if cursor != nil && cursor.Before {
+		for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+			words[i], words[j] = words[j], words[i]
+		}
+	}
+
+	var first, last *listCursor
+	if len(words) > 0 {
+		f := newListCursor(listArgs.OrderBy, words[0].sortValue, words[0].WordID, true)
+		l := newListCursor(listArgs.OrderBy, words[len(words)-1].sortValue, words[len(words)-1].WordID, false)
+		first, last = &f, &l
 	}
 
-	return words, err
+	return words, pageCursors(cursor, more, first, last), nil
 }
 
-func (dbal DBAL) WordRandom(language, part string) (word Word, err error) {
+// WordRandom returns a random unarchived word of a part that matches tags.
+func (dbal DBAL) WordRandom(ctx context.Context, language, part string, tags TagFilter) (word Word, err error) {
+	defer observeQuery("WordRandom", time.Now(), &err)
+
 	// todo: validate language, part
 
+	q := &dbQuery{}
+	q.where("language = " + q.arg(language))
+	q.where("part = " + q.arg(part))
+	q.where("archived_at IS NULL")
+	dbTagFilter(tags, q)
+
 	stmt := `SELECT
                 word_id,
                 word,
@@ -338,9 +469,10 @@ func (dbal DBAL) WordRandom(language, part string) (word Word, err error) {
                 part,
                 created_at,
                 updated_at,
-                archived_at FROM words WHERE language=$1 AND part=$2 AND archived_at IS NULL ORDER BY RANDOM() LIMIT 1;`
+                archived_at,
+                ` + dbWordTagsColumn + ` FROM words ` + q.whereClause() + ` ORDER BY RANDOM() LIMIT 1;`
 
-	err = dbal.QueryRow(stmt, language, part).Scan(
+	err = dbal.QueryRowContext(ctx, stmt, q.args...).Scan(
 		&word.WordID,
 		&word.Word,
 		&word.Language,
@@ -348,6 +480,7 @@ func (dbal DBAL) WordRandom(language, part string) (word Word, err error) {
 		&word.CreatedAt,
 		&word.UpdatedAt,
 		&word.ArchivedAt,
+		pq.Array(&word.Tags),
 	)
 
 	if err == nil {
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,11 +17,13 @@ import (
 // -----------------------------------------------------------------------------
 func TestDBAL_WordCreate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
 	before := time.Now().Round(time.Microsecond)
-	word, err := dbal.WordCreate("Grand", "en", "adjective")
+	word, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -48,20 +53,47 @@ func TestDBAL_WordCreate(t *testing.T) {
 	}
 }
 
+func TestDBAL_WordCreate_LanguageNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+
+	_, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_WordCreate_PartNotFound(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	_, err := dbal.WordCreate(ctx, "Grand", "en", "adj")
+	if err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // DBAL.WordGet
 // -----------------------------------------------------------------------------
 func TestDBAL_WordGet(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,20 +108,22 @@ func TestDBAL_WordGet(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordSetWord(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetWord(word_in.WordID, "Big")
+	err = dbal.WordSetWord(ctx, word_in.WordID, "Big")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -105,19 +139,21 @@ func TestDBAL_WordSetWord(t *testing.T) {
 
 func TestDBAL_WordSetWord_Duplicate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.WordCreate("Pink", "en", "adjective")
+	_, err = dbal.WordCreate(ctx, "Pink", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetWord(word_in.WordID, "Pink")
+	err = dbal.WordSetWord(ctx, word_in.WordID, "Pink")
 	if err != errors.WordDuplicate {
 		t.Fatal(err)
 	}
@@ -125,10 +161,11 @@ func TestDBAL_WordSetWord_Duplicate(t *testing.T) {
 
 func TestDBAL_WordSetWord_WordNotFound_validUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetWord(crypto.NewUUID(), "Grand")
+	err := dbal.WordSetWord(ctx, crypto.NewUUID(), "Grand")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -136,10 +173,11 @@ func TestDBAL_WordSetWord_WordNotFound_validUUID(t *testing.T) {
 
 func TestDBAL_WordSetWord_WordNotFound_invalidUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetWord("invalidUUID", "Grand")
+	err := dbal.WordSetWord(ctx, "invalidUUID", "Grand")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -150,20 +188,22 @@ func TestDBAL_WordSetWord_WordNotFound_invalidUUID(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordSetLanguage(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetLanguage(word_in.WordID, "fr")
+	err = dbal.WordSetLanguage(ctx, word_in.WordID, "fr")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -178,19 +218,21 @@ func TestDBAL_WordSetLanguage(t *testing.T) {
 
 func TestDBAL_WordSetLanguage_Duplicate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.WordCreate("Grand", "fr", "adjective")
+	_, err = dbal.WordCreate(ctx, "Grand", "fr", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetLanguage(word_in.WordID, "fr")
+	err = dbal.WordSetLanguage(ctx, word_in.WordID, "fr")
 	if err != errors.WordDuplicate {
 		t.Fatal(err)
 	}
@@ -198,10 +240,11 @@ func TestDBAL_WordSetLanguage_Duplicate(t *testing.T) {
 
 func TestDBAL_WordSetLanguage_WordNotFound_validUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetLanguage(crypto.NewUUID(), "fr")
+	err := dbal.WordSetLanguage(ctx, crypto.NewUUID(), "fr")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -209,10 +252,11 @@ func TestDBAL_WordSetLanguage_WordNotFound_validUUID(t *testing.T) {
 
 func TestDBAL_WordSetLanguage_WordNotFound_invalidUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetLanguage("invalidUUID", "fr")
+	err := dbal.WordSetLanguage(ctx, "invalidUUID", "fr")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -223,20 +267,22 @@ func TestDBAL_WordSetLanguage_WordNotFound_invalidUUID(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordSetPart(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetPart(word_in.WordID, "article")
+	err = dbal.WordSetPart(ctx, word_in.WordID, "article")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -251,19 +297,21 @@ func TestDBAL_WordSetPart(t *testing.T) {
 
 func TestDBAL_WordSetPart_Duplicate(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = dbal.WordCreate("Grand", "en", "article")
+	_, err = dbal.WordCreate(ctx, "Grand", "en", "article")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetPart(word_in.WordID, "article")
+	err = dbal.WordSetPart(ctx, word_in.WordID, "article")
 	if err != errors.WordDuplicate {
 		t.Fatal(err)
 	}
@@ -271,10 +319,11 @@ func TestDBAL_WordSetPart_Duplicate(t *testing.T) {
 
 func TestDBAL_WordSetPart_WordNotFound_validUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetPart(crypto.NewUUID(), "article")
+	err := dbal.WordSetPart(ctx, crypto.NewUUID(), "article")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -282,10 +331,11 @@ func TestDBAL_WordSetPart_WordNotFound_validUUID(t *testing.T) {
 
 func TestDBAL_WordSetPart_WordNotFound_invalidUUID(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	err := dbal.WordSetPart("invalidUUID", "article")
+	err := dbal.WordSetPart(ctx, "invalidUUID", "article")
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -296,17 +346,19 @@ func TestDBAL_WordSetPart_WordNotFound_invalidUUID(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordSetArchive(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetArchive(word_in.WordID)
+	err = dbal.WordSetArchive(ctx, word_in.WordID)
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -321,17 +373,19 @@ func TestDBAL_WordSetArchive(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordSetUnarchive(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	word_in, err := dbal.WordCreate("Grand", "en", "adjective")
+	word_in, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = dbal.WordSetUnArchive(word_in.WordID)
+	err = dbal.WordSetUnArchive(ctx, word_in.WordID)
 
-	word_out, err := dbal.WordGet(word_in.WordID)
+	word_out, err := dbal.WordGet(ctx, word_in.WordID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -346,34 +400,34 @@ func TestDBAL_WordSetUnarchive(t *testing.T) {
 // -----------------------------------------------------------------------------
 func TestDBAL_WordList(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	w1, err := dbal.WordCreate("Grand", "en", "adjective")
+	w1, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	w2, err := dbal.WordCreate("Hotel", "en", "noun")
+	w2, err := dbal.WordCreate(ctx, "Hotel", "en", "noun")
 	if err != nil {
 		t.Fatal(err)
 	}
-	w3, err := dbal.WordCreate("The", "en", "article")
+	w3, err := dbal.WordCreate(ctx, "The", "en", "article")
 	if err != nil {
 		t.Fatal(err)
 	}
-	w4, err := dbal.WordCreate("Budapest", "en", "place")
+	w4, err := dbal.WordCreate(ctx, "Budapest", "en", "place")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	limit := 10
-	trueVar := true
 	listargs := WordListArgs{
-		Limit:       &limit,
-		OrderByWord: &trueVar,
+		Limit:   10,
+		OrderBy: []OrderBy{{Key: "word"}},
 	}
 
-	results, err := dbal.WordList(listargs)
+	results, _, err := dbal.WordList(ctx, listargs)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -392,30 +446,161 @@ func TestDBAL_WordList(t *testing.T) {
 	}
 }
 
+func TestDBAL_WordList_Cursor(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en")
+
+	// Ties on part are broken by word_id.
+	var ids []string
+	for _, w := range []string{"Grand", "Pink", "Tall", "Blue", "Odd"} {
+		word, err := dbal.WordCreate(ctx, w, "en", "adjective")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, word.WordID)
+	}
+	sort.Strings(ids)
+
+	listargs := WordListArgs{
+		Limit:   2,
+		OrderBy: []OrderBy{{Key: "part"}},
+	}
+
+	var seen []string
+	var page Page
+	for i := 0; i < 3; i++ {
+		results, p, err := dbal.WordList(ctx, listargs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range results {
+			seen = append(seen, w.WordID)
+		}
+		page = p
+		listargs.Cursor = p.NextCursor
+	}
+	if strings.Join(seen, ",") != strings.Join(ids, ",") {
+		t.Fatal(seen, ids)
+	}
+	if page.NextCursor != "" || page.PrevCursor == "" {
+		t.Fatal(page)
+	}
+
+	// Back from the last page.
+	listargs.Cursor = page.PrevCursor
+	results, page, err := dbal.WordList(ctx, listargs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].WordID != ids[2] || results[1].WordID != ids[3] {
+		t.Fatal(results)
+	}
+	if page.NextCursor == "" || page.PrevCursor == "" {
+		t.Fatal(page)
+	}
+
+	// A cursor from another ordering is rejected.
+	listargs.OrderBy = []OrderBy{{Key: "word"}}
+	if _, _, err := dbal.WordList(ctx, listargs); err != errors.InvalidCursor {
+		t.Fatal(err)
+	}
+}
+
+func TestDBAL_WordList_Filter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
+	defer close()
+	newTestLanguages(t, dbal, "en", "fr")
+
+	w1, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := dbal.WordCreate(ctx, "Grey", "en", "adjective")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.WordCreate(ctx, "Grand", "fr", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.WordCreate(ctx, "Agree", "en", "verb"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbal.WordCreate(ctx, "Green", "en", "adjective"); err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := ParseWordFilter("language=en,part=adjective,wordPrefix=gr,archived=exclude")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter.WordContains = "a"
+	results, _, err := dbal.WordList(ctx, WordListArgs{Filter: filter, OrderBy: []OrderBy{{Key: "word", Desc: true}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].WordID != w1.WordID {
+		t.Fatal(results)
+	}
+
+	// LIKE wildcards in filters match literally.
+	results, _, err = dbal.WordList(ctx, WordListArgs{Filter: WordFilter{WordPrefix: "%"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatal(results)
+	}
+
+	if err := dbal.WordSetArchive(ctx, w2.WordID); err != nil {
+		t.Fatal(err)
+	}
+	results, _, err = dbal.WordList(ctx, WordListArgs{Filter: WordFilter{Archived: ArchivedOnly}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].WordID != w2.WordID {
+		t.Fatal(results)
+	}
+
+	if _, _, err := dbal.WordList(ctx, WordListArgs{OrderBy: []OrderBy{{Key: "word_id"}}}); err != errors.InvalidOrderBy {
+		t.Fatal(err)
+	}
+	if _, _, err := dbal.WordList(ctx, WordListArgs{Filter: WordFilter{Archived: "some"}}); err != errors.InvalidFilter {
+		t.Fatal(err)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // DBAL.WordRandom
 // -----------------------------------------------------------------------------
 func TestDBAL_WordRandom(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
+	newTestLanguages(t, dbal, "en")
 
-	w1, err := dbal.WordCreate("Grand", "en", "adjective")
+	w1, err := dbal.WordCreate(ctx, "Grand", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	w2, err := dbal.WordCreate("Pink", "en", "adjective")
+	w2, err := dbal.WordCreate(ctx, "Pink", "en", "adjective")
 	if err != nil {
 		t.Fatal(err)
 	}
-	w3, err := dbal.WordCreate("Budapest", "en", "place")
+	w3, err := dbal.WordCreate(ctx, "Budapest", "en", "place")
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	outIDs := map[string]int{}
 	for i := 0; i < 100; i++ {
-		w_out, err := dbal.WordRandom("en", "adjective")
+		w_out, err := dbal.WordRandom(ctx, "en", "adjective", TagFilter{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -439,10 +624,11 @@ func TestDBAL_WordRandom(t *testing.T) {
 
 func TestDBAL_WordRandom_WordNotFound(t *testing.T) {
 	t.Parallel()
-	dbal, close := NewTestDBAL()
+	ctx := context.Background()
+	dbal, close := NewTestDBAL(t)
 	defer close()
 
-	_, err := dbal.WordRandom("en", "adjective")
+	_, err := dbal.WordRandom(ctx, "en", "adjective", TagFilter{})
 	if err != errors.WordNotFound {
 		t.Fatal(err)
 	}
@@ -11,6 +11,8 @@ var (
 	HttpBadRequestArgs = NewErr("HttpBadRequestArgs")
 	HttpBadMethod      = NewErr("HttpBadMethod")
 	HttpBadContentType = NewErr("HttpBadContentType")
+	HttpBodyTooLarge   = NewErr("HttpBodyTooLarge")
+	HttpInvalidArgs    = NewErr("HttpInvalidArgs")
 
 	WordDuplicate = NewErr("DuplicateWord")
 	WordNotFound  = NewErr("WordNotFound")
@@ -18,7 +20,35 @@ var (
 	PatternDuplicate = NewErr("DuplicatePattern")
 	PatternNotFound  = NewErr("PatternNotFound")
 
-	InvalidUUID = NewErr("InvalidUUID")
+	LanguageDuplicate = NewErr("DuplicateLanguage")
+	LanguageNotFound  = NewErr("LanguageNotFound")
+	LanguageInvalid   = NewErr("LanguageInvalid")
+	LanguageArchived  = NewErr("LanguageArchived")
+
+	PartDuplicate = NewErr("DuplicatePart")
+	PartNotFound  = NewErr("PartNotFound")
+	PartInvalid   = NewErr("PartInvalid")
+
+	TagInvalid = NewErr("TagInvalid")
+
+	ApiKeyNotFound     = NewErr("ApiKeyNotFound")
+	ApiKeyInvalidScope = NewErr("ApiKeyInvalidScope")
+
+	AuthRequired  = NewErr("AuthRequired")
+	AuthInvalid   = NewErr("AuthInvalid")
+	AuthForbidden = NewErr("AuthForbidden")
+
+	RateLimited       = NewErr("RateLimited")
+	QuotaExceeded     = NewErr("QuotaExceeded")
+	GenerateFailed    = NewErr("GenerateFailed")
+	GenerateExhausted = NewErr("GenerateExhausted")
+
+	InvalidUUID    = NewErr("InvalidUUID")
+	InvalidCursor  = NewErr("InvalidCursor")
+	InvalidOrderBy = NewErr("InvalidOrderBy")
+	InvalidFilter  = NewErr("InvalidFilter")
+
+	NotSupported = NewErr("NotSupported")
 )
 
 // -----------------------------------------------------------------------------
@@ -70,6 +100,39 @@ func (e Error) Equals(err error) bool {
 	return e.code == e2.Code()
 }
 
+// -----------------------------------------------------------------------------
+type FieldError struct {
+	Field string `json:"field"`
+	Code  string `json:"code"`
+	Msg   string `json:"message"`
+}
+
+type ValidationError struct {
+	err    Error
+	fields []FieldError
+}
+
+func (e Error) WithFields(fields ...FieldError) error {
+	return ValidationError{e, fields}
+}
+
+func (e ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e ValidationError) Code() string {
+	return e.err.Code()
+}
+
+func (e ValidationError) Msg() string {
+	return e.err.Msg()
+}
+
+func (e ValidationError) Fields() []FieldError {
+	return e.fields
+}
+
+// -----------------------------------------------------------------------------
 func UnexpectedError(err error, format string, args ...interface{}) error {
 	//defer alerts.AlertError(err, format, args...)
 	return Unexpected.WithErr(err)
@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Source provides the languages, and the random patterns and words, aliases
+// are built from. Every database.Store implements it.
+type Source interface {
+	LanguageGet(ctx context.Context, code string) (language database.Language, err error)
+	PatternRandom(ctx context.Context, language string) (pattern database.Pattern, err error)
+	WordRandom(ctx context.Context, language, part string, tags database.TagFilter) (word database.Word, err error)
+}
+
+// Options narrow the words aliases are built from.
+type Options struct {
+	// Tags applies to every slot.
+	Tags database.TagFilter
+
+	// Slots applies to the slots at those positions, counting from 0. A
+	// slot's IncludeTags replace those of Tags, and its ExcludeTags add to
+	// them.
+	Slots map[int]database.TagFilter
+
+	// Theme replaces the theme of every pattern drawn.
+	Theme string
+}
+
+// slotTags returns the filter of the slot at position of a pattern. The
+// theme, Theme or else the pattern's, is included when nothing else is.
+func (o Options) slotTags(position int, theme string) database.TagFilter {
+	if o.Theme != "" {
+		theme = o.Theme
+	}
+	tags := o.Tags
+	if slot, ok := o.Slots[position]; ok {
+		if len(slot.IncludeTags) > 0 {
+			tags.IncludeTags = slot.IncludeTags
+		}
+		tags.ExcludeTags = append(append([]string{}, tags.ExcludeTags...), slot.ExcludeTags...)
+	}
+	if len(tags.IncludeTags) == 0 && theme != "" {
+		tags.IncludeTags = []string{theme}
+	}
+	return tags
+}
+
+type Alias struct {
+	Alias     string   `json:"alias"`
+	Words     []string `json:"words"`
+	Language  string   `json:"language"`
+	PatternID string   `json:"patternID"`
+}
+
+const defaultMaxRerolls = 10
+
+type Generator struct {
+	Source Source
+
+	// MaxRerolls is how many times a new pattern is drawn when the current
+	// one has a part without any words.
+	MaxRerolls int
+}
+
+func New(source Source) *Generator {
+	return &Generator{Source: source, MaxRerolls: defaultMaxRerolls}
+}
+
+// Generate builds an alias from a random pattern of language, joining its
+// words with the language's separator. Archived languages return
+// errors.LanguageArchived. Patterns with a slot no word can fill are redrawn,
+// up to MaxRerolls times.
+func (g *Generator) Generate(ctx context.Context, language string, opts Options) (alias Alias, err error) {
+	lang, err := g.Source.LanguageGet(ctx, language)
+	if err != nil {
+		generationFailures.Inc(otherLanguage)
+		return alias, err
+	}
+	if lang.ArchivedAt != nil {
+		generationFailures.Inc(language)
+		return alias, errors.LanguageArchived
+	}
+
+	for i := 0; i <= g.MaxRerolls; i++ {
+		if i > 0 {
+			generationRerolls.Inc(language)
+		}
+
+		pattern, err := g.Source.PatternRandom(ctx, language)
+		if err != nil {
+			generationFailures.Inc(language)
+			return alias, err
+		}
+
+		alias, err = g.fromPattern(ctx, pattern, lang.Separator, opts)
+		if err == nil {
+			aliasesGenerated.Inc(language)
+			return alias, nil
+		}
+		if !errors.WordNotFound.Equals(err) {
+			generationFailures.Inc(language)
+			return alias, err
+		}
+	}
+
+	generationFailures.Inc(language)
+	return alias, errors.GenerateFailed
+}
+
+func (g *Generator) fromPattern(ctx context.Context, pattern database.Pattern, separator string, opts Options) (alias Alias, err error) {
+	alias.Language = pattern.Language
+	alias.PatternID = pattern.PatternID
+
+	for i, part := range strings.Split(pattern.Pattern, ",") {
+		word, err := g.Source.WordRandom(ctx, pattern.Language, part, opts.slotTags(i, pattern.Theme))
+		if err != nil {
+			return alias, err
+		}
+		alias.Words = append(alias.Words, word.Word)
+	}
+
+	alias.Alias = strings.Join(alias.Words, separator)
+	return alias, nil
+}
+
+const defaultMaxMisses = 100
+
+// Unique generates aliases that it hasn't returned before.
+type Unique struct {
+	Generator *Generator
+	Language  string
+	Options   Options
+
+	// MaxMisses is how many duplicates in a row are drawn before the space
+	// of aliases is considered exhausted.
+	MaxMisses int
+
+	seen map[string]bool
+}
+
+func (g *Generator) Unique(language string, opts Options) *Unique {
+	return &Unique{
+		Generator: g,
+		Language:  language,
+		Options:   opts,
+		MaxMisses: defaultMaxMisses,
+		seen:      map[string]bool{},
+	}
+}
+
+// Next returns a new alias, or errors.GenerateExhausted once MaxMisses
+// duplicates are drawn in a row.
+func (u *Unique) Next(ctx context.Context) (alias Alias, err error) {
+	for i := 0; i < u.MaxMisses; i++ {
+		alias, err = u.Generator.Generate(ctx, u.Language, u.Options)
+		if err != nil {
+			return alias, err
+		}
+		if !u.seen[alias.Alias] {
+			u.seen[alias.Alias] = true
+			return alias, nil
+		}
+	}
+	return alias, errors.GenerateExhausted
+}
+
+// Count is how many aliases Next has returned.
+func (u *Unique) Count() int {
+	return len(u.seen)
+}
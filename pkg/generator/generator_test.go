@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/metrics"
+)
+
+type fakeSource struct {
+	// languages defaults to every language, separated by spaces.
+	languages map[string]database.Language
+	patterns  []string
+	words     map[string]string
+	n         int
+}
+
+func (s *fakeSource) LanguageGet(ctx context.Context, code string) (language database.Language, err error) {
+	if s.languages == nil {
+		return database.Language{Code: code, Separator: " "}, nil
+	}
+	language, ok := s.languages[code]
+	if !ok {
+		return language, errors.LanguageNotFound
+	}
+	return language, nil
+}
+
+func (s *fakeSource) PatternRandom(ctx context.Context, language string) (pattern database.Pattern, err error) {
+	if len(s.patterns) == 0 {
+		return pattern, errors.PatternNotFound
+	}
+	pattern.Pattern = s.patterns[s.n%len(s.patterns)]
+	pattern.Language = language
+	s.n++
+	return pattern, nil
+}
+
+func (s *fakeSource) WordRandom(ctx context.Context, language, part string, tags database.TagFilter) (word database.Word, err error) {
+	w, ok := s.words[part]
+	if !ok {
+		return word, errors.WordNotFound
+	}
+	word.Word = w
+	return word, nil
+}
+
+// -----------------------------------------------------------------------------
+// Generator.Generate
+// -----------------------------------------------------------------------------
+func TestGenerator_Generate(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{
+		patterns: []string{"adjective,noun"},
+		words:    map[string]string{"adjective": "Grand", "noun": "Hotel"},
+	})
+
+	alias, err := g.Generate(context.Background(), "en", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Hotel" {
+		t.Fatal(alias)
+	}
+}
+
+func TestGenerator_Generate_Reroll(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{
+		patterns: []string{"place,noun", "adjective,noun"},
+		words:    map[string]string{"adjective": "Grand", "noun": "Hotel"},
+	})
+
+	alias, err := g.Generate(context.Background(), "en", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Hotel" {
+		t.Fatal(alias)
+	}
+}
+
+func TestGenerator_Generate_Failed(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{
+		patterns: []string{"place,noun"},
+		words:    map[string]string{"noun": "Hotel"},
+	})
+
+	_, err := g.Generate(context.Background(), "en", Options{})
+	if err != errors.GenerateFailed {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerator_Generate_Separator(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{
+		languages: map[string]database.Language{"ja": {Code: "ja", Separator: ""}},
+		patterns:  []string{"adjective,noun"},
+		words:     map[string]string{"adjective": "大", "noun": "橋"},
+	})
+
+	alias, err := g.Generate(context.Background(), "ja", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "大橋" || len(alias.Words) != 2 {
+		t.Fatal(alias)
+	}
+}
+
+func TestGenerator_Generate_LanguageArchived(t *testing.T) {
+	t.Parallel()
+	archivedAt := time.Now()
+	g := New(&fakeSource{
+		languages: map[string]database.Language{"en": {Code: "en", Separator: " ", ArchivedAt: &archivedAt}},
+		patterns:  []string{"noun"},
+		words:     map[string]string{"noun": "Hotel"},
+	})
+
+	if _, err := g.Generate(context.Background(), "en", Options{}); err != errors.LanguageArchived {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerator_Generate_PatternNotFound(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{})
+
+	if _, err := g.Generate(context.Background(), "en", Options{}); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerator_Generate_LanguageNotFound(t *testing.T) {
+	t.Parallel()
+	g := New(&fakeSource{languages: map[string]database.Language{}})
+
+	_, err := g.Generate(context.Background(), "zz-language-not-found", Options{})
+	if err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+
+	// Unknown languages don't get series of their own.
+	var b bytes.Buffer
+	if err := metrics.Default.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "zz-language-not-found") ||
+		!strings.Contains(b.String(), `aliasgen_generation_failures_total{language="other"}`) {
+		t.Fatal(b.String())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Unique.Next
+// -----------------------------------------------------------------------------
+func TestUnique_Next(t *testing.T) {
+	t.Parallel()
+	u := New(&fakeSource{
+		patterns: []string{"adjective,noun", "noun", "adjective"},
+		words:    map[string]string{"adjective": "Grand", "noun": "Hotel"},
+	}).Unique("en", Options{})
+	u.MaxMisses = 5
+
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		alias, err := u.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[alias.Alias] {
+			t.Fatal("duplicate", alias.Alias)
+		}
+		seen[alias.Alias] = true
+	}
+
+	if _, err := u.Next(context.Background()); err != errors.GenerateExhausted {
+		t.Fatal(err)
+	}
+	if u.Count() != 3 {
+		t.Fatal(u.Count())
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Options
+// -----------------------------------------------------------------------------
+func TestOptions_slotTags(t *testing.T) {
+	t.Parallel()
+	opts := Options{
+		Tags: database.TagFilter{IncludeTags: []string{"space"}, ExcludeTags: []string{"rude"}},
+		Slots: map[int]database.TagFilter{
+			1: {IncludeTags: []string{"animals"}, ExcludeTags: []string{"cute"}},
+			2: {ExcludeTags: []string{"cute"}},
+		},
+	}
+
+	tags := opts.slotTags(0, "fantasy")
+	if len(tags.IncludeTags) != 1 || tags.IncludeTags[0] != "space" || len(tags.ExcludeTags) != 1 {
+		t.Fatal(tags)
+	}
+
+	tags = opts.slotTags(1, "fantasy")
+	if len(tags.IncludeTags) != 1 || tags.IncludeTags[0] != "animals" || len(tags.ExcludeTags) != 2 {
+		t.Fatal(tags)
+	}
+
+	tags = opts.slotTags(2, "")
+	if len(tags.IncludeTags) != 1 || tags.IncludeTags[0] != "space" || len(tags.ExcludeTags) != 2 {
+		t.Fatal(tags)
+	}
+
+	tags = Options{}.slotTags(0, "fantasy")
+	if len(tags.IncludeTags) != 1 || tags.IncludeTags[0] != "fantasy" || len(tags.ExcludeTags) != 0 {
+		t.Fatal(tags)
+	}
+}
@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Loader provides the languages and the full unarchived lexicon. Every
+// database.Store implements it.
+type Loader interface {
+	LanguageList(ctx context.Context, showArchived bool) (languages []database.Language, err error)
+	LexiconLoad(ctx context.Context) (words []database.Word, patterns []database.Pattern, err error)
+}
+
+// Lexicon is an in-memory copy of the lexicon that serves random patterns
+// and words without querying the database. Until it is loaded it defers to
+// Fallback.
+type Lexicon struct {
+	Fallback Source
+
+	mu        sync.RWMutex
+	loadedAt  time.Time
+	languages map[string]database.Language
+	patterns  map[string][]database.Pattern
+	words     map[string]map[string][]database.Word
+	rand      *rand.Rand
+	randMu    sync.Mutex
+
+	// stale asks Refresh to reload before the next tick.
+	stale chan struct{}
+}
+
+func NewLexicon(fallback Source) *Lexicon {
+	return &Lexicon{
+		Fallback: fallback,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		stale:    make(chan struct{}, 1),
+	}
+}
+
+func (l *Lexicon) Load(ctx context.Context, loader Loader) error {
+	languages, err := loader.LanguageList(ctx, true)
+	if err != nil {
+		return err
+	}
+	words, patterns, err := loader.LexiconLoad(ctx)
+	if err != nil {
+		return err
+	}
+
+	byCode := map[string]database.Language{}
+	for _, language := range languages {
+		byCode[language.Code] = language
+	}
+
+	byLanguage := map[string][]database.Pattern{}
+	for _, p := range patterns {
+		byLanguage[p.Language] = append(byLanguage[p.Language], p)
+	}
+
+	byPart := map[string]map[string][]database.Word{}
+	for _, w := range words {
+		if byPart[w.Language] == nil {
+			byPart[w.Language] = map[string][]database.Word{}
+		}
+		byPart[w.Language][w.Part] = append(byPart[w.Language][w.Part], w)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.languages = byCode
+	l.patterns = byLanguage
+	l.words = byPart
+	l.loadedAt = time.Now()
+
+	return nil
+}
+
+// Refresh reloads the lexicon every interval, and after Invalidate, until
+// stop is closed. Failed reloads keep the previous copy and are passed to
+// onErr. Closing stop cancels a reload in flight.
+func (l *Lexicon) Refresh(loader Loader, interval time.Duration, stop <-chan struct{}, onErr func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-l.stale:
+		}
+		if err := l.Load(ctx, loader); err != nil && onErr != nil {
+			onErr(err)
+		}
+	}
+}
+
+// Invalidate marks the lexicon stale after a write to the database, so
+// Refresh reloads it right away. Writes made while a reload is pending are
+// covered by that reload.
+func (l *Lexicon) Invalidate() {
+	select {
+	case l.stale <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Lexicon) Loaded() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return !l.loadedAt.IsZero()
+}
+
+func (l *Lexicon) LoadedAt() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.loadedAt
+}
+
+func (l *Lexicon) intn(n int) int {
+	l.randMu.Lock()
+	defer l.randMu.Unlock()
+	return l.rand.Intn(n)
+}
+
+func (l *Lexicon) LanguageGet(ctx context.Context, code string) (language database.Language, err error) {
+	l.mu.RLock()
+	if l.loadedAt.IsZero() {
+		l.mu.RUnlock()
+		return l.Fallback.LanguageGet(ctx, code)
+	}
+	language, ok := l.languages[code]
+	l.mu.RUnlock()
+
+	if !ok {
+		return language, errors.LanguageNotFound
+	}
+	return language, nil
+}
+
+func (l *Lexicon) PatternRandom(ctx context.Context, language string) (pattern database.Pattern, err error) {
+	l.mu.RLock()
+	if l.loadedAt.IsZero() {
+		l.mu.RUnlock()
+		return l.Fallback.PatternRandom(ctx, language)
+	}
+	patterns := l.patterns[language]
+	l.mu.RUnlock()
+
+	if len(patterns) == 0 {
+		return pattern, errors.PatternNotFound
+	}
+	return patterns[l.intn(len(patterns))], nil
+}
+
+func (l *Lexicon) WordRandom(ctx context.Context, language, part string, tags database.TagFilter) (word database.Word, err error) {
+	l.mu.RLock()
+	if l.loadedAt.IsZero() {
+		l.mu.RUnlock()
+		return l.Fallback.WordRandom(ctx, language, part, tags)
+	}
+	words := l.words[language][part]
+	l.mu.RUnlock()
+
+	if len(tags.IncludeTags) > 0 || len(tags.ExcludeTags) > 0 {
+		var matched []database.Word
+		for _, w := range words {
+			if tags.Match(w.Tags) {
+				matched = append(matched, w)
+			}
+		}
+		words = matched
+	}
+
+	if len(words) == 0 {
+		return word, errors.WordNotFound
+	}
+	return words[l.intn(len(words))], nil
+}
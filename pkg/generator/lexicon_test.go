@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+type fakeLoader struct {
+	languages []database.Language
+	words     []database.Word
+	patterns  []database.Pattern
+}
+
+func (l fakeLoader) LanguageList(ctx context.Context, showArchived bool) ([]database.Language, error) {
+	return l.languages, nil
+}
+
+func (l fakeLoader) LexiconLoad(ctx context.Context) ([]database.Word, []database.Pattern, error) {
+	return l.words, l.patterns, nil
+}
+
+// -----------------------------------------------------------------------------
+// Lexicon
+// -----------------------------------------------------------------------------
+func TestLexicon_Fallback(t *testing.T) {
+	t.Parallel()
+	lex := NewLexicon(&fakeSource{
+		patterns: []string{"noun"},
+		words:    map[string]string{"noun": "Hotel"},
+	})
+
+	if lex.Loaded() {
+		t.Fatal("loaded before Load")
+	}
+
+	alias, err := New(lex).Generate(context.Background(), "en", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Hotel" {
+		t.Fatal(alias)
+	}
+}
+
+func TestLexicon_Load(t *testing.T) {
+	t.Parallel()
+	lex := NewLexicon(&fakeSource{})
+
+	err := lex.Load(context.Background(), fakeLoader{
+		languages: []database.Language{
+			{Code: "en", Separator: " "},
+			{Code: "fr", Separator: " "},
+		},
+		words: []database.Word{
+			{Word: "Grand", Language: "en", Part: "adjective"},
+			{Word: "Hotel", Language: "en", Part: "noun"},
+			{Word: "Hôtel", Language: "fr", Part: "noun"},
+		},
+		patterns: []database.Pattern{
+			{Pattern: "adjective,noun", Language: "en"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lex.Loaded() {
+		t.Fatal("not loaded")
+	}
+
+	alias, err := New(lex).Generate(context.Background(), "en", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Hotel" {
+		t.Fatal(alias)
+	}
+
+	if _, err := lex.LanguageGet(context.Background(), "de"); err != errors.LanguageNotFound {
+		t.Fatal(err)
+	}
+	if _, err := lex.PatternRandom(context.Background(), "fr"); err != errors.PatternNotFound {
+		t.Fatal(err)
+	}
+	if _, err := lex.WordRandom(context.Background(), "fr", "adjective", database.TagFilter{}); err != errors.WordNotFound {
+		t.Fatal(err)
+	}
+}
+
+func TestLexicon_Tags(t *testing.T) {
+	t.Parallel()
+	lex := NewLexicon(&fakeSource{})
+
+	err := lex.Load(context.Background(), fakeLoader{
+		languages: []database.Language{{Code: "en", Separator: " "}},
+		words: []database.Word{
+			{Word: "Grand", Language: "en", Part: "adjective", Tags: []string{"corporate-safe"}},
+			{Word: "Cursed", Language: "en", Part: "adjective", Tags: []string{"fantasy"}},
+			{Word: "Hotel", Language: "en", Part: "noun", Tags: []string{"corporate-safe"}},
+			{Word: "Dragon", Language: "en", Part: "noun", Tags: []string{"animals", "fantasy"}},
+		},
+		patterns: []database.Pattern{
+			{Pattern: "adjective,noun", Language: "en", Theme: "fantasy"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := New(lex)
+
+	// The theme applies when nothing else is asked for.
+	alias, err := g.Generate(context.Background(), "en", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Cursed Dragon" {
+		t.Fatal(alias)
+	}
+
+	alias, err = g.Generate(context.Background(), "en", Options{Theme: "corporate-safe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Hotel" {
+		t.Fatal(alias)
+	}
+
+	alias, err = g.Generate(context.Background(), "en", Options{
+		Tags: database.TagFilter{IncludeTags: []string{"corporate-safe"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Hotel" {
+		t.Fatal(alias)
+	}
+
+	alias, err = g.Generate(context.Background(), "en", Options{
+		Tags:  database.TagFilter{IncludeTags: []string{"corporate-safe"}},
+		Slots: map[int]database.TagFilter{1: {IncludeTags: []string{"animals"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Alias != "Grand Dragon" {
+		t.Fatal(alias)
+	}
+
+	_, err = g.Generate(context.Background(), "en", Options{
+		Tags: database.TagFilter{ExcludeTags: []string{"fantasy"}},
+	})
+	if err != errors.GenerateFailed {
+		t.Fatal(err)
+	}
+}
+
+func TestLexicon_Invalidate(t *testing.T) {
+	t.Parallel()
+	lex := NewLexicon(&fakeSource{})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go lex.Refresh(fakeLoader{}, time.Hour, stop, nil)
+
+	// Nothing reloads for an hour but Invalidate.
+	lex.Invalidate()
+	for deadline := time.Now().Add(5 * time.Second); !lex.Loaded(); {
+		if time.Now().After(deadline) {
+			t.Fatal("not reloaded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,26 @@
+package generator
+
+import "github.com/timaraxian/alias-gen/pkg/metrics"
+
+// otherLanguage labels the series of unknown languages. Requests name any
+// language they like, so only those in the languages table get series of
+// their own.
+const otherLanguage = "other"
+
+var (
+	aliasesGenerated = metrics.NewCounterVec(
+		"aliasgen_aliases_generated_total",
+		"Aliases generated by language.",
+		"language",
+	)
+	generationRerolls = metrics.NewCounterVec(
+		"aliasgen_generation_rerolls_total",
+		"Patterns redrawn because a part had no words, by language.",
+		"language",
+	)
+	generationFailures = metrics.NewCounterVec(
+		"aliasgen_generation_failures_total",
+		"Generation attempts that produced no alias, by language.",
+		"language",
+	)
+)
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Level int32
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < Debug || l > Error {
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses a level name, defaulting to Info for an empty name.
+func ParseLevel(name string) (Level, error) {
+	if name == "" {
+		return Info, nil
+	}
+	for i, n := range levelNames {
+		if strings.EqualFold(n, name) {
+			return Level(i), nil
+		}
+	}
+	return Info, fmt.Errorf("unknown log level %q", name)
+}
+
+type Fields map[string]interface{}
+
+// Logger writes one JSON object per line for every entry at or above its
+// level.
+type Logger struct {
+	level int32
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func New(out io.Writer, level Level) *Logger {
+	return &Logger{out: out, level: int32(level)}
+}
+
+func (l *Logger) SetLevel(level Level) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
+func (l *Logger) Enabled(level Level) bool {
+	return int32(level) >= atomic.LoadInt32(&l.level)
+}
+
+func (l *Logger) Log(level Level, msg string, fields Fields) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	entry := make(Fields, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		b = []byte(fmt.Sprintf(`{"level":"error","msg":"failed encoding log entry: %s"}`, err))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out.Write(append(b, '\n'))
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a set of token buckets keyed by client. Each bucket holds up to
+// burst tokens and refills at rate tokens per second.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+const sweepInterval = time.Minute
+
+func New(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+func (l *Limiter) Allow(key string) (ok bool, retryAfter time.Duration) {
+	return l.AllowAt(key, time.Now())
+}
+
+// AllowAt takes a token from key's bucket. When the bucket is empty it
+// returns how long until the next token is available.
+func (l *Limiter) AllowAt(key string, now time.Time) (ok bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, found := l.buckets[key]
+	if !found {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens -= 1
+		return true, 0
+	}
+
+	if l.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	wait := (1 - b.tokens) / l.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// sweep drops buckets that have refilled completely, as they're equivalent
+// to a new bucket.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
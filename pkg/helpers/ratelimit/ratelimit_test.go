@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter_AllowAt(t *testing.T) {
+	t.Parallel()
+	l := New(1, 2)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.AllowAt("a", now); !ok {
+			t.Fatal(i)
+		}
+	}
+
+	ok, retryAfter := l.AllowAt("a", now)
+	if ok {
+		t.Fatal(ok)
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Fatal(retryAfter)
+	}
+
+	if ok, _ := l.AllowAt("b", now); !ok {
+		t.Fatal("buckets are not per key")
+	}
+
+	if ok, _ := l.AllowAt("a", now.Add(time.Second)); !ok {
+		t.Fatal("bucket did not refill")
+	}
+}
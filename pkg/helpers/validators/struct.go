@@ -0,0 +1,107 @@
+package validators
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+)
+
+// Charsets lists the named character sets usable with the `charset` rule.
+var Charsets = map[string]func(r rune) bool{
+	"word": func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsMark(r) || r == ' ' || r == '-' || r == '\''
+	},
+	"language": func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-')
+	},
+	"part": func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLower(r) || unicode.IsDigit(r) || r == '_')
+	},
+	"tag": func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLower(r) || unicode.IsDigit(r) || r == '-' || r == '_')
+	},
+	"pattern": func(r rune) bool {
+		return r < utf8.RuneSelf && (unicode.IsLower(r) || unicode.IsDigit(r) || r == '_' || r == ',')
+	},
+}
+
+// Struct validates the string fields of the struct pointed to by v against
+// the rules in their `validate` tag, e.g. `validate:"required,max=64,charset=word"`.
+// Field errors are reported using the field's json name.
+func Struct(v interface{}) (fieldErrs []errors.FieldError) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		tag := rt.Field(i).Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+
+		fv := reflect.Indirect(rv.Field(i))
+		value := ""
+		if fv.IsValid() && fv.Kind() == reflect.String {
+			value = fv.String()
+		}
+
+		if fieldErr, ok := validateField(value, tag); !ok {
+			fieldErr.Field = jsonName(rt.Field(i))
+			fieldErrs = append(fieldErrs, fieldErr)
+		}
+	}
+
+	return fieldErrs
+}
+
+func validateField(value, tag string) (fieldErr errors.FieldError, ok bool) {
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+
+		switch name {
+		case "required":
+			if strings.TrimSpace(value) == "" {
+				return errors.FieldError{Code: "Required", Msg: "is required"}, false
+			}
+		case "max":
+			max, err := strconv.Atoi(arg)
+			if err != nil {
+				panic("validators: bad max rule: " + rule)
+			}
+			if utf8.RuneCountInString(value) > max {
+				return errors.FieldError{Code: "TooLong", Msg: "must be at most " + arg + " characters"}, false
+			}
+		case "charset":
+			allowed, ok := Charsets[arg]
+			if !ok {
+				panic("validators: unknown charset: " + arg)
+			}
+			for _, r := range value {
+				if !allowed(r) {
+					return errors.FieldError{Code: "InvalidCharacters", Msg: "contains characters outside the " + arg + " charset"}, false
+				}
+			}
+		default:
+			panic("validators: unknown rule: " + rule)
+		}
+	}
+
+	return fieldErr, true
+}
+
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
@@ -0,0 +1,299 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds metrics and writes them in the Prometheus text exposition
+// format. Packages register their metrics on Default so they don't depend on
+// whatever serves them.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+	names   map[string]bool
+}
+
+type metric interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+var Default = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{names: map[string]bool{}}
+}
+
+func (reg *Registry) register(m metric) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if reg.names[m.name()] {
+		panic("metrics: duplicate metric " + m.name())
+	}
+	reg.names[m.name()] = true
+	reg.metrics = append(reg.metrics, m)
+}
+
+func (reg *Registry) Write(w io.Writer) error {
+	reg.mu.Lock()
+	metrics := append([]metric(nil), reg.metrics...)
+	reg.mu.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].name() < metrics[j].name() })
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+	return bw.Flush()
+}
+
+// -----------------------------------------------------------------------------
+type desc struct {
+	metricName string
+	help       string
+	kind       string
+	labels     []string
+}
+
+func (d desc) name() string {
+	return d.metricName
+}
+
+func (d desc) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.metricName, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.metricName, d.kind)
+}
+
+func (d desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.metricName, len(d.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (d desc) labelPairs(key string, extra ...string) string {
+	var pairs []string
+	if len(d.labels) > 0 {
+		for i, v := range strings.Split(key, "\xff") {
+			pairs = append(pairs, d.labels[i]+`="`+escapeLabel(v)+`"`)
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// -----------------------------------------------------------------------------
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return Default.NewCounterVec(name, help, labels...)
+}
+
+func (reg *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{metricName: name, help: help, kind: "counter", labels: labels},
+		values: map[string]float64{},
+	}
+	reg.register(c)
+	return c
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic("metrics: counters can't decrease")
+	}
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += v
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(w)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.metricName, c.labelPairs(key), formatFloat(c.values[key]))
+	}
+}
+
+// -----------------------------------------------------------------------------
+type GaugeVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return Default.NewGaugeVec(name, help, labels...)
+}
+
+func (reg *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{
+		desc:   desc{metricName: name, help: help, kind: "gauge", labels: labels},
+		values: map[string]float64{},
+	}
+	reg.register(g)
+	return g
+}
+
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[key] = v
+}
+
+// Reset drops every series, for gauges that are recomputed wholesale.
+func (g *GaugeVec) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values = map[string]float64{}
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.writeHeader(w)
+	for _, key := range sortedKeys(g.values) {
+		fmt.Fprintf(w, "%s%s %s\n", g.metricName, g.labelPairs(key), formatFloat(g.values[key]))
+	}
+}
+
+// -----------------------------------------------------------------------------
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type HistogramVec struct {
+	desc
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return Default.NewHistogramVec(name, help, buckets, labels...)
+}
+
+func (reg *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	h := &HistogramVec{
+		desc:    desc{metricName: name, help: help, kind: "histogram", labels: labels},
+		buckets: buckets,
+		series:  map[string]*histogram{},
+	}
+	reg.register(h)
+	return h
+}
+
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, le := range h.buckets {
+		if v <= le {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w)
+
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := h.series[key]
+		for i, le := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(key, "le", formatFloat(le)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(key, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, h.labelPairs(key), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, h.labelPairs(key), s.count)
+	}
+}
+
+// -----------------------------------------------------------------------------
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	t.Parallel()
+	reg := NewRegistry()
+
+	c := reg.NewCounterVec("test_requests_total", "Requests.", "route", "status")
+	c.Inc("/a", "200")
+	c.Add(2, "/a", "200")
+	c.Inc("/b\"", "500")
+
+	g := reg.NewGaugeVec("test_words", "Words.", "language")
+	g.Set(3, "en")
+
+	h := reg.NewHistogramVec("test_duration_seconds", "Duration.", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+
+	buf := &bytes.Buffer{}
+	if err := reg.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="0.1"} 1
+test_duration_seconds_bucket{le="1"} 2
+test_duration_seconds_bucket{le="+Inf"} 2
+test_duration_seconds_sum 0.55
+test_duration_seconds_count 2
+# HELP test_requests_total Requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/a",status="200"} 3
+test_requests_total{route="/b\"",status="500"} 1
+# HELP test_words Words.
+# TYPE test_words gauge
+test_words{language="en"} 3
+`
+	if buf.String() != expected {
+		t.Fatal(buf.String())
+	}
+}
+
+func TestRegistry_Duplicate(t *testing.T) {
+	t.Parallel()
+	reg := NewRegistry()
+	reg.NewCounterVec("test_total", "Total.")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	reg.NewCounterVec("test_total", "Total.")
+}
@@ -0,0 +1,1854 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.13.0
+// source: aliasgen.proto
+
+package aliasgenpb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type Word struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WordId     string                 `protobuf:"bytes,1,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	Word       string                 `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	Language   string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Part       string                 `protobuf:"bytes,4,opt,name=part,proto3" json:"part,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Word) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *Word) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Word) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Word) GetPart() string {
+	if x != nil {
+		return x.Part
+	}
+	return ""
+}
+
+func (x *Word) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Word) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Word) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+type WordCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word     string `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Part     string `protobuf:"bytes,3,opt,name=part,proto3" json:"part,omitempty"`
+}
+
+func (x *WordCreateRequest) Reset() {
+	*x = WordCreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordCreateRequest) ProtoMessage() {}
+
+func (x *WordCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordCreateRequest.ProtoReflect.Descriptor instead.
+func (*WordCreateRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WordCreateRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordCreateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *WordCreateRequest) GetPart() string {
+	if x != nil {
+		return x.Part
+	}
+	return ""
+}
+
+type WordGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WordId string `protobuf:"bytes,1,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+}
+
+func (x *WordGetRequest) Reset() {
+	*x = WordGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordGetRequest) ProtoMessage() {}
+
+func (x *WordGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordGetRequest.ProtoReflect.Descriptor instead.
+func (*WordGetRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WordGetRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+// WordUpdateRequest sets the non-empty fields.
+type WordUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WordId   string `protobuf:"bytes,1,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	Word     string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Part     string `protobuf:"bytes,4,opt,name=part,proto3" json:"part,omitempty"`
+}
+
+func (x *WordUpdateRequest) Reset() {
+	*x = WordUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordUpdateRequest) ProtoMessage() {}
+
+func (x *WordUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordUpdateRequest.ProtoReflect.Descriptor instead.
+func (*WordUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WordUpdateRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *WordUpdateRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordUpdateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *WordUpdateRequest) GetPart() string {
+	if x != nil {
+		return x.Part
+	}
+	return ""
+}
+
+type WordSetArchivedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WordId   string `protobuf:"bytes,1,opt,name=word_id,json=wordId,proto3" json:"word_id,omitempty"`
+	Archived bool   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+}
+
+func (x *WordSetArchivedRequest) Reset() {
+	*x = WordSetArchivedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordSetArchivedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordSetArchivedRequest) ProtoMessage() {}
+
+func (x *WordSetArchivedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordSetArchivedRequest.ProtoReflect.Descriptor instead.
+func (*WordSetArchivedRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WordSetArchivedRequest) GetWordId() string {
+	if x != nil {
+		return x.WordId
+	}
+	return ""
+}
+
+func (x *WordSetArchivedRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type WordListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// page_size defaults to 50 and is capped at 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page.
+	PageToken    string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ShowArchived bool   `protobuf:"varint,3,opt,name=show_archived,json=showArchived,proto3" json:"show_archived,omitempty"`
+}
+
+func (x *WordListRequest) Reset() {
+	*x = WordListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordListRequest) ProtoMessage() {}
+
+func (x *WordListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordListRequest.ProtoReflect.Descriptor instead.
+func (*WordListRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WordListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *WordListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *WordListRequest) GetShowArchived() bool {
+	if x != nil {
+		return x.ShowArchived
+	}
+	return false
+}
+
+type WordListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size counts the words on every page. Only the first page,
+	// requested without a page token, sets it.
+	TotalSize int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+}
+
+func (x *WordListResponse) Reset() {
+	*x = WordListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordListResponse) ProtoMessage() {}
+
+func (x *WordListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordListResponse.ProtoReflect.Descriptor instead.
+func (*WordListResponse) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WordListResponse) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *WordListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *WordListResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type Pattern struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PatternId  string                 `protobuf:"bytes,1,opt,name=pattern_id,json=patternId,proto3" json:"pattern_id,omitempty"`
+	Pattern    string                 `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Language   string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+}
+
+func (x *Pattern) Reset() {
+	*x = Pattern{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pattern) ProtoMessage() {}
+
+func (x *Pattern) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pattern.ProtoReflect.Descriptor instead.
+func (*Pattern) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Pattern) GetPatternId() string {
+	if x != nil {
+		return x.PatternId
+	}
+	return ""
+}
+
+func (x *Pattern) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *Pattern) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Pattern) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Pattern) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Pattern) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+type PatternCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pattern  string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *PatternCreateRequest) Reset() {
+	*x = PatternCreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternCreateRequest) ProtoMessage() {}
+
+func (x *PatternCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternCreateRequest.ProtoReflect.Descriptor instead.
+func (*PatternCreateRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PatternCreateRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *PatternCreateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type PatternGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PatternId string `protobuf:"bytes,1,opt,name=pattern_id,json=patternId,proto3" json:"pattern_id,omitempty"`
+}
+
+func (x *PatternGetRequest) Reset() {
+	*x = PatternGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternGetRequest) ProtoMessage() {}
+
+func (x *PatternGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternGetRequest.ProtoReflect.Descriptor instead.
+func (*PatternGetRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PatternGetRequest) GetPatternId() string {
+	if x != nil {
+		return x.PatternId
+	}
+	return ""
+}
+
+// PatternUpdateRequest sets the non-empty fields.
+type PatternUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PatternId string `protobuf:"bytes,1,opt,name=pattern_id,json=patternId,proto3" json:"pattern_id,omitempty"`
+	Pattern   string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Language  string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *PatternUpdateRequest) Reset() {
+	*x = PatternUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternUpdateRequest) ProtoMessage() {}
+
+func (x *PatternUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternUpdateRequest.ProtoReflect.Descriptor instead.
+func (*PatternUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PatternUpdateRequest) GetPatternId() string {
+	if x != nil {
+		return x.PatternId
+	}
+	return ""
+}
+
+func (x *PatternUpdateRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *PatternUpdateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type PatternSetArchivedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PatternId string `protobuf:"bytes,1,opt,name=pattern_id,json=patternId,proto3" json:"pattern_id,omitempty"`
+	Archived  bool   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+}
+
+func (x *PatternSetArchivedRequest) Reset() {
+	*x = PatternSetArchivedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternSetArchivedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternSetArchivedRequest) ProtoMessage() {}
+
+func (x *PatternSetArchivedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternSetArchivedRequest.ProtoReflect.Descriptor instead.
+func (*PatternSetArchivedRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PatternSetArchivedRequest) GetPatternId() string {
+	if x != nil {
+		return x.PatternId
+	}
+	return ""
+}
+
+func (x *PatternSetArchivedRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type PatternListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize     int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken    string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ShowArchived bool   `protobuf:"varint,3,opt,name=show_archived,json=showArchived,proto3" json:"show_archived,omitempty"`
+}
+
+func (x *PatternListRequest) Reset() {
+	*x = PatternListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternListRequest) ProtoMessage() {}
+
+func (x *PatternListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternListRequest.ProtoReflect.Descriptor instead.
+func (*PatternListRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PatternListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PatternListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *PatternListRequest) GetShowArchived() bool {
+	if x != nil {
+		return x.ShowArchived
+	}
+	return false
+}
+
+type PatternListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Patterns      []*Pattern `protobuf:"bytes,1,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	NextPageToken string     `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is set on the first page, as for WordListResponse.
+	TotalSize int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+}
+
+func (x *PatternListResponse) Reset() {
+	*x = PatternListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PatternListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatternListResponse) ProtoMessage() {}
+
+func (x *PatternListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatternListResponse.ProtoReflect.Descriptor instead.
+func (*PatternListResponse) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PatternListResponse) GetPatterns() []*Pattern {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *PatternListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *PatternListResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type Alias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Alias     string   `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Words     []string `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+	Language  string   `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	PatternId string   `protobuf:"bytes,4,opt,name=pattern_id,json=patternId,proto3" json:"pattern_id,omitempty"`
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Alias) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Alias) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *Alias) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Alias) GetPatternId() string {
+	if x != nil {
+		return x.PatternId
+	}
+	return ""
+}
+
+// TagFilter narrows the words drawn for an alias: a word needs one of
+// include_tags and none of exclude_tags.
+type TagFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IncludeTags []string `protobuf:"bytes,1,rep,name=include_tags,json=includeTags,proto3" json:"include_tags,omitempty"`
+	ExcludeTags []string `protobuf:"bytes,2,rep,name=exclude_tags,json=excludeTags,proto3" json:"exclude_tags,omitempty"`
+}
+
+func (x *TagFilter) Reset() {
+	*x = TagFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TagFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagFilter) ProtoMessage() {}
+
+func (x *TagFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagFilter.ProtoReflect.Descriptor instead.
+func (*TagFilter) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TagFilter) GetIncludeTags() []string {
+	if x != nil {
+		return x.IncludeTags
+	}
+	return nil
+}
+
+func (x *TagFilter) GetExcludeTags() []string {
+	if x != nil {
+		return x.ExcludeTags
+	}
+	return nil
+}
+
+type AliasGenerateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// count defaults to 1, and is at most 100.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// tags narrows the words of every slot. Without include_tags a pattern's
+	// theme applies.
+	Tags *TagFilter `protobuf:"bytes,3,opt,name=tags,proto3" json:"tags,omitempty"`
+	// slots narrows the words of the slots at those positions, from 0 to 31.
+	// A slot's include_tags replace those of tags, and its exclude_tags add to
+	// them.
+	Slots map[int32]*TagFilter `protobuf:"bytes,4,rep,name=slots,proto3" json:"slots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// theme replaces the theme of every pattern drawn.
+	Theme string `protobuf:"bytes,5,opt,name=theme,proto3" json:"theme,omitempty"`
+}
+
+func (x *AliasGenerateRequest) Reset() {
+	*x = AliasGenerateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliasGenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasGenerateRequest) ProtoMessage() {}
+
+func (x *AliasGenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasGenerateRequest.ProtoReflect.Descriptor instead.
+func (*AliasGenerateRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AliasGenerateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *AliasGenerateRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AliasGenerateRequest) GetTags() *TagFilter {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AliasGenerateRequest) GetSlots() map[int32]*TagFilter {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *AliasGenerateRequest) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+type AliasGenerateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Aliases []*Alias `protobuf:"bytes,1,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// quota_remaining is unset when the key's quota is unlimited.
+	QuotaRemaining *wrapperspb.Int32Value `protobuf:"bytes,2,opt,name=quota_remaining,json=quotaRemaining,proto3" json:"quota_remaining,omitempty"`
+}
+
+func (x *AliasGenerateResponse) Reset() {
+	*x = AliasGenerateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliasGenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasGenerateResponse) ProtoMessage() {}
+
+func (x *AliasGenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasGenerateResponse.ProtoReflect.Descriptor instead.
+func (*AliasGenerateResponse) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AliasGenerateResponse) GetAliases() []*Alias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *AliasGenerateResponse) GetQuotaRemaining() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.QuotaRemaining
+	}
+	return nil
+}
+
+type AliasStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// count of 0 streams until the space of aliases is exhausted.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// tags, slots and theme are as for AliasGenerateRequest.
+	Tags  *TagFilter           `protobuf:"bytes,3,opt,name=tags,proto3" json:"tags,omitempty"`
+	Slots map[int32]*TagFilter `protobuf:"bytes,4,rep,name=slots,proto3" json:"slots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Theme string               `protobuf:"bytes,5,opt,name=theme,proto3" json:"theme,omitempty"`
+}
+
+func (x *AliasStreamRequest) Reset() {
+	*x = AliasStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliasgen_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliasStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasStreamRequest) ProtoMessage() {}
+
+func (x *AliasStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliasgen_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasStreamRequest.ProtoReflect.Descriptor instead.
+func (*AliasStreamRequest) Descriptor() ([]byte, []int) {
+	return file_aliasgen_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AliasStreamRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *AliasStreamRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AliasStreamRequest) GetTags() *TagFilter {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AliasStreamRequest) GetSlots() map[int32]*TagFilter {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+func (x *AliasStreamRequest) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+var File_aliasgen_proto protoreflect.FileDescriptor
+
+var file_aliasgen_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0b, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96,
+	0x02, 0x0a, 0x04, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x72, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x22, 0x57, 0x0a, 0x11, 0x57, 0x6f, 0x72, 0x64, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74,
+	0x22, 0x29, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x64, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x22, 0x70, 0x0a, 0x11, 0x57,
+	0x6f, 0x72, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x22, 0x4d, 0x0a,
+	0x16, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x22, 0x72, 0x0a, 0x0f,
+	0x57, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x68, 0x6f, 0x77, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x73, 0x68, 0x6f, 0x77, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x22, 0x82, 0x01, 0x0a, 0x10, 0x57, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x91, 0x02, 0x0a, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3b, 0x0a, 0x0b,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4c, 0x0a, 0x14, 0x50, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a, 0x11, 0x50, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x49, 0x64, 0x22, 0x6b, 0x0a, 0x14, 0x50,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x56, 0x0a, 0x19, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x53, 0x65, 0x74, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64,
+	0x22, 0x75, 0x0a, 0x12, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x68, 0x6f, 0x77, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x22, 0x8e, 0x01, 0x0a, 0x13, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x6e, 0x0a, 0x05, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x49, 0x64, 0x22, 0x51, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x54, 0x61, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x61, 0x67, 0x73, 0x22, 0xa0, 0x02, 0x0a, 0x14,
+	0x41, 0x6c, 0x69, 0x61, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6c, 0x69, 0x61, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x68, 0x65, 0x6d, 0x65, 0x1a, 0x50, 0x0a, 0x0a,
+	0x53, 0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8b,
+	0x01, 0x0a, 0x15, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x07, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f,
+	0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e, 0x71, 0x75,
+	0x6f, 0x74, 0x61, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x9c, 0x02, 0x0a,
+	0x12, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x12, 0x40, 0x0a, 0x05, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6c, 0x69, 0x61, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x73, 0x6c,
+	0x6f, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x68, 0x65, 0x6d, 0x65, 0x1a, 0x50, 0x0a, 0x0a, 0x53, 0x6c, 0x6f,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xf7, 0x06, 0x0a, 0x08,
+	0x41, 0x6c, 0x69, 0x61, 0x73, 0x47, 0x65, 0x6e, 0x12, 0x3f, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x64,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x39, 0x0a, 0x07, 0x57, 0x6f, 0x72,
+	0x64, 0x47, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x6f, 0x72, 0x64, 0x12, 0x3f, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x64, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x6f, 0x72, 0x64, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x49, 0x0a, 0x0f, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x23, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x41, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64,
+	0x12, 0x47, 0x0a, 0x08, 0x57, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x50, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x42, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x47, 0x65,
+	0x74, 0x12, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x48, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x12, 0x52, 0x0a, 0x12, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x53, 0x65, 0x74, 0x41,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x26, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67,
+	0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x53, 0x65, 0x74,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x14, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x50, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x44, 0x0a, 0x0b, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1f,
+	0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x30, 0x01, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x69, 0x6d, 0x61, 0x72, 0x61, 0x78, 0x69, 0x61, 0x6e, 0x2f, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x2d, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63,
+	0x2f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x67, 0x65, 0x6e, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aliasgen_proto_rawDescOnce sync.Once
+	file_aliasgen_proto_rawDescData = file_aliasgen_proto_rawDesc
+)
+
+func file_aliasgen_proto_rawDescGZIP() []byte {
+	file_aliasgen_proto_rawDescOnce.Do(func() {
+		file_aliasgen_proto_rawDescData = protoimpl.X.CompressGZIP(file_aliasgen_proto_rawDescData)
+	})
+	return file_aliasgen_proto_rawDescData
+}
+
+var file_aliasgen_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_aliasgen_proto_goTypes = []interface{}{
+	(*Word)(nil),                      // 0: aliasgen.v1.Word
+	(*WordCreateRequest)(nil),         // 1: aliasgen.v1.WordCreateRequest
+	(*WordGetRequest)(nil),            // 2: aliasgen.v1.WordGetRequest
+	(*WordUpdateRequest)(nil),         // 3: aliasgen.v1.WordUpdateRequest
+	(*WordSetArchivedRequest)(nil),    // 4: aliasgen.v1.WordSetArchivedRequest
+	(*WordListRequest)(nil),           // 5: aliasgen.v1.WordListRequest
+	(*WordListResponse)(nil),          // 6: aliasgen.v1.WordListResponse
+	(*Pattern)(nil),                   // 7: aliasgen.v1.Pattern
+	(*PatternCreateRequest)(nil),      // 8: aliasgen.v1.PatternCreateRequest
+	(*PatternGetRequest)(nil),         // 9: aliasgen.v1.PatternGetRequest
+	(*PatternUpdateRequest)(nil),      // 10: aliasgen.v1.PatternUpdateRequest
+	(*PatternSetArchivedRequest)(nil), // 11: aliasgen.v1.PatternSetArchivedRequest
+	(*PatternListRequest)(nil),        // 12: aliasgen.v1.PatternListRequest
+	(*PatternListResponse)(nil),       // 13: aliasgen.v1.PatternListResponse
+	(*Alias)(nil),                     // 14: aliasgen.v1.Alias
+	(*TagFilter)(nil),                 // 15: aliasgen.v1.TagFilter
+	(*AliasGenerateRequest)(nil),      // 16: aliasgen.v1.AliasGenerateRequest
+	(*AliasGenerateResponse)(nil),     // 17: aliasgen.v1.AliasGenerateResponse
+	(*AliasStreamRequest)(nil),        // 18: aliasgen.v1.AliasStreamRequest
+	nil,                               // 19: aliasgen.v1.AliasGenerateRequest.SlotsEntry
+	nil,                               // 20: aliasgen.v1.AliasStreamRequest.SlotsEntry
+	(*timestamppb.Timestamp)(nil),     // 21: google.protobuf.Timestamp
+	(*wrapperspb.Int32Value)(nil),     // 22: google.protobuf.Int32Value
+}
+var file_aliasgen_proto_depIdxs = []int32{
+	21, // 0: aliasgen.v1.Word.created_at:type_name -> google.protobuf.Timestamp
+	21, // 1: aliasgen.v1.Word.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 2: aliasgen.v1.Word.archived_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: aliasgen.v1.WordListResponse.words:type_name -> aliasgen.v1.Word
+	21, // 4: aliasgen.v1.Pattern.created_at:type_name -> google.protobuf.Timestamp
+	21, // 5: aliasgen.v1.Pattern.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 6: aliasgen.v1.Pattern.archived_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: aliasgen.v1.PatternListResponse.patterns:type_name -> aliasgen.v1.Pattern
+	15, // 8: aliasgen.v1.AliasGenerateRequest.tags:type_name -> aliasgen.v1.TagFilter
+	19, // 9: aliasgen.v1.AliasGenerateRequest.slots:type_name -> aliasgen.v1.AliasGenerateRequest.SlotsEntry
+	14, // 10: aliasgen.v1.AliasGenerateResponse.aliases:type_name -> aliasgen.v1.Alias
+	22, // 11: aliasgen.v1.AliasGenerateResponse.quota_remaining:type_name -> google.protobuf.Int32Value
+	15, // 12: aliasgen.v1.AliasStreamRequest.tags:type_name -> aliasgen.v1.TagFilter
+	20, // 13: aliasgen.v1.AliasStreamRequest.slots:type_name -> aliasgen.v1.AliasStreamRequest.SlotsEntry
+	15, // 14: aliasgen.v1.AliasGenerateRequest.SlotsEntry.value:type_name -> aliasgen.v1.TagFilter
+	15, // 15: aliasgen.v1.AliasStreamRequest.SlotsEntry.value:type_name -> aliasgen.v1.TagFilter
+	1,  // 16: aliasgen.v1.AliasGen.WordCreate:input_type -> aliasgen.v1.WordCreateRequest
+	2,  // 17: aliasgen.v1.AliasGen.WordGet:input_type -> aliasgen.v1.WordGetRequest
+	3,  // 18: aliasgen.v1.AliasGen.WordUpdate:input_type -> aliasgen.v1.WordUpdateRequest
+	4,  // 19: aliasgen.v1.AliasGen.WordSetArchived:input_type -> aliasgen.v1.WordSetArchivedRequest
+	5,  // 20: aliasgen.v1.AliasGen.WordList:input_type -> aliasgen.v1.WordListRequest
+	8,  // 21: aliasgen.v1.AliasGen.PatternCreate:input_type -> aliasgen.v1.PatternCreateRequest
+	9,  // 22: aliasgen.v1.AliasGen.PatternGet:input_type -> aliasgen.v1.PatternGetRequest
+	10, // 23: aliasgen.v1.AliasGen.PatternUpdate:input_type -> aliasgen.v1.PatternUpdateRequest
+	11, // 24: aliasgen.v1.AliasGen.PatternSetArchived:input_type -> aliasgen.v1.PatternSetArchivedRequest
+	12, // 25: aliasgen.v1.AliasGen.PatternList:input_type -> aliasgen.v1.PatternListRequest
+	16, // 26: aliasgen.v1.AliasGen.AliasGenerate:input_type -> aliasgen.v1.AliasGenerateRequest
+	18, // 27: aliasgen.v1.AliasGen.AliasStream:input_type -> aliasgen.v1.AliasStreamRequest
+	0,  // 28: aliasgen.v1.AliasGen.WordCreate:output_type -> aliasgen.v1.Word
+	0,  // 29: aliasgen.v1.AliasGen.WordGet:output_type -> aliasgen.v1.Word
+	0,  // 30: aliasgen.v1.AliasGen.WordUpdate:output_type -> aliasgen.v1.Word
+	0,  // 31: aliasgen.v1.AliasGen.WordSetArchived:output_type -> aliasgen.v1.Word
+	6,  // 32: aliasgen.v1.AliasGen.WordList:output_type -> aliasgen.v1.WordListResponse
+	7,  // 33: aliasgen.v1.AliasGen.PatternCreate:output_type -> aliasgen.v1.Pattern
+	7,  // 34: aliasgen.v1.AliasGen.PatternGet:output_type -> aliasgen.v1.Pattern
+	7,  // 35: aliasgen.v1.AliasGen.PatternUpdate:output_type -> aliasgen.v1.Pattern
+	7,  // 36: aliasgen.v1.AliasGen.PatternSetArchived:output_type -> aliasgen.v1.Pattern
+	13, // 37: aliasgen.v1.AliasGen.PatternList:output_type -> aliasgen.v1.PatternListResponse
+	17, // 38: aliasgen.v1.AliasGen.AliasGenerate:output_type -> aliasgen.v1.AliasGenerateResponse
+	14, // 39: aliasgen.v1.AliasGen.AliasStream:output_type -> aliasgen.v1.Alias
+	28, // [28:40] is the sub-list for method output_type
+	16, // [16:28] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_aliasgen_proto_init() }
+func file_aliasgen_proto_init() {
+	if File_aliasgen_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_aliasgen_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Word); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordSetArchivedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pattern); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternSetArchivedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PatternListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TagFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliasGenerateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliasGenerateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliasgen_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliasStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aliasgen_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aliasgen_proto_goTypes,
+		DependencyIndexes: file_aliasgen_proto_depIdxs,
+		MessageInfos:      file_aliasgen_proto_msgTypes,
+	}.Build()
+	File_aliasgen_proto = out.File
+	file_aliasgen_proto_rawDesc = nil
+	file_aliasgen_proto_goTypes = nil
+	file_aliasgen_proto_depIdxs = nil
+}
@@ -0,0 +1,525 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package aliasgenpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// AliasGenClient is the client API for AliasGen service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AliasGenClient interface {
+	WordCreate(ctx context.Context, in *WordCreateRequest, opts ...grpc.CallOption) (*Word, error)
+	WordGet(ctx context.Context, in *WordGetRequest, opts ...grpc.CallOption) (*Word, error)
+	WordUpdate(ctx context.Context, in *WordUpdateRequest, opts ...grpc.CallOption) (*Word, error)
+	WordSetArchived(ctx context.Context, in *WordSetArchivedRequest, opts ...grpc.CallOption) (*Word, error)
+	WordList(ctx context.Context, in *WordListRequest, opts ...grpc.CallOption) (*WordListResponse, error)
+	PatternCreate(ctx context.Context, in *PatternCreateRequest, opts ...grpc.CallOption) (*Pattern, error)
+	PatternGet(ctx context.Context, in *PatternGetRequest, opts ...grpc.CallOption) (*Pattern, error)
+	PatternUpdate(ctx context.Context, in *PatternUpdateRequest, opts ...grpc.CallOption) (*Pattern, error)
+	PatternSetArchived(ctx context.Context, in *PatternSetArchivedRequest, opts ...grpc.CallOption) (*Pattern, error)
+	PatternList(ctx context.Context, in *PatternListRequest, opts ...grpc.CallOption) (*PatternListResponse, error)
+	AliasGenerate(ctx context.Context, in *AliasGenerateRequest, opts ...grpc.CallOption) (*AliasGenerateResponse, error)
+	// AliasStream sends unique aliases until count is reached, the space of
+	// aliases is exhausted, or the call is cancelled.
+	AliasStream(ctx context.Context, in *AliasStreamRequest, opts ...grpc.CallOption) (AliasGen_AliasStreamClient, error)
+}
+
+type aliasGenClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAliasGenClient(cc grpc.ClientConnInterface) AliasGenClient {
+	return &aliasGenClient{cc}
+}
+
+func (c *aliasGenClient) WordCreate(ctx context.Context, in *WordCreateRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/WordCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) WordGet(ctx context.Context, in *WordGetRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/WordGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) WordUpdate(ctx context.Context, in *WordUpdateRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/WordUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) WordSetArchived(ctx context.Context, in *WordSetArchivedRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/WordSetArchived", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) WordList(ctx context.Context, in *WordListRequest, opts ...grpc.CallOption) (*WordListResponse, error) {
+	out := new(WordListResponse)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/WordList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) PatternCreate(ctx context.Context, in *PatternCreateRequest, opts ...grpc.CallOption) (*Pattern, error) {
+	out := new(Pattern)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/PatternCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) PatternGet(ctx context.Context, in *PatternGetRequest, opts ...grpc.CallOption) (*Pattern, error) {
+	out := new(Pattern)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/PatternGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) PatternUpdate(ctx context.Context, in *PatternUpdateRequest, opts ...grpc.CallOption) (*Pattern, error) {
+	out := new(Pattern)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/PatternUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) PatternSetArchived(ctx context.Context, in *PatternSetArchivedRequest, opts ...grpc.CallOption) (*Pattern, error) {
+	out := new(Pattern)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/PatternSetArchived", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) PatternList(ctx context.Context, in *PatternListRequest, opts ...grpc.CallOption) (*PatternListResponse, error) {
+	out := new(PatternListResponse)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/PatternList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) AliasGenerate(ctx context.Context, in *AliasGenerateRequest, opts ...grpc.CallOption) (*AliasGenerateResponse, error) {
+	out := new(AliasGenerateResponse)
+	err := c.cc.Invoke(ctx, "/aliasgen.v1.AliasGen/AliasGenerate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aliasGenClient) AliasStream(ctx context.Context, in *AliasStreamRequest, opts ...grpc.CallOption) (AliasGen_AliasStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AliasGen_serviceDesc.Streams[0], "/aliasgen.v1.AliasGen/AliasStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aliasGenAliasStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AliasGen_AliasStreamClient interface {
+	Recv() (*Alias, error)
+	grpc.ClientStream
+}
+
+type aliasGenAliasStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aliasGenAliasStreamClient) Recv() (*Alias, error) {
+	m := new(Alias)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AliasGenServer is the server API for AliasGen service.
+// All implementations must embed UnimplementedAliasGenServer
+// for forward compatibility
+type AliasGenServer interface {
+	WordCreate(context.Context, *WordCreateRequest) (*Word, error)
+	WordGet(context.Context, *WordGetRequest) (*Word, error)
+	WordUpdate(context.Context, *WordUpdateRequest) (*Word, error)
+	WordSetArchived(context.Context, *WordSetArchivedRequest) (*Word, error)
+	WordList(context.Context, *WordListRequest) (*WordListResponse, error)
+	PatternCreate(context.Context, *PatternCreateRequest) (*Pattern, error)
+	PatternGet(context.Context, *PatternGetRequest) (*Pattern, error)
+	PatternUpdate(context.Context, *PatternUpdateRequest) (*Pattern, error)
+	PatternSetArchived(context.Context, *PatternSetArchivedRequest) (*Pattern, error)
+	PatternList(context.Context, *PatternListRequest) (*PatternListResponse, error)
+	AliasGenerate(context.Context, *AliasGenerateRequest) (*AliasGenerateResponse, error)
+	// AliasStream sends unique aliases until count is reached, the space of
+	// aliases is exhausted, or the call is cancelled.
+	AliasStream(*AliasStreamRequest, AliasGen_AliasStreamServer) error
+	mustEmbedUnimplementedAliasGenServer()
+}
+
+// UnimplementedAliasGenServer must be embedded to have forward compatible implementations.
+type UnimplementedAliasGenServer struct {
+}
+
+func (UnimplementedAliasGenServer) WordCreate(context.Context, *WordCreateRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordCreate not implemented")
+}
+func (UnimplementedAliasGenServer) WordGet(context.Context, *WordGetRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordGet not implemented")
+}
+func (UnimplementedAliasGenServer) WordUpdate(context.Context, *WordUpdateRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordUpdate not implemented")
+}
+func (UnimplementedAliasGenServer) WordSetArchived(context.Context, *WordSetArchivedRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordSetArchived not implemented")
+}
+func (UnimplementedAliasGenServer) WordList(context.Context, *WordListRequest) (*WordListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WordList not implemented")
+}
+func (UnimplementedAliasGenServer) PatternCreate(context.Context, *PatternCreateRequest) (*Pattern, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatternCreate not implemented")
+}
+func (UnimplementedAliasGenServer) PatternGet(context.Context, *PatternGetRequest) (*Pattern, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatternGet not implemented")
+}
+func (UnimplementedAliasGenServer) PatternUpdate(context.Context, *PatternUpdateRequest) (*Pattern, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatternUpdate not implemented")
+}
+func (UnimplementedAliasGenServer) PatternSetArchived(context.Context, *PatternSetArchivedRequest) (*Pattern, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatternSetArchived not implemented")
+}
+func (UnimplementedAliasGenServer) PatternList(context.Context, *PatternListRequest) (*PatternListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatternList not implemented")
+}
+func (UnimplementedAliasGenServer) AliasGenerate(context.Context, *AliasGenerateRequest) (*AliasGenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AliasGenerate not implemented")
+}
+func (UnimplementedAliasGenServer) AliasStream(*AliasStreamRequest, AliasGen_AliasStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AliasStream not implemented")
+}
+func (UnimplementedAliasGenServer) mustEmbedUnimplementedAliasGenServer() {}
+
+// UnsafeAliasGenServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AliasGenServer will
+// result in compilation errors.
+type UnsafeAliasGenServer interface {
+	mustEmbedUnimplementedAliasGenServer()
+}
+
+func RegisterAliasGenServer(s *grpc.Server, srv AliasGenServer) {
+	s.RegisterService(&_AliasGen_serviceDesc, srv)
+}
+
+func _AliasGen_WordCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).WordCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/WordCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).WordCreate(ctx, req.(*WordCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_WordGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).WordGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/WordGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).WordGet(ctx, req.(*WordGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_WordUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).WordUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/WordUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).WordUpdate(ctx, req.(*WordUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_WordSetArchived_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordSetArchivedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).WordSetArchived(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/WordSetArchived",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).WordSetArchived(ctx, req.(*WordSetArchivedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_WordList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WordListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).WordList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/WordList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).WordList(ctx, req.(*WordListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_PatternCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).PatternCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/PatternCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).PatternCreate(ctx, req.(*PatternCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_PatternGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).PatternGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/PatternGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).PatternGet(ctx, req.(*PatternGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_PatternUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).PatternUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/PatternUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).PatternUpdate(ctx, req.(*PatternUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_PatternSetArchived_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternSetArchivedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).PatternSetArchived(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/PatternSetArchived",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).PatternSetArchived(ctx, req.(*PatternSetArchivedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_PatternList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatternListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).PatternList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/PatternList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).PatternList(ctx, req.(*PatternListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_AliasGenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AliasGenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AliasGenServer).AliasGenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliasgen.v1.AliasGen/AliasGenerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AliasGenServer).AliasGenerate(ctx, req.(*AliasGenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AliasGen_AliasStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AliasStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AliasGenServer).AliasStream(m, &aliasGenAliasStreamServer{stream})
+}
+
+type AliasGen_AliasStreamServer interface {
+	Send(*Alias) error
+	grpc.ServerStream
+}
+
+type aliasGenAliasStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aliasGenAliasStreamServer) Send(m *Alias) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _AliasGen_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aliasgen.v1.AliasGen",
+	HandlerType: (*AliasGenServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WordCreate",
+			Handler:    _AliasGen_WordCreate_Handler,
+		},
+		{
+			MethodName: "WordGet",
+			Handler:    _AliasGen_WordGet_Handler,
+		},
+		{
+			MethodName: "WordUpdate",
+			Handler:    _AliasGen_WordUpdate_Handler,
+		},
+		{
+			MethodName: "WordSetArchived",
+			Handler:    _AliasGen_WordSetArchived_Handler,
+		},
+		{
+			MethodName: "WordList",
+			Handler:    _AliasGen_WordList_Handler,
+		},
+		{
+			MethodName: "PatternCreate",
+			Handler:    _AliasGen_PatternCreate_Handler,
+		},
+		{
+			MethodName: "PatternGet",
+			Handler:    _AliasGen_PatternGet_Handler,
+		},
+		{
+			MethodName: "PatternUpdate",
+			Handler:    _AliasGen_PatternUpdate_Handler,
+		},
+		{
+			MethodName: "PatternSetArchived",
+			Handler:    _AliasGen_PatternSetArchived_Handler,
+		},
+		{
+			MethodName: "PatternList",
+			Handler:    _AliasGen_PatternList_Handler,
+		},
+		{
+			MethodName: "AliasGenerate",
+			Handler:    _AliasGen_AliasGenerate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AliasStream",
+			Handler:       _AliasGen_AliasStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aliasgen.proto",
+}
@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCodes maps an errors.Error code to the gRPC status code returned to
+// clients. Codes that aren't listed are treated as internal errors.
+var grpcCodes = map[string]codes.Code{}
+
+func registerCode(e errors.Error, code codes.Code) {
+	grpcCodes[e.Code()] = code
+}
+
+func init() {
+	registerCode(errors.Unexpected, codes.Internal)
+
+	registerCode(errors.HttpBadRequestArgs, codes.InvalidArgument)
+	registerCode(errors.HttpInvalidArgs, codes.InvalidArgument)
+
+	registerCode(errors.WordDuplicate, codes.AlreadyExists)
+	registerCode(errors.WordNotFound, codes.NotFound)
+
+	registerCode(errors.PatternDuplicate, codes.AlreadyExists)
+	registerCode(errors.PatternNotFound, codes.NotFound)
+
+	registerCode(errors.LanguageDuplicate, codes.AlreadyExists)
+	registerCode(errors.LanguageNotFound, codes.NotFound)
+	registerCode(errors.LanguageInvalid, codes.InvalidArgument)
+	registerCode(errors.LanguageArchived, codes.FailedPrecondition)
+
+	registerCode(errors.PartDuplicate, codes.AlreadyExists)
+	registerCode(errors.PartNotFound, codes.NotFound)
+	registerCode(errors.PartInvalid, codes.InvalidArgument)
+	registerCode(errors.TagInvalid, codes.InvalidArgument)
+
+	registerCode(errors.AuthRequired, codes.Unauthenticated)
+	registerCode(errors.AuthInvalid, codes.Unauthenticated)
+	registerCode(errors.AuthForbidden, codes.PermissionDenied)
+
+	registerCode(errors.RateLimited, codes.ResourceExhausted)
+	registerCode(errors.QuotaExceeded, codes.ResourceExhausted)
+	registerCode(errors.GenerateFailed, codes.FailedPrecondition)
+	registerCode(errors.GenerateExhausted, codes.FailedPrecondition)
+
+	registerCode(errors.InvalidUUID, codes.InvalidArgument)
+	registerCode(errors.InvalidCursor, codes.InvalidArgument)
+	registerCode(errors.InvalidOrderBy, codes.InvalidArgument)
+	registerCode(errors.InvalidFilter, codes.InvalidArgument)
+
+	registerCode(errors.NotSupported, codes.Unimplemented)
+}
+
+type codedErr interface {
+	Code() string
+	Msg() string
+}
+
+// toStatus converts an error from the DBAL or generator into a gRPC status.
+// The errors.Error code is the status message, followed by its message if it
+// has one. Validation errors carry their fields as BadRequest details.
+func (s *Server) toStatus(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case context.DeadlineExceeded:
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	// Already a status, e.g. from a failed stream send.
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	appErr, ok := err.(codedErr)
+	code, known := codes.Internal, false
+	if ok {
+		code, known = grpcCodes[appErr.Code()]
+	}
+	if !known || code == codes.Internal {
+		s.app().Logger.Log(logger.Error, err.Error(), nil)
+		return status.Error(codes.Internal, errors.Unexpected.Code())
+	}
+
+	msg := appErr.Code()
+	if appErr.Msg() != "" {
+		msg += ": " + appErr.Msg()
+	}
+	st := status.New(code, msg)
+
+	if fieldErr, ok := err.(errors.ValidationError); ok {
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range fieldErr.Fields() {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Msg,
+			})
+		}
+		if withDetails, err := st.WithDetails(badRequest); err == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
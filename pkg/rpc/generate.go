@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/application"
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+	pb "github.com/timaraxian/alias-gen/pkg/rpc/aliasgenpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	maxAliasGenerateCount = 100
+	maxAliasStreamCount   = 100000
+)
+
+func aliasToPB(alias generator.Alias) *pb.Alias {
+	return &pb.Alias{
+		Alias:     alias.Alias,
+		Words:     alias.Words,
+		Language:  alias.Language,
+		PatternId: alias.PatternID,
+	}
+}
+
+// generateOptions checks the tags, slots and theme of a request as the JSON
+// API does.
+func generateOptions(tags *pb.TagFilter, slots map[int32]*pb.TagFilter, theme string) (generator.Options, error) {
+	var bySlot map[int]database.TagFilter
+	if len(slots) > 0 {
+		bySlot = map[int]database.TagFilter{}
+		for position, slot := range slots {
+			bySlot[int(position)] = database.TagFilter{
+				IncludeTags: slot.GetIncludeTags(),
+				ExcludeTags: slot.GetExcludeTags(),
+			}
+		}
+	}
+	return application.GenerateOptions(tags.GetIncludeTags(), tags.GetExcludeTags(), bySlot, theme)
+}
+
+func countOutOfRange(min, max int) error {
+	return errors.HttpInvalidArgs.WithFields(errors.FieldError{
+		Field: "count",
+		Code:  "OutOfRange",
+		Msg:   "must be between " + strconv.Itoa(min) + " and " + strconv.Itoa(max),
+	})
+}
+
+func (s *Server) AliasGenerate(ctx context.Context, req *pb.AliasGenerateRequest) (*pb.AliasGenerateResponse, error) {
+	args := &application.AliasGenerateArgs{Language: req.Language, Count: int(req.Count)}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+	if args.Count == 0 {
+		args.Count = 1
+	}
+	if args.Count < 0 || args.Count > maxAliasGenerateCount {
+		return nil, countOutOfRange(1, maxAliasGenerateCount)
+	}
+	opts, err := generateOptions(req.Tags, req.Slots, req.Theme)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := apiKeyFromContext(ctx)
+	now := time.Now()
+	remaining, err := s.app().Keys.GenerationQuotaConsume(ctx, apiKey.ApiKeyID, args.Count, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.AliasGenerateResponse{}
+	if remaining != nil {
+		resp.QuotaRemaining = wrapperspb.Int32(int32(*remaining))
+	}
+	for i := 0; i < args.Count; i++ {
+		alias, err := s.app().Generator.Generate(ctx, args.Language, opts)
+		if err != nil {
+			s.app().GenerationQuotaRefund(apiKey.ApiKeyID, args.Count, now)
+			return nil, err
+		}
+		resp.Aliases = append(resp.Aliases, aliasToPB(alias))
+	}
+	return resp, nil
+}
+
+// AliasStream sends unique aliases until the count is reached or the space of
+// aliases is exhausted. Running out of quota mid-stream ends the call with
+// ResourceExhausted after the aliases already sent.
+func (s *Server) AliasStream(req *pb.AliasStreamRequest, stream pb.AliasGen_AliasStreamServer) error {
+	args := &application.AliasStreamArgs{Language: req.Language, Count: int(req.Count)}
+	if err := validate(args); err != nil {
+		return err
+	}
+	if args.Count == 0 {
+		args.Count = maxAliasStreamCount
+	}
+	if args.Count < 0 || args.Count > maxAliasStreamCount {
+		return countOutOfRange(0, maxAliasStreamCount)
+	}
+	opts, err := generateOptions(req.Tags, req.Slots, req.Theme)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	apiKey := apiKeyFromContext(ctx)
+	unique := s.app().Generator.Unique(args.Language, opts)
+
+	quota := s.app().ReserveQuota(apiKey.ApiKeyID, args.Count)
+	defer quota.Release()
+
+	for sent := 0; sent < args.Count; sent++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		alias, err := unique.Next(ctx)
+		if errors.GenerateExhausted.Equals(err) && sent > 0 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := quota.Take(ctx); err != nil {
+			return err
+		}
+
+		if err := stream.Send(aliasToPB(alias)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/timaraxian/alias-gen/pkg/application"
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/validators"
+	pb "github.com/timaraxian/alias-gen/pkg/rpc/aliasgenpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type patternArgs struct {
+	Pattern  string `json:"pattern" validate:"required,max=255,charset=pattern"`
+	Language string `json:"language" validate:"required,max=35,charset=language"`
+}
+
+func validate(args interface{}) error {
+	if fieldErrs := validators.Struct(args); len(fieldErrs) > 0 {
+		return errors.HttpInvalidArgs.WithFields(fieldErrs...)
+	}
+	return nil
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func wordToPB(word database.Word) *pb.Word {
+	return &pb.Word{
+		WordId:     word.WordID,
+		Word:       word.Word,
+		Language:   word.Language,
+		Part:       word.Part,
+		CreatedAt:  timestamp(&word.CreatedAt),
+		UpdatedAt:  timestamp(&word.UpdatedAt),
+		ArchivedAt: timestamp(word.ArchivedAt),
+	}
+}
+
+func patternToPB(pattern database.Pattern) *pb.Pattern {
+	return &pb.Pattern{
+		PatternId:  pattern.PatternID,
+		Pattern:    pattern.Pattern,
+		Language:   pattern.Language,
+		CreatedAt:  timestamp(&pattern.CreatedAt),
+		UpdatedAt:  timestamp(&pattern.UpdatedAt),
+		ArchivedAt: timestamp(pattern.ArchivedAt),
+	}
+}
+
+func archivedFilter(show bool) string {
+	if show {
+		return database.ArchivedInclude
+	}
+	return database.ArchivedExclude
+}
+
+// -----------------------------------------------------------------------------
+// Words
+// -----------------------------------------------------------------------------
+func (s *Server) WordCreate(ctx context.Context, req *pb.WordCreateRequest) (*pb.Word, error) {
+	args := &application.WordCreateArgs{Word: req.Word, Language: req.Language, Part: req.Part}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	word, err := s.app().Store.WordCreate(ctx, args.Word, args.Language, args.Part)
+	if err != nil {
+		return nil, err
+	}
+	return wordToPB(word), nil
+}
+
+func (s *Server) WordGet(ctx context.Context, req *pb.WordGetRequest) (*pb.Word, error) {
+	word, err := s.app().Store.WordGet(ctx, req.WordId)
+	if err != nil {
+		return nil, err
+	}
+	return wordToPB(word), nil
+}
+
+func (s *Server) WordUpdate(ctx context.Context, req *pb.WordUpdateRequest) (*pb.Word, error) {
+	word, err := s.app().Store.WordGet(ctx, req.WordId)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &application.WordCreateArgs{Word: word.Word, Language: word.Language, Part: word.Part}
+	if req.Word != "" {
+		args.Word = req.Word
+	}
+	if req.Language != "" {
+		args.Language = req.Language
+	}
+	if req.Part != "" {
+		args.Part = req.Part
+	}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	word.Word, word.Language, word.Part = args.Word, args.Language, args.Part
+	if err := s.app().Store.WordUpdate(ctx, word); err != nil {
+		return nil, err
+	}
+
+	return s.WordGet(ctx, &pb.WordGetRequest{WordId: word.WordID})
+}
+
+func (s *Server) WordSetArchived(ctx context.Context, req *pb.WordSetArchivedRequest) (*pb.Word, error) {
+	var err error
+	if req.Archived {
+		err = s.app().Store.WordSetArchive(ctx, req.WordId)
+	} else {
+		err = s.app().Store.WordSetUnArchive(ctx, req.WordId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.WordGet(ctx, &pb.WordGetRequest{WordId: req.WordId})
+}
+
+func (s *Server) WordList(ctx context.Context, req *pb.WordListRequest) (*pb.WordListResponse, error) {
+	filter := database.WordFilter{Archived: archivedFilter(req.ShowArchived)}
+
+	// Page tokens are the store's cursors.
+	words, page, err := s.app().Store.WordList(ctx, database.WordListArgs{
+		Filter:  filter,
+		OrderBy: []database.OrderBy{{Key: "createdAt"}},
+		Limit:   int(req.PageSize),
+		Cursor:  req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.WordListResponse{NextPageToken: page.NextCursor}
+	if req.PageToken == "" {
+		facets, err := s.app().Store.WordFacets(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		resp.TotalSize = int32(facets.Total)
+	}
+	for _, word := range words {
+		resp.Words = append(resp.Words, wordToPB(word))
+	}
+	return resp, nil
+}
+
+// -----------------------------------------------------------------------------
+// Patterns
+// -----------------------------------------------------------------------------
+func (s *Server) PatternCreate(ctx context.Context, req *pb.PatternCreateRequest) (*pb.Pattern, error) {
+	args := &patternArgs{Pattern: req.Pattern, Language: req.Language}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	pattern, err := s.app().Store.PatternCreate(ctx, args.Pattern, args.Language)
+	if err != nil {
+		return nil, err
+	}
+	return patternToPB(pattern), nil
+}
+
+func (s *Server) PatternGet(ctx context.Context, req *pb.PatternGetRequest) (*pb.Pattern, error) {
+	pattern, err := s.app().Store.PatternGet(ctx, req.PatternId)
+	if err != nil {
+		return nil, err
+	}
+	return patternToPB(pattern), nil
+}
+
+func (s *Server) PatternUpdate(ctx context.Context, req *pb.PatternUpdateRequest) (*pb.Pattern, error) {
+	pattern, err := s.app().Store.PatternGet(ctx, req.PatternId)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &patternArgs{Pattern: pattern.Pattern, Language: pattern.Language}
+	if req.Pattern != "" {
+		args.Pattern = req.Pattern
+	}
+	if req.Language != "" {
+		args.Language = req.Language
+	}
+	if err := validate(args); err != nil {
+		return nil, err
+	}
+
+	pattern.Pattern, pattern.Language = args.Pattern, args.Language
+	if err := s.app().Store.PatternUpdate(ctx, pattern); err != nil {
+		return nil, err
+	}
+
+	return s.PatternGet(ctx, &pb.PatternGetRequest{PatternId: pattern.PatternID})
+}
+
+func (s *Server) PatternSetArchived(ctx context.Context, req *pb.PatternSetArchivedRequest) (*pb.Pattern, error) {
+	var err error
+	if req.Archived {
+		err = s.app().Store.PatternSetArchive(ctx, req.PatternId)
+	} else {
+		err = s.app().Store.PatternSetUnArchive(ctx, req.PatternId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PatternGet(ctx, &pb.PatternGetRequest{PatternId: req.PatternId})
+}
+
+func (s *Server) PatternList(ctx context.Context, req *pb.PatternListRequest) (*pb.PatternListResponse, error) {
+	filter := database.PatternFilter{Archived: archivedFilter(req.ShowArchived)}
+
+	// Page tokens are the store's cursors.
+	patterns, page, err := s.app().Store.PatternList(ctx, database.PatternListArgs{
+		Filter:  filter,
+		OrderBy: []database.OrderBy{{Key: "createdAt"}},
+		Limit:   int(req.PageSize),
+		Cursor:  req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.PatternListResponse{NextPageToken: page.NextCursor}
+	if req.PageToken == "" {
+		facets, err := s.app().Store.PatternFacets(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		resp.TotalSize = int32(facets.Total)
+	}
+	for _, pattern := range patterns {
+		resp.Patterns = append(resp.Patterns, patternToPB(pattern))
+	}
+	return resp, nil
+}
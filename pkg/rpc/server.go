@@ -0,0 +1,301 @@
+// Package rpc serves the lexicon and generation API over gRPC, sharing the
+// application.App of the JSON API.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative -I aliasgenpb aliasgenpb/aliasgen.proto
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/timaraxian/alias-gen/pkg/application"
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	"github.com/timaraxian/alias-gen/pkg/helpers/ratelimit"
+	pb "github.com/timaraxian/alias-gen/pkg/rpc/aliasgenpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type Server struct {
+	pb.UnimplementedAliasGenServer
+
+	// app returns the current App, which changes when config is reloaded.
+	app  func() *application.App
+	GRPC *grpc.Server
+
+	// limiters are the rate limit buckets of each method, and addrLimiter
+	// those of each client address, under limitersApp's config. They start
+	// afresh when the config is reloaded.
+	limitersMu  sync.Mutex
+	limitersApp *application.App
+	limiters    map[string]*ratelimit.Limiter
+	addrLimiter *ratelimit.Limiter
+}
+
+// methodScopes is the API key scope each method requires.
+var methodScopes = map[string]string{
+	"WordCreate":         database.ScopeLexiconWrite,
+	"WordGet":            database.ScopeLexiconRead,
+	"WordUpdate":         database.ScopeLexiconWrite,
+	"WordSetArchived":    database.ScopeLexiconWrite,
+	"WordList":           database.ScopeLexiconRead,
+	"PatternCreate":      database.ScopeLexiconWrite,
+	"PatternGet":         database.ScopeLexiconRead,
+	"PatternUpdate":      database.ScopeLexiconWrite,
+	"PatternSetArchived": database.ScopeLexiconWrite,
+	"PatternList":        database.ScopeLexiconRead,
+	"AliasGenerate":      database.ScopeGenerate,
+	"AliasStream":        database.ScopeGenerate,
+}
+
+func New(app func() *application.App) *Server {
+	s := &Server{app: app}
+	s.GRPC = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	)
+	pb.RegisterAliasGenServer(s.GRPC, s)
+	return s
+}
+
+// ListenAndServe blocks until the server fails or is stopped.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.GRPC.Serve(lis)
+}
+
+// Shutdown waits up to timeout for in-flight calls, then cancels them.
+func (s *Server) Shutdown(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.GRPC.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.GRPC.Stop()
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Authentication
+// -----------------------------------------------------------------------------
+type ctxKey int
+
+const apiKeyCtxKey ctxKey = iota
+
+// methodName is the method of a full gRPC method name, without the service.
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func apiKeyFromContext(ctx context.Context) database.ApiKey {
+	apiKey, _ := ctx.Value(apiKeyCtxKey).(database.ApiKey)
+	return apiKey
+}
+
+// authenticate checks the bearer API key in the call's metadata against the
+// scope the method requires.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	scope, ok := methodScopes[methodName(fullMethod)]
+	if !ok {
+		return ctx, errors.AuthForbidden
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var header string
+	if values := md.Get("authorization"); len(values) > 0 {
+		header = values[0]
+	}
+	if !strings.HasPrefix(header, "Bearer ") {
+		return ctx, errors.AuthRequired
+	}
+
+	apiKey, err := s.app().Keys.ApiKeyAuthenticate(ctx, strings.TrimPrefix(header, "Bearer "))
+	if err != nil {
+		return ctx, err
+	}
+	if !apiKey.HasScope(scope) {
+		return ctx, errors.AuthForbidden
+	}
+
+	return context.WithValue(ctx, apiKeyCtxKey, apiKey), nil
+}
+
+// -----------------------------------------------------------------------------
+// Rate limiting
+// -----------------------------------------------------------------------------
+
+// resetLimiters starts the buckets afresh when the config has been reloaded.
+// The caller holds limitersMu.
+func (s *Server) resetLimiters() *application.App {
+	app := s.app()
+	if s.limitersApp != app {
+		s.limitersApp, s.limiters, s.addrLimiter = app, map[string]*ratelimit.Limiter{}, nil
+		if limit := app.Config.RateLimit.Addr; limit.Rate > 0 {
+			s.addrLimiter = ratelimit.New(limit.Rate, limit.Burst)
+		}
+	}
+	return app
+}
+
+// limiter returns the bucket set of method, or nil when it isn't limited.
+func (s *Server) limiter(method string) *ratelimit.Limiter {
+	s.limitersMu.Lock()
+	defer s.limitersMu.Unlock()
+
+	app := s.resetLimiters()
+	limiter, ok := s.limiters[method]
+	if !ok {
+		r, n := utf8.DecodeRuneInString(method)
+		limit := app.Config.RateLimit.ForRoute("/" + string(unicode.ToLower(r)) + method[n:])
+		if limit.Rate > 0 {
+			limiter = ratelimit.New(limit.Rate, limit.Burst)
+		}
+		s.limiters[method] = limiter
+	}
+	return limiter
+}
+
+// addrRateLimit takes a token from the client address's bucket before the
+// call is authenticated, as the JSON API does, so a client can't try keys
+// without limit.
+func (s *Server) addrRateLimit(ctx context.Context) error {
+	s.limitersMu.Lock()
+	s.resetLimiters()
+	limiter := s.addrLimiter
+	s.limitersMu.Unlock()
+
+	if limiter == nil {
+		return nil
+	}
+	if ok, _ := limiter.Allow(peerKey(ctx)); !ok {
+		return errors.RateLimited
+	}
+	return nil
+}
+
+// peerKey is the bucket key of the client address, like the JSON API's.
+func peerKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "addr:"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "addr:" + host
+}
+
+// rateLimit takes a token from the API key's bucket for the method, as the
+// JSON API does for the matching route.
+func (s *Server) rateLimit(ctx context.Context, fullMethod string) error {
+	limiter := s.limiter(methodName(fullMethod))
+	if limiter == nil {
+		return nil
+	}
+	if ok, _ := limiter.Allow("key:" + apiKeyFromContext(ctx).ApiKeyID); !ok {
+		return errors.RateLimited
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Interceptors
+// -----------------------------------------------------------------------------
+
+// call runs a method handler, turning a panic into an unexpected error so
+// the client gets codes.Internal and the server keeps running.
+func call(handler func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = errors.Unexpected.WithErr(fmt.Errorf("%s", p))
+		}
+	}()
+	return handler()
+}
+
+func (s *Server) logCall(ctx context.Context, method string, start time.Time, err error) {
+	level := logger.Info
+	if err != nil {
+		level = logger.Warn
+	}
+	fields := logger.Fields{
+		"method":     method,
+		"durationMs": float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if apiKey := apiKeyFromContext(ctx); apiKey.Name != "" {
+		fields["apiKey"] = apiKey.Name
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	s.app().Logger.Log(level, "rpc", fields)
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	err = s.addrRateLimit(ctx)
+	if err == nil {
+		ctx, err = s.authenticate(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = s.rateLimit(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = call(func() (err error) {
+			resp, err = handler(ctx, req)
+			return err
+		})
+	}
+	if err == nil && methodScopes[methodName(info.FullMethod)] == database.ScopeLexiconWrite {
+		s.app().LexiconChanged()
+	}
+	err = s.toStatus(err)
+	s.logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx := ss.Context()
+	err := s.addrRateLimit(ctx)
+	if err == nil {
+		ctx, err = s.authenticate(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = s.rateLimit(ctx, info.FullMethod)
+	}
+	if err == nil {
+		err = call(func() error {
+			return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+	err = s.toStatus(err)
+	s.logCall(ctx, info.FullMethod, start, err)
+	return err
+}
@@ -0,0 +1,366 @@
+package rpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/timaraxian/alias-gen/pkg/application"
+	"github.com/timaraxian/alias-gen/pkg/database"
+	"github.com/timaraxian/alias-gen/pkg/errors"
+	"github.com/timaraxian/alias-gen/pkg/generator"
+	"github.com/timaraxian/alias-gen/pkg/helpers/logger"
+	pb "github.com/timaraxian/alias-gen/pkg/rpc/aliasgenpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newTestServer() *Server {
+	app := &application.App{Logger: logger.New(ioutil.Discard, logger.Error)}
+	return New(func() *application.App { return app })
+}
+
+// -----------------------------------------------------------------------------
+// Server.toStatus
+// -----------------------------------------------------------------------------
+func TestServer_toStatus(t *testing.T) {
+	t.Parallel()
+	s := newTestServer()
+
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.WordNotFound, codes.NotFound},
+		{errors.PatternDuplicate, codes.AlreadyExists},
+		{errors.AuthRequired, codes.Unauthenticated},
+		{errors.AuthForbidden, codes.PermissionDenied},
+		{errors.QuotaExceeded, codes.ResourceExhausted},
+		{errors.Unexpected, codes.Internal},
+		{context.Canceled, codes.Canceled},
+		{status.Error(codes.Unavailable, "gone"), codes.Unavailable},
+	}
+	for _, c := range cases {
+		if code := status.Code(s.toStatus(c.err)); code != c.code {
+			t.Fatal(c.err, code)
+		}
+	}
+	if s.toStatus(nil) != nil {
+		t.Fatal("nil")
+	}
+}
+
+func TestServer_toStatus_FieldViolations(t *testing.T) {
+	t.Parallel()
+	s := newTestServer()
+
+	err := errors.HttpInvalidArgs.WithFields(errors.FieldError{Field: "word", Code: "Required", Msg: "is required"})
+	st := status.Convert(s.toStatus(err))
+	if st.Code() != codes.InvalidArgument {
+		t.Fatal(st.Code())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatal(details)
+	}
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "word" {
+		t.Fatal(details[0])
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.unaryInterceptor
+// -----------------------------------------------------------------------------
+func TestServer_unaryInterceptor_Unauthenticated(t *testing.T) {
+	t.Parallel()
+	s := newTestServer()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/WordGet"}
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc"))
+	_, err := s.unaryInterceptor(ctx, &pb.WordGetRequest{}, info, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatal(err)
+	}
+	if called {
+		t.Fatal("handler called")
+	}
+}
+
+func TestServer_unaryInterceptor_UnknownMethod(t *testing.T) {
+	t.Parallel()
+	s := newTestServer()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/Nope"}
+	_, err := s.unaryInterceptor(context.Background(), nil, info, nil)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_unaryInterceptor_Panic(t *testing.T) {
+	t.Parallel()
+	s, secret := newTestServerWithKey(t, application.RateLimitConfig{})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/WordGet"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+secret))
+	_, err := s.unaryInterceptor(ctx, &pb.WordGetRequest{}, info, handler)
+	if status.Code(err) != codes.Internal {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_unaryInterceptor_RateLimited(t *testing.T) {
+	t.Parallel()
+	s, secret := newTestServerWithKey(t, application.RateLimitConfig{
+		Routes: map[string]application.RateLimit{"/wordGet": {Rate: 0.001, Burst: 1}},
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+secret))
+	call := func(method string) error {
+		_, err := s.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/" + method}, handler)
+		return err
+	}
+
+	if err := call("WordGet"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call("WordGet"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatal(err)
+	}
+	// Other methods have buckets of their own.
+	if err := call("WordList"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_unaryInterceptor_AddrRateLimited(t *testing.T) {
+	t.Parallel()
+	s, secret := newTestServerWithKey(t, application.RateLimitConfig{
+		Addr: application.RateLimit{Rate: 0.001, Burst: 1},
+	})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/WordGet"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	call := func(ip, auth string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", auth))
+		_, err := s.unaryInterceptor(ctx, nil, info, handler)
+		return err
+	}
+
+	// The address is limited before the key is checked.
+	if err := call("10.0.0.1", "Bearer nope"); status.Code(err) != codes.Unauthenticated {
+		t.Fatal(err)
+	}
+	if err := call("10.0.0.1", "Bearer "+secret); status.Code(err) != codes.ResourceExhausted {
+		t.Fatal(err)
+	}
+	if err := call("10.0.0.2", "Bearer "+secret); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.streamInterceptor
+// -----------------------------------------------------------------------------
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss testServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+func TestServer_streamInterceptor_Panic(t *testing.T) {
+	t.Parallel()
+	s, secret := newTestServerWithKey(t, application.RateLimitConfig{})
+
+	info := &grpc.StreamServerInfo{FullMethod: "/aliasgen.v1.AliasGen/AliasStream"}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+secret))
+	err := s.streamInterceptor(nil, testServerStream{ctx: ctx}, info, handler)
+	if status.Code(err) != codes.Internal {
+		t.Fatal(err)
+	}
+}
+
+// newTestServerWithKey serves from a memory store holding one API key with
+// every scope, and returns the key's secret.
+func newTestServerWithKey(t *testing.T, limits application.RateLimitConfig) (s *Server, secret string) {
+	store := database.NewMemoryStore()
+	app := &application.App{
+		Config: application.Config{RateLimit: limits},
+		Logger: logger.New(ioutil.Discard, logger.Error),
+		Store:  store,
+		Keys:   store,
+	}
+
+	scopes := []string{database.ScopeLexiconRead, database.ScopeLexiconWrite, database.ScopeGenerate}
+	_, secret, err := store.ApiKeyCreate(context.Background(), "rpc", scopes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(func() *application.App { return app }), secret
+}
+
+// -----------------------------------------------------------------------------
+// Server.AliasGenerate
+// -----------------------------------------------------------------------------
+func TestServer_AliasGenerate_Tags(t *testing.T) {
+	t.Parallel()
+	s, secret := newTestServerWithKey(t, application.RateLimitConfig{})
+	app := s.app()
+	app.Generator = generator.New(app.Store)
+
+	ctx := context.Background()
+	if _, err := app.Store.LanguageCreate(ctx, database.Language{Code: "en", Name: "English", Separator: " "}); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{"adjective", "noun"} {
+		if _, err := app.Store.PartCreate(ctx, database.Part{Language: "en", Part: part}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, w := range []struct{ word, part, tag string }{
+		{"Grand", "adjective", "corporate-safe"},
+		{"Cursed", "adjective", "fantasy"},
+		{"Hotel", "noun", "corporate-safe"},
+		{"Dragon", "noun", "animals"},
+	} {
+		word, err := app.Store.WordCreate(ctx, w.word, "en", w.part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := app.Store.WordTag(ctx, word.WordID, w.tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := app.Store.PatternCreate(ctx, "adjective,noun", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aliasgen.v1.AliasGen/AliasGenerate"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.AliasGenerate(ctx, req.(*pb.AliasGenerateRequest))
+	}
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+secret))
+
+	resp, err := s.unaryInterceptor(ctx, &pb.AliasGenerateRequest{
+		Language: "en",
+		Tags:     &pb.TagFilter{IncludeTags: []string{"corporate-safe"}},
+		Slots:    map[int32]*pb.TagFilter{1: {IncludeTags: []string{"animals"}}},
+	}, info, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliases := resp.(*pb.AliasGenerateResponse).Aliases; len(aliases) != 1 || aliases[0].Alias != "Grand Dragon" {
+		t.Fatal(aliases)
+	}
+
+	for _, req := range []*pb.AliasGenerateRequest{
+		{Language: "en", Tags: &pb.TagFilter{IncludeTags: []string{""}}},
+		{Language: "en", Slots: map[int32]*pb.TagFilter{32: {IncludeTags: []string{"animals"}}}},
+		{Language: "en", Theme: "Fantasy"},
+	} {
+		if _, err := s.unaryInterceptor(ctx, req, info, handler); status.Code(err) != codes.InvalidArgument {
+			t.Fatal(req, err)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.WordUpdate
+// -----------------------------------------------------------------------------
+func TestServer_WordUpdate_LanguageAndPart(t *testing.T) {
+	t.Parallel()
+	s, _ := newTestServerWithKey(t, application.RateLimitConfig{})
+	store := s.app().Store
+
+	ctx := context.Background()
+	for _, part := range []database.Part{{Language: "en", Part: "noun"}, {Language: "fr", Part: "nom"}} {
+		if _, err := store.LanguageCreate(ctx, database.Language{Code: part.Language, Name: part.Language, Separator: " "}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.PartCreate(ctx, part); err != nil {
+			t.Fatal(err)
+		}
+	}
+	word, err := store.WordCreate(ctx, "Hotel", "en", "noun")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fr has no "noun" part and en no "nom" part, so this only succeeds
+	// when language and part change together.
+	got, err := s.WordUpdate(ctx, &pb.WordUpdateRequest{WordId: word.WordID, Word: "Hôtel", Language: "fr", Part: "nom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Word != "Hôtel" || got.Language != "fr" || got.Part != "nom" {
+		t.Fatal(got)
+	}
+
+	if _, err := s.WordUpdate(ctx, &pb.WordUpdateRequest{WordId: word.WordID, Word: "Hotel", Language: "en"}); err != errors.PartNotFound {
+		t.Fatal(err)
+	}
+	if got, err := store.WordGet(ctx, word.WordID); err != nil || got.Word != "Hôtel" || got.Language != "fr" {
+		t.Fatal(got, err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Server.PatternUpdate
+// -----------------------------------------------------------------------------
+func TestServer_PatternUpdate_LanguageAndPattern(t *testing.T) {
+	t.Parallel()
+	s, _ := newTestServerWithKey(t, application.RateLimitConfig{})
+	store := s.app().Store
+
+	ctx := context.Background()
+	for _, part := range []database.Part{{Language: "en", Part: "noun"}, {Language: "fr", Part: "nom"}} {
+		if _, err := store.LanguageCreate(ctx, database.Language{Code: part.Language, Name: part.Language, Separator: " "}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.PartCreate(ctx, part); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pattern, err := store.PatternCreate(ctx, "noun", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.PatternUpdate(ctx, &pb.PatternUpdateRequest{PatternId: pattern.PatternID, Pattern: "nom", Language: "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Pattern != "nom" || got.Language != "fr" {
+		t.Fatal(got)
+	}
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+func (app *App) ShowNewApiKey() (form *tview.Form) {
+	if app.NextState != "addApiKey" {
+		panic("Invalid State")
+	}
+
+	app.ApiKey.SetName = ""
+	app.ApiKey.SetScopes = map[string]bool{}
+	app.ApiKey.SetQuota = nil
+
+	form = tview.NewForm().
+		AddInputField("name", "", 20, nil, func(text string) {
+			app.ApiKey.SetName = strings.TrimSpace(text)
+		}).
+		AddInputField("daily quota", "", 10, tview.InputFieldInteger, func(text string) {
+			app.processApiKeyQuota(text)
+		})
+
+	for _, scope := range database.Scopes {
+		scope := scope
+		form.AddCheckbox(scope, false, func(checked bool) {
+			app.ApiKey.SetScopes[scope] = checked
+		})
+	}
+
+	form.
+		AddButton("Mint Key", func() {
+			app.NextState = "submitApiKey"
+			app.Ui.Stop()
+		}).
+		AddButton("Cancel", func() {
+			app.NextState = "listApiKeys"
+			app.Ui.Stop()
+		})
+
+	app.PrevState = "addApiKey"
+	app.Update = true
+	form.SetBorder(true).SetTitle("Mint API Key").SetTitleAlign(tview.AlignLeft)
+
+	return form
+}
+
+func (app *App) processApiKeyQuota(text string) {
+	i, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		app.ApiKey.SetQuota = nil
+		return
+	}
+	app.ApiKey.SetQuota = &i
+}
+
+func (app *App) SubmitNewApiKey() (err error) {
+	if app.NextState != "submitApiKey" {
+		panic("Invalid State")
+	}
+
+	var scopes []string
+	for _, scope := range database.Scopes {
+		if app.ApiKey.SetScopes[scope] {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	apiKey, secret, err := app.Keys.ApiKeyCreate(context.Background(), app.ApiKey.SetName, scopes)
+	if err == nil && app.ApiKey.SetQuota != nil {
+		err = app.Keys.ApiKeySetDailyGenerationQuota(context.Background(), apiKey.ApiKeyID, app.ApiKey.SetQuota)
+	}
+	app.ApiKey.Secret = secret
+	app.PrevState = "submitApiKey"
+	app.NextState = "showApiKeySecret"
+	app.Update = true
+	return err
+}
+
+func (app *App) ShowApiKeySecret() (modal *tview.Modal) {
+	if app.NextState != "showApiKeySecret" {
+		panic("Invalid State")
+	}
+
+	secret := app.ApiKey.Secret
+	app.ApiKey.Secret = ""
+
+	modal = tview.NewModal().
+		SetText("Copy this key now, it won't be shown again:\n\n" + secret).
+		AddButtons([]string{"Done"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			app.NextState = "listApiKeys"
+			app.Ui.Stop()
+		})
+
+	app.PrevState = "showApiKeySecret"
+	app.Update = true
+
+	return modal
+}
+
+func (app *App) ListApiKeys() (table *tview.Table) {
+	if app.NextState != "listApiKeys" {
+		panic("Invalid State")
+	}
+
+	apiKeys, err := app.Keys.ApiKeyList(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	table = tview.NewTable().
+		SetBorders(true)
+
+	cols, rows := 6, len(apiKeys)+1
+
+	// build header
+	header := []string{"ApiKeyID", "Name", "Scopes", "DailyQuota", "CreatedAt", "RevokedAt"}
+
+	for c := 0; c < cols; c++ {
+		table.SetCell(0, c,
+			tview.NewTableCell(header[c]).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter))
+	}
+
+	// build content
+	for r := 1; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			table.SetCell(r, c,
+				tview.NewTableCell(getApiKeyRowValue(apiKeys[r-1], c)).
+					SetTextColor(tcell.ColorWhite).
+					SetAlign(tview.AlignCenter))
+		}
+	}
+
+	// table navigation
+	table.Select(1, 0).SetFixed(1, 0).SetSelectable(true, false).SetSelectedFunc(func(row, col int) {
+		if row < 1 {
+			return
+		}
+		app.ApiKey.GetApiKeyID = table.GetCell(row, 0).Text
+		app.NextState = "viewApiKey"
+		app.Update = true
+		app.Ui.Stop()
+	}).SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyESC {
+			app.NextState = "menu"
+			app.Update = true
+			app.Ui.Stop()
+		}
+		if key == tcell.KeyTAB {
+			app.NextState = "addApiKey"
+			app.Update = true
+			app.Ui.Stop()
+		}
+	})
+
+	app.PrevState = "listApiKeys"
+	app.Update = true
+
+	table.SetBorder(true).SetTitle("API Keys (TAB to mint a key || ESC for menu)").SetTitleAlign(tview.AlignLeft)
+
+	return table
+}
+
+func getApiKeyRowValue(row database.ApiKey, c int) string {
+	switch c {
+	case 0:
+		return row.ApiKeyID
+	case 1:
+		return row.Name
+	case 2:
+		return strings.Join(row.Scopes, ", ")
+	case 3:
+		if row.DailyGenerationQuota != nil {
+			return strconv.Itoa(*row.DailyGenerationQuota)
+		}
+		return "unlimited"
+	case 4:
+		return row.CreatedAt.Format("2006-01-02 15:04:05")
+	case 5:
+		if row.RevokedAt != nil {
+			return row.RevokedAt.Format("2006-01-02 15:04:05")
+		}
+	default:
+		return ""
+	}
+	return ""
+}
+
+func (app *App) ViewApiKey() (list *tview.List) {
+	if app.NextState != "viewApiKey" {
+		panic("Invalid State")
+	}
+
+	apiKey, err := app.Keys.ApiKeyGet(context.Background(), app.ApiKey.GetApiKeyID)
+	if err != nil {
+		panic(err)
+	}
+
+	CreatedAt := apiKey.CreatedAt.Format("2006-01-02 15:04:05")
+	RevokedAt := ""
+	if apiKey.RevokedAt != nil {
+		RevokedAt = apiKey.RevokedAt.Format("2006-01-02 15:04:05")
+	}
+
+	list = tview.NewList().
+		AddItem("ApiKeyID", apiKey.ApiKeyID, 'a', nil).
+		AddItem("Name", apiKey.Name, 'b', nil).
+		AddItem("Scopes", strings.Join(apiKey.Scopes, ", "), 'c', nil).
+		AddItem("CreatedAt", CreatedAt, 'd', nil).
+		AddItem("RevokedAt", RevokedAt, 'e', nil)
+
+	if apiKey.RevokedAt == nil {
+		list.AddItem("Revoke", "Revoke this key immediately", 'r', func() {
+			app.NextState = "submitApiKeyRevoke"
+			app.Ui.Stop()
+		})
+	}
+
+	list.
+		AddItem("Back to list", "", 'f', func() {
+			app.NextState = "listApiKeys"
+			app.Ui.Stop()
+		}).
+		AddItem("Back to menu", "", 'g', func() {
+			app.NextState = "menu"
+			app.Ui.Stop()
+		}).
+		AddItem("Quit", "", 'q', func() {
+			app.NextState = "stop"
+			app.Ui.Stop()
+		})
+
+	app.PrevState = "viewApiKey"
+	app.Update = true
+
+	list.SetBorder(true).SetTitle("View API Key").SetTitleAlign(tview.AlignLeft)
+
+	return list
+}
+
+func (app *App) SubmitApiKeyRevoke() (err error) {
+	if app.NextState != "submitApiKeyRevoke" {
+		panic("Invalid State")
+	}
+
+	err = app.Keys.ApiKeyRevoke(context.Background(), app.ApiKey.GetApiKeyID)
+	app.PrevState = "submitApiKeyRevoke"
+	app.NextState = "viewApiKey"
+	app.Update = true
+	return err
+}
@@ -7,6 +7,7 @@ func (app *App) Loop() (err error) {
 	var list *tview.List
 	var form *tview.Form
 	var table *tview.Table
+	var view *tview.Flex
 	var modal *tview.Modal
 
 	for {
@@ -28,7 +29,7 @@ func (app *App) Loop() (err error) {
 					return err
 				}
 			case "listWords":
-				table = app.ListWords()
+				view, table = app.ListWords()
 			case "viewWordListArgs":
 				form = app.ShowWordListArgs()
 			case "viewWord":
@@ -56,6 +57,8 @@ func (app *App) Loop() (err error) {
 				}
 			case "editWordArchive":
 				form = app.ShowEditWordArchive()
+			case "confirmWordArchive":
+				modal = app.ConfirmWordArchive()
 			case "submitWordArchive":
 				err = app.SubmitWordArchive()
 				if err != nil {
@@ -71,7 +74,7 @@ func (app *App) Loop() (err error) {
 					return err
 				}
 			case "listPatterns":
-				table = app.ListPatterns()
+				view, table = app.ListPatterns()
 			case "viewPatternListArgs":
 				form = app.ShowPatternListArgs()
 			case "viewPattern":
@@ -98,6 +101,26 @@ func (app *App) Loop() (err error) {
 					return err
 				}
 
+				//API keys
+			case "listApiKeys":
+				table = app.ListApiKeys()
+			case "addApiKey":
+				form = app.ShowNewApiKey()
+			case "submitApiKey":
+				err = app.SubmitNewApiKey()
+				if err != nil {
+					return err
+				}
+			case "showApiKeySecret":
+				modal = app.ShowApiKeySecret()
+			case "viewApiKey":
+				list = app.ViewApiKey()
+			case "submitApiKeyRevoke":
+				err = app.SubmitApiKeyRevoke()
+				if err != nil {
+					return err
+				}
+
 				//random
 			case "selectLanguage":
 				form = app.SelectLanguage()
@@ -108,22 +131,27 @@ func (app *App) Loop() (err error) {
 
 		if app.Update {
 			switch app.PrevState {
-			case "menu", "viewWord", "viewPattern":
+			case "menu", "viewWord", "viewPattern", "viewApiKey":
 				err := app.Ui.SetRoot(list, true).SetFocus(list).Run()
 				if err != nil {
 					return err
 				}
-			case "addWord", "editWordWord", "editWordLanguage", "editWordPart", "editWordArchive", "viewWordListArgs", "addPattern", "editPatternPattern", "editPatternLanguage", "editPatternArchive", "viewPatternListArgs", "selectLanguage":
+			case "addWord", "editWordWord", "editWordLanguage", "editWordPart", "editWordArchive", "viewWordListArgs", "addPattern", "editPatternPattern", "editPatternLanguage", "editPatternArchive", "viewPatternListArgs", "selectLanguage", "addApiKey":
 				err := app.Ui.SetRoot(form, true).SetFocus(form).Run()
 				if err != nil {
 					return err
 				}
 			case "listWords", "listPatterns":
+				err := app.Ui.SetRoot(view, true).SetFocus(table).Run()
+				if err != nil {
+					return err
+				}
+			case "listApiKeys":
 				err := app.Ui.SetRoot(table, true).SetFocus(table).Run()
 				if err != nil {
 					return err
 				}
-			case "showRandomAlias", "err":
+			case "showRandomAlias", "showApiKeySecret", "confirmWordArchive", "err":
 				err := app.Ui.SetRoot(modal, true).SetFocus(modal).Run()
 				if err != nil {
 					return err
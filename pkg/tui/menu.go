@@ -27,6 +27,10 @@ func (app *App) ShowMenu() (list *tview.List) {
 			app.NextState = "selectLanguage"
 			app.Ui.Stop()
 		}).
+		AddItem("API keys", "Mint and revoke API keys for the server", 'f', func() {
+			app.NextState = "listApiKeys"
+			app.Ui.Stop()
+		}).
 		AddItem("Quit", "Press to exit", 'q', func() {
 			app.NextState = "stop"
 			app.Ui.Stop()
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -54,7 +56,7 @@ func (app *App) SubmitNewPattern() (err error) {
 		panic("Invalid State")
 	}
 
-	_, err = app.DBAL.PatternCreate(app.Pattern.SetPattern, app.Pattern.SetLanguage)
+	_, err = app.Store.PatternCreate(context.Background(), app.Pattern.SetPattern, app.Pattern.SetLanguage)
 	app.PrevState = "submitPattern"
 	app.NextState = "menu"
 	app.Update = true
@@ -62,28 +64,27 @@ func (app *App) SubmitNewPattern() (err error) {
 
 }
 
-func (app *App) ListPatterns() (table *tview.Table) {
+func (app *App) ListPatterns() (view *tview.Flex, table *tview.Table) {
 	if app.NextState != "listPatterns" {
 		panic("Invalid State")
 	}
 
-	// get patterns from DBAL
-	listArgs := database.PatternListArgs{}
-	listArgs.Limit = &app.PatternListArgs.Limit
-	listArgs.Offset = &app.PatternListArgs.Offset
-	listArgs.OrderByPattern = &app.PatternListArgs.OrderByPattern
-	listArgs.DescPattern = &app.PatternListArgs.DescPattern
-	listArgs.OrderByLanguage = &app.PatternListArgs.OrderByLanguage
-	listArgs.DescLanguage = &app.PatternListArgs.DescLanguage
-	listArgs.OrderByUpdatedAt = &app.PatternListArgs.OrderByUpdatedAt
-	listArgs.DescUpdatedAt = &app.PatternListArgs.DescUpdatedAt
-	listArgs.OrderByCreatedAt = &app.PatternListArgs.OrderByCreatedAt
-	listArgs.DescCreatedAt = &app.PatternListArgs.DescCreatedAt
-	listArgs.ShowArchived = &app.PatternListArgs.ShowArchived
-	patterns, err := app.DBAL.PatternList(listArgs)
+	// get patterns from the store
+	listArgs, err := app.patternListArgs()
 	if err != nil {
 		panic(err)
 	}
+	patterns, page, err := app.Store.PatternList(context.Background(), listArgs)
+	if err != nil {
+		panic(err)
+	}
+	app.PatternListArgs.Page = page
+
+	facets, err := app.Store.PatternFacets(context.Background(), listArgs.Filter)
+	if err != nil {
+		panic(err)
+	}
+	summary := listSummary("patterns", facets, app.PatternListArgs.PageNum, listArgs.Limit)
 
 	table = tview.NewTable().
 		SetBorders(true)
@@ -129,12 +130,36 @@ func (app *App) ListPatterns() (table *tview.Table) {
 		}
 	})
 
+	// page navigation
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		page := app.PatternListArgs.Page
+		cursor, step := "", 0
+		switch {
+		case event.Key() == tcell.KeyRight || event.Rune() == 'n':
+			cursor, step = page.NextCursor, 1
+		case event.Key() == tcell.KeyLeft || event.Rune() == 'p':
+			cursor, step = page.PrevCursor, -1
+		default:
+			return event
+		}
+		if cursor != "" {
+			app.PatternListArgs.Cursor = cursor
+			app.PatternListArgs.PageNum += step
+			// Clear PrevState so the loop rebuilds the table.
+			app.PrevState = ""
+			app.NextState = "listPatterns"
+			app.Update = true
+			app.Ui.Stop()
+		}
+		return nil
+	})
+
 	app.PrevState = "listPatterns"
 	app.Update = true
 
-	table.SetBorder(true).SetTitle("Patterns (TAB for options || ESC for menu)").SetTitleAlign(tview.AlignLeft)
+	table.SetBorder(true).SetTitle("Patterns (n/p for next/prev page || TAB for options || ESC for menu)").SetTitleAlign(tview.AlignLeft)
 
-	return table
+	return listView(summary, table), table
 }
 
 func getPatternRowValue(row database.Pattern, c int) string {
@@ -165,44 +190,23 @@ func (app *App) ShowPatternListArgs() (form *tview.Form) {
 	}
 
 	limit := strconv.Itoa(app.PatternListArgs.Limit)
-	offset := strconv.Itoa(app.PatternListArgs.Offset)
+	args := app.PatternListArgs
 
 	form = tview.NewForm().
 		AddInputField("Limit", limit, 5, nil, func(text string) {
-			app.processPatternListArgsLimit(text)
+			args.Limit, _ = strconv.Atoi(strings.TrimSpace(text))
 		}).
-		AddInputField("Offset", offset, 5, nil, func(text string) {
-			app.processPatternListArgsOffset(text)
+		AddInputField("Order By", args.OrderBy, 40, nil, func(text string) {
+			args.OrderBy = text
 		}).
-		AddCheckbox("Order By Pattern", app.PatternListArgs.OrderByPattern, func(checked bool) {
-			app.PatternListArgs.OrderByPattern = checked
-		}).
-		AddCheckbox("Descending Pattern", app.PatternListArgs.DescPattern, func(checked bool) {
-			app.PatternListArgs.DescPattern = checked
-		}).
-		AddCheckbox("Order By Language", app.PatternListArgs.OrderByLanguage, func(checked bool) {
-			app.PatternListArgs.OrderByLanguage = checked
-		}).
-		AddCheckbox("Descending Language", app.PatternListArgs.DescLanguage, func(checked bool) {
-			app.PatternListArgs.DescLanguage = checked
-		}).
-		AddCheckbox("Order By UpdatedAt", app.PatternListArgs.OrderByUpdatedAt, func(checked bool) {
-			app.PatternListArgs.OrderByUpdatedAt = checked
-		}).
-		AddCheckbox("Descending UpdatedAt", app.PatternListArgs.DescUpdatedAt, func(checked bool) {
-			app.PatternListArgs.DescUpdatedAt = checked
-		}).
-		AddCheckbox("Order By CreatedAt", app.PatternListArgs.OrderByCreatedAt, func(checked bool) {
-			app.PatternListArgs.OrderByCreatedAt = checked
-		}).
-		AddCheckbox("Descending CreatedAt", app.PatternListArgs.DescCreatedAt, func(checked bool) {
-			app.PatternListArgs.DescCreatedAt = checked
-		}).
-		AddCheckbox("Show Archived", app.PatternListArgs.ShowArchived, func(checked bool) {
-			app.PatternListArgs.ShowArchived = checked
+		AddInputField("Filter", args.Filter, 60, nil, func(text string) {
+			args.Filter = text
 		}).
 		AddButton("Back to list", func() {
-			app.updatePatternListArgsLimitOffset()
+			if err := app.updatePatternListArgs(args); err != nil {
+				form.SetTitle("Pattern List Args (" + err.Error() + ")")
+				return
+			}
 			app.NextState = "listPatterns"
 			app.Ui.Stop()
 		})
@@ -214,30 +218,33 @@ func (app *App) ShowPatternListArgs() (form *tview.Form) {
 	return form
 }
 
-func (app *App) processPatternListArgsLimit(text string) {
-	i, err := strconv.Atoi(strings.TrimSpace(text))
-	if err != nil {
-		app.Err = err
-	} else {
-		app.Err = nil
+func (app *App) patternListArgs() (listArgs database.PatternListArgs, err error) {
+	listArgs.Limit = app.PatternListArgs.Limit
+	listArgs.Cursor = app.PatternListArgs.Cursor
+	if listArgs.OrderBy, err = database.ParseOrderBy(app.PatternListArgs.OrderBy); err != nil {
+		return listArgs, err
 	}
-	app.PatternListArgs.Limit = i
+	listArgs.Filter, err = database.ParsePatternFilter(app.PatternListArgs.Filter)
+	return listArgs, err
 }
 
-func (app *App) processPatternListArgsOffset(text string) {
-	i, err := strconv.Atoi(strings.TrimSpace(text))
-	if err != nil {
-		app.Err = err
-	} else {
-		app.Err = nil
+// updatePatternListArgs checks the new args and goes back to the first page, as
+// cursors only work with the ordering they were made for.
+func (app *App) updatePatternListArgs(args PatternListArgs) error {
+	if args.Limit < 0 || args.Limit > database.MaxListLimit {
+		return fmt.Errorf("limit must be between 0 and %d", database.MaxListLimit)
 	}
-	app.PatternListArgs.Offset = i
-}
-
-func (app *App) updatePatternListArgsLimitOffset() {
-	if app.Err != nil {
-		panic(app.Err)
+	args.Cursor = ""
+	args.PageNum = 0
+	args.Page = database.Page{}
+
+	prev := app.PatternListArgs
+	app.PatternListArgs = args
+	if _, err := app.patternListArgs(); err != nil {
+		app.PatternListArgs = prev
+		return err
 	}
+	return nil
 }
 
 func (app *App) ViewPattern() (list *tview.List) {
@@ -245,7 +252,7 @@ func (app *App) ViewPattern() (list *tview.List) {
 		panic("Invalid State")
 	}
 
-	pattern, err := app.DBAL.PatternGet(app.Pattern.GetPatternID)
+	pattern, err := app.Store.PatternGet(context.Background(), app.Pattern.GetPatternID)
 	if err != nil {
 		panic(err)
 	}
@@ -329,7 +336,7 @@ func (app *App) SubmitPatternPattern() (err error) {
 		panic("Invalid State")
 	}
 
-	err = app.DBAL.PatternSetPattern(app.Pattern.GetPatternID, app.Pattern.SetPattern)
+	err = app.Store.PatternSetPattern(context.Background(), app.Pattern.GetPatternID, app.Pattern.SetPattern)
 	app.PrevState = "submitPatternPattern"
 	app.NextState = "viewPattern"
 	app.Update = true
@@ -365,7 +372,7 @@ func (app *App) SubmitPatternLanguage() (err error) {
 		panic("Invalid State")
 	}
 
-	err = app.DBAL.PatternSetLanguage(app.Pattern.GetPatternID, app.Pattern.SetLanguage)
+	err = app.Store.PatternSetLanguage(context.Background(), app.Pattern.GetPatternID, app.Pattern.SetLanguage)
 	app.PrevState = "submitPatternLanguage"
 	app.NextState = "viewPattern"
 	app.Update = true
@@ -407,9 +414,9 @@ func (app *App) SubmitPatternArchive() (err error) {
 	}
 
 	if app.Pattern.Archive {
-		err = app.DBAL.PatternSetArchive(app.Pattern.GetPatternID)
+		err = app.Store.PatternSetArchive(context.Background(), app.Pattern.GetPatternID)
 	} else {
-		err = app.DBAL.PatternSetUnArchive(app.Pattern.GetPatternID)
+		err = app.Store.PatternSetUnArchive(context.Background(), app.Pattern.GetPatternID)
 	}
 
 	app.PrevState = "submitPatternArchive"
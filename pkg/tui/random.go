@@ -1,9 +1,9 @@
 package tui
 
 import (
-	"strings"
-
+	"context"
 	"github.com/rivo/tview"
+	"github.com/timaraxian/alias-gen/pkg/generator"
 )
 
 func (app *App) SelectLanguage() (form *tview.Form) {
@@ -11,7 +11,7 @@ func (app *App) SelectLanguage() (form *tview.Form) {
 		panic("Invalid State")
 	}
 
-	languages, err := app.DBAL.GetDistinctLanguages()
+	languages, err := app.Store.GetDistinctLanguages(context.Background())
 	if err != nil {
 		panic(err)
 	}
@@ -45,31 +45,11 @@ func (app *App) ShowRandomAlias() (modal *tview.Modal) {
 		panic("Invalid State")
 	}
 
-	for {
-		pattern, err := app.DBAL.PatternRandom(app.Random.language)
-		if err != nil {
-			panic(err)
-		}
-		patternSlice := strings.Split(pattern.Pattern, ",")
-		if len(patternSlice) < 1 {
-			panic("No Patterns")
-		}
-
-		for i := 0; i < len(patternSlice); i++ {
-			word, err := app.DBAL.WordRandom(app.Random.language, patternSlice[i])
-			if err != nil {
-				app.Random.empty = true
-				continue
-			}
-			app.Random.alias = append(app.Random.alias, word.Word)
-		}
-		if !app.Random.empty {
-			break
-		}
+	generated, err := generator.New(app.Store).Generate(context.Background(), app.Random.language, generator.Options{})
+	if err != nil {
+		panic(err)
 	}
-
-	alias := strings.Join(app.Random.alias, " ")
-	app.Random.alias = []string{}
+	alias := generated.Alias
 
 	modal = tview.NewModal().
 		SetText("Random alias").
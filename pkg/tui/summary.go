@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/timaraxian/alias-gen/pkg/database"
+)
+
+const maxSummaryFacets = 8
+
+// listSummary is the header above a list table: the page, the total, and the
+// largest facets of the rows matching the filter.
+func listSummary(noun string, facets database.Facets, pageNum, limit int) *tview.TextView {
+	if limit <= 0 {
+		limit = database.DefaultListLimit
+	}
+	pages := (facets.Total + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+
+	lines := []string{fmt.Sprintf("Page %d of %d, %d %s", pageNum+1, pages, facets.Total, noun)}
+	for _, f := range []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"Language", facets.Language},
+		{"Part", facets.Part},
+		{"Archived", facets.Archived},
+	} {
+		if len(f.counts) > 0 {
+			lines = append(lines, f.name+": "+summarizeFacet(f.counts))
+		}
+	}
+
+	return tview.NewTextView().SetText(strings.Join(lines, "\n"))
+}
+
+// summarizeFacet lists the largest counts first.
+func summarizeFacet(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	var parts []string
+	for i, k := range keys {
+		if i == maxSummaryFacets {
+			parts = append(parts, fmt.Sprintf("+%d more", len(keys)-i))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// listView stacks a summary above a table.
+func listView(summary *tview.TextView, table *tview.Table) *tview.Flex {
+	lines := strings.Count(summary.GetText(false), "\n") + 1
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, lines, 0, false).
+		AddItem(table, 0, 1, true)
+}
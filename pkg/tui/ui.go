@@ -8,8 +8,9 @@ import (
 type App struct {
 	Config Config
 
-	Ui   *tview.Application
-	DBAL *database.DBAL
+	Ui    *tview.Application
+	Store database.Store
+	Keys  database.KeyStore
 
 	Err error
 
@@ -19,6 +20,7 @@ type App struct {
 
 	Word    Word
 	Pattern Pattern
+	ApiKey  ApiKey
 
 	WordListArgs    WordListArgs
 	PatternListArgs PatternListArgs
@@ -36,6 +38,7 @@ func NewApp(config Config, services []Service) (a *App, err error) {
 		Update:          false,
 		Word:            Word{},
 		Pattern:         Pattern{},
+		ApiKey:          ApiKey{},
 		WordListArgs:    WordListArgs{},
 		PatternListArgs: PatternListArgs{},
 		Random:          Random{},
@@ -62,6 +65,10 @@ type Word struct {
 	SetLanguage string
 	SetPart     string
 	Archive     bool
+
+	// ArchiveBreaks are the patterns archiving the word would leave without
+	// words for one of their slots.
+	ArchiveBreaks []database.Pattern
 }
 
 type Pattern struct {
@@ -71,39 +78,48 @@ type Pattern struct {
 	Archive      bool
 }
 
+type ApiKey struct {
+	GetApiKeyID string
+	SetName     string
+	SetScopes   map[string]bool
+	SetQuota    *int
+	Secret      string
+}
+
 type WordListArgs struct {
-	Limit            int
-	Offset           int
-	OrderByWord      bool
-	DescWord         bool
-	OrderByLanguage  bool
-	DescLanguage     bool
-	OrderByPart      bool
-	DescPart         bool
-	OrderByUpdatedAt bool
-	DescUpdatedAt    bool
-	OrderByCreatedAt bool
-	DescCreatedAt    bool
-	ShowArchived     bool
+	Limit int
+
+	// OrderBy and Filter are text specs, e.g. "language,-createdAt" and
+	// "language=en,archived=include".
+	OrderBy string
+	Filter  string
+
+	// Cursor is the page being shown, PageNum its number from 0, and Page
+	// the cursors either side of it.
+	Cursor  string
+	PageNum int
+	Page    database.Page
+
+	// Search is the text in the search box. While set, the table shows the
+	// words matching it instead of the page.
+	Search string
 }
 
 type PatternListArgs struct {
-	Limit            int
-	Offset           int
-	OrderByPattern   bool
-	DescPattern      bool
-	OrderByLanguage  bool
-	DescLanguage     bool
-	OrderByUpdatedAt bool
-	DescUpdatedAt    bool
-	OrderByCreatedAt bool
-	DescCreatedAt    bool
-	ShowArchived     bool
+	Limit int
+
+	// OrderBy and Filter are text specs, e.g. "language,-createdAt" and
+	// "language=en,archived=include".
+	OrderBy string
+	Filter  string
+
+	// Cursor is the page being shown, PageNum its number from 0, and Page
+	// the cursors either side of it.
+	Cursor  string
+	PageNum int
+	Page    database.Page
 }
 
 type Random struct {
 	language string
-	pattern  []string
-	alias    []string
-	empty    bool
 }
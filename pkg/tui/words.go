@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -57,7 +59,7 @@ func (app *App) SubmitNewWord() (err error) {
 		panic("Invalid State")
 	}
 
-	_, err = app.DBAL.WordCreate(app.Word.SetWord, app.Word.SetLanguage, app.Word.SetPart)
+	_, err = app.Store.WordCreate(context.Background(), app.Word.SetWord, app.Word.SetLanguage, app.Word.SetPart)
 	app.PrevState = "submitWord"
 	app.NextState = "menu"
 	app.Update = true
@@ -65,59 +67,64 @@ func (app *App) SubmitNewWord() (err error) {
 
 }
 
-func (app *App) ListWords() (table *tview.Table) {
+func (app *App) ListWords() (view *tview.Flex, table *tview.Table) {
 	if app.NextState != "listWords" {
 		panic("Invalid State")
 	}
 
-	// get words from DBAL
-	listArgs := database.WordListArgs{}
-	listArgs.Limit = &app.WordListArgs.Limit
-	listArgs.Offset = &app.WordListArgs.Offset
-	listArgs.OrderByWord = &app.WordListArgs.OrderByWord
-	listArgs.DescWord = &app.WordListArgs.DescWord
-	listArgs.OrderByLanguage = &app.WordListArgs.OrderByLanguage
-	listArgs.DescLanguage = &app.WordListArgs.DescLanguage
-	listArgs.OrderByPart = &app.WordListArgs.OrderByPart
-	listArgs.DescPart = &app.WordListArgs.DescPart
-	listArgs.OrderByUpdatedAt = &app.WordListArgs.OrderByUpdatedAt
-	listArgs.DescUpdatedAt = &app.WordListArgs.DescUpdatedAt
-	listArgs.OrderByCreatedAt = &app.WordListArgs.OrderByCreatedAt
-	listArgs.DescCreatedAt = &app.WordListArgs.DescCreatedAt
-	listArgs.ShowArchived = &app.WordListArgs.ShowArchived
-	words, err := app.DBAL.WordList(listArgs)
+	// get words from the store
+	listArgs, err := app.wordListArgs()
 	if err != nil {
 		panic(err)
 	}
+	words, page, err := app.Store.WordList(context.Background(), listArgs)
+	if err != nil {
+		panic(err)
+	}
+	app.WordListArgs.Page = page
+
+	facets, err := app.Store.WordFacets(context.Background(), listArgs.Filter)
+	if err != nil {
+		panic(err)
+	}
+	summary := listSummary("words", facets, app.WordListArgs.PageNum, listArgs.Limit)
 
 	table = tview.NewTable().
 		SetBorders(true)
+	fillWordTable(table, words)
+
+	// live search, narrowed by the filter's language and part
+	search := tview.NewInputField().SetLabel("Search: ")
+	search.SetChangedFunc(func(text string) {
+		app.WordListArgs.Search = text
+		if strings.TrimSpace(text) == "" {
+			search.SetLabel("Search: ")
+			fillWordTable(table, words)
+			return
+		}
 
-	cols, rows := 7, len(words)+1
-
-	// build header
-	header := []string{"WordID", "Word", "Language", "Part", "CreatedAt", "UpdatedAt", "ArchivedAt"}
-
-	for c := 0; c < cols; c++ {
-		table.SetCell(0, c,
-			tview.NewTableCell(header[c]).
-				SetTextColor(tcell.ColorYellow).
-				SetAlign(tview.AlignCenter))
-	}
-
-	// build content
-	for r := 1; r < rows; r++ {
-		for c := 0; c < cols; c++ {
-			table.SetCell(r, c,
-				tview.NewTableCell(getWordRowValue(words[r-1], c)).
-					SetTextColor(tcell.ColorWhite).
-					SetAlign(tview.AlignCenter))
+		found, err := app.searchWords(text, listArgs.Filter)
+		if err != nil {
+			panic(err)
 		}
+		search.SetLabel(fmt.Sprintf("Search (%d): ", len(found)))
+		fillWordTable(table, found)
+	}).SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyESC {
+			search.SetText("")
+		}
+		app.Ui.SetFocus(table)
+	})
+	if app.WordListArgs.Search != "" {
+		search.SetText(app.WordListArgs.Search)
 	}
 
 	// table navigation
 	table.Select(1, 0).SetFixed(1, 0).SetSelectable(true, false).SetSelectedFunc(func(row, col int) {
-		app.Word.GetWordID = table.GetCell(row, col).Text
+		if row >= table.GetRowCount() {
+			return
+		}
+		app.Word.GetWordID = table.GetCell(row, 0).Text
 		app.NextState = "viewWord"
 		app.Update = true
 		app.Ui.Stop()
@@ -134,12 +141,76 @@ func (app *App) ListWords() (table *tview.Table) {
 		}
 	})
 
+	// search and page navigation
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' {
+			app.Ui.SetFocus(search)
+			return nil
+		}
+		if app.WordListArgs.Search != "" {
+			return event
+		}
+
+		page := app.WordListArgs.Page
+		cursor, step := "", 0
+		switch {
+		case event.Key() == tcell.KeyRight || event.Rune() == 'n':
+			cursor, step = page.NextCursor, 1
+		case event.Key() == tcell.KeyLeft || event.Rune() == 'p':
+			cursor, step = page.PrevCursor, -1
+		default:
+			return event
+		}
+		if cursor != "" {
+			app.WordListArgs.Cursor = cursor
+			app.WordListArgs.PageNum += step
+			// Clear PrevState so the loop rebuilds the table.
+			app.PrevState = ""
+			app.NextState = "listWords"
+			app.Update = true
+			app.Ui.Stop()
+		}
+		return nil
+	})
+
 	app.PrevState = "listWords"
 	app.Update = true
 
-	table.SetBorder(true).SetTitle("Words (TAB for options || ESC for menu)").SetTitleAlign(tview.AlignLeft)
+	table.SetBorder(true).SetTitle("Words (n/p for next/prev page || / to search || TAB for options || ESC for menu)").SetTitleAlign(tview.AlignLeft)
 
-	return table
+	view = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(search, 1, 0, false).
+		AddItem(listView(summary, table), 0, 1, true)
+	return view, table
+}
+
+// fillWordTable replaces the rows of table with words.
+func fillWordTable(table *tview.Table, words []database.Word) {
+	table.Clear()
+
+	cols, rows := 7, len(words)+1
+
+	// build header
+	header := []string{"WordID", "Word", "Language", "Part", "CreatedAt", "UpdatedAt", "ArchivedAt"}
+
+	for c := 0; c < cols; c++ {
+		table.SetCell(0, c,
+			tview.NewTableCell(header[c]).
+				SetTextColor(tcell.ColorYellow).
+				SetAlign(tview.AlignCenter))
+	}
+
+	// build content
+	for r := 1; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			table.SetCell(r, c,
+				tview.NewTableCell(getWordRowValue(words[r-1], c)).
+					SetTextColor(tcell.ColorWhite).
+					SetAlign(tview.AlignCenter))
+		}
+	}
+
+	table.ScrollToBeginning().Select(1, 0)
 }
 
 func getWordRowValue(row database.Word, c int) string {
@@ -172,50 +243,23 @@ func (app *App) ShowWordListArgs() (form *tview.Form) {
 	}
 
 	limit := strconv.Itoa(app.WordListArgs.Limit)
-	offset := strconv.Itoa(app.WordListArgs.Offset)
+	args := app.WordListArgs
 
 	form = tview.NewForm().
 		AddInputField("Limit", limit, 5, nil, func(text string) {
-			app.processWordListArgsLimit(text)
-		}).
-		AddInputField("Offset", offset, 5, nil, func(text string) {
-			app.processWordListArgsOffset(text)
-		}).
-		AddCheckbox("Order By Word", app.WordListArgs.OrderByWord, func(checked bool) {
-			app.WordListArgs.OrderByWord = checked
-		}).
-		AddCheckbox("Descending Word", app.WordListArgs.DescWord, func(checked bool) {
-			app.WordListArgs.DescWord = checked
-		}).
-		AddCheckbox("Order By Language", app.WordListArgs.OrderByLanguage, func(checked bool) {
-			app.WordListArgs.OrderByLanguage = checked
-		}).
-		AddCheckbox("Descending Language", app.WordListArgs.DescLanguage, func(checked bool) {
-			app.WordListArgs.DescLanguage = checked
-		}).
-		AddCheckbox("Order By Part", app.WordListArgs.OrderByPart, func(checked bool) {
-			app.WordListArgs.OrderByPart = checked
+			args.Limit, _ = strconv.Atoi(strings.TrimSpace(text))
 		}).
-		AddCheckbox("Descending Part", app.WordListArgs.DescPart, func(checked bool) {
-			app.WordListArgs.DescPart = checked
+		AddInputField("Order By", args.OrderBy, 40, nil, func(text string) {
+			args.OrderBy = text
 		}).
-		AddCheckbox("Order By UpdatedAt", app.WordListArgs.OrderByUpdatedAt, func(checked bool) {
-			app.WordListArgs.OrderByUpdatedAt = checked
-		}).
-		AddCheckbox("Descending UpdatedAt", app.WordListArgs.DescUpdatedAt, func(checked bool) {
-			app.WordListArgs.DescUpdatedAt = checked
-		}).
-		AddCheckbox("Order By CreatedAt", app.WordListArgs.OrderByCreatedAt, func(checked bool) {
-			app.WordListArgs.OrderByCreatedAt = checked
-		}).
-		AddCheckbox("Descending CreatedAt", app.WordListArgs.DescCreatedAt, func(checked bool) {
-			app.WordListArgs.DescCreatedAt = checked
-		}).
-		AddCheckbox("Show Archived", app.WordListArgs.ShowArchived, func(checked bool) {
-			app.WordListArgs.ShowArchived = checked
+		AddInputField("Filter", args.Filter, 60, nil, func(text string) {
+			args.Filter = text
 		}).
 		AddButton("Back to list", func() {
-			app.updateWordListArgsLimitOffset()
+			if err := app.updateWordListArgs(args); err != nil {
+				form.SetTitle("Word List Args (" + err.Error() + ")")
+				return
+			}
 			app.NextState = "listWords"
 			app.Ui.Stop()
 		})
@@ -227,30 +271,33 @@ func (app *App) ShowWordListArgs() (form *tview.Form) {
 	return form
 }
 
-func (app *App) processWordListArgsLimit(text string) {
-	i, err := strconv.Atoi(strings.TrimSpace(text))
-	if err != nil {
-		app.Err = err
-	} else {
-		app.Err = nil
+func (app *App) wordListArgs() (listArgs database.WordListArgs, err error) {
+	listArgs.Limit = app.WordListArgs.Limit
+	listArgs.Cursor = app.WordListArgs.Cursor
+	if listArgs.OrderBy, err = database.ParseOrderBy(app.WordListArgs.OrderBy); err != nil {
+		return listArgs, err
 	}
-	app.WordListArgs.Limit = i
+	listArgs.Filter, err = database.ParseWordFilter(app.WordListArgs.Filter)
+	return listArgs, err
 }
 
-func (app *App) processWordListArgsOffset(text string) {
-	i, err := strconv.Atoi(strings.TrimSpace(text))
-	if err != nil {
-		app.Err = err
-	} else {
-		app.Err = nil
+// updateWordListArgs checks the new args and goes back to the first page, as
+// cursors only work with the ordering they were made for.
+func (app *App) updateWordListArgs(args WordListArgs) error {
+	if args.Limit < 0 || args.Limit > database.MaxListLimit {
+		return fmt.Errorf("limit must be between 0 and %d", database.MaxListLimit)
 	}
-	app.WordListArgs.Offset = i
-}
-
-func (app *App) updateWordListArgsLimitOffset() {
-	if app.Err != nil {
-		panic(app.Err)
+	args.Cursor = ""
+	args.PageNum = 0
+	args.Page = database.Page{}
+
+	prev := app.WordListArgs
+	app.WordListArgs = args
+	if _, err := app.wordListArgs(); err != nil {
+		app.WordListArgs = prev
+		return err
 	}
+	return nil
 }
 
 func (app *App) ViewWord() (list *tview.List) {
@@ -258,7 +305,7 @@ func (app *App) ViewWord() (list *tview.List) {
 		panic("Invalid State")
 	}
 
-	word, err := app.DBAL.WordGet(app.Word.GetWordID)
+	word, err := app.Store.WordGet(context.Background(), app.Word.GetWordID)
 	if err != nil {
 		panic(err)
 	}
@@ -347,7 +394,7 @@ func (app *App) SubmitWordWord() (err error) {
 		panic("Invalid State")
 	}
 
-	err = app.DBAL.WordSetWord(app.Word.GetWordID, app.Word.SetWord)
+	err = app.Store.WordSetWord(context.Background(), app.Word.GetWordID, app.Word.SetWord)
 	app.PrevState = "submitWordWord"
 	app.NextState = "viewWord"
 	app.Update = true
@@ -383,7 +430,7 @@ func (app *App) SubmitWordLanguage() (err error) {
 		panic("Invalid State")
 	}
 
-	err = app.DBAL.WordSetLanguage(app.Word.GetWordID, app.Word.SetLanguage)
+	err = app.Store.WordSetLanguage(context.Background(), app.Word.GetWordID, app.Word.SetLanguage)
 	app.PrevState = "submitWordLanguage"
 	app.NextState = "viewWord"
 	app.Update = true
@@ -419,7 +466,7 @@ func (app *App) SubmitWordPart() (err error) {
 		panic("Invalid State")
 	}
 
-	err = app.DBAL.WordSetPart(app.Word.GetWordID, app.Word.SetPart)
+	err = app.Store.WordSetPart(context.Background(), app.Word.GetWordID, app.Word.SetPart)
 	app.PrevState = "submitWordPart"
 	app.NextState = "viewWord"
 	app.Update = true
@@ -431,12 +478,23 @@ func (app *App) ShowEditWordArchive() (form *tview.Form) {
 		panic("Invalid State")
 	}
 
+	archived := app.Word.Archive
 	form = tview.NewForm().
 		AddCheckbox("archived", app.Word.Archive, func(checked bool) {
 			app.processWordArchived(checked)
 		}).
 		AddButton("Edit Archive", func() {
 			app.NextState = "submitWordArchive"
+			if app.Word.Archive && !archived {
+				breaks, err := app.Store.WordArchiveBreaks(context.Background(), app.Word.GetWordID)
+				if err != nil {
+					panic(err)
+				}
+				if len(breaks) > 0 {
+					app.Word.ArchiveBreaks = breaks
+					app.NextState = "confirmWordArchive"
+				}
+			}
 			app.Ui.Stop()
 		}).
 		AddButton("Cancel", func() {
@@ -451,6 +509,38 @@ func (app *App) ShowEditWordArchive() (form *tview.Form) {
 	return form
 }
 
+// ConfirmWordArchive warns that archiving the last word of a part breaks the
+// patterns using it.
+func (app *App) ConfirmWordArchive() (modal *tview.Modal) {
+	if app.NextState != "confirmWordArchive" {
+		panic("Invalid State")
+	}
+
+	patterns := make([]string, len(app.Word.ArchiveBreaks))
+	for i, pattern := range app.Word.ArchiveBreaks {
+		patterns[i] = pattern.Pattern
+	}
+	app.Word.ArchiveBreaks = nil
+
+	modal = tview.NewModal().
+		SetText(fmt.Sprintf("%q is the last %s word in %s. These patterns will have no word for it:\n\n%s\n\nArchive anyway?",
+			app.Word.SetWord, app.Word.SetPart, app.Word.SetLanguage, strings.Join(patterns, "\n"))).
+		AddButtons([]string{"Archive", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			if buttonLabel == "Archive" {
+				app.NextState = "submitWordArchive"
+			} else {
+				app.NextState = "viewWord"
+			}
+			app.Ui.Stop()
+		})
+
+	app.PrevState = "confirmWordArchive"
+	app.Update = true
+
+	return modal
+}
+
 func (app *App) processWordArchived(checked bool) {
 	app.Word.Archive = checked
 }
@@ -461,9 +551,9 @@ func (app *App) SubmitWordArchive() (err error) {
 	}
 
 	if app.Word.Archive {
-		err = app.DBAL.WordSetArchive(app.Word.GetWordID)
+		err = app.Store.WordSetArchive(context.Background(), app.Word.GetWordID)
 	} else {
-		err = app.DBAL.WordSetUnArchive(app.Word.GetWordID)
+		err = app.Store.WordSetUnArchive(context.Background(), app.Word.GetWordID)
 	}
 
 	app.PrevState = "submitWordArchive"
@@ -471,3 +561,42 @@ func (app *App) SubmitWordArchive() (err error) {
 	app.Update = true
 	return err
 }
+
+// searchWords finds the words spelled like text within the filter's language
+// and part. Stores that can't search list the words containing text instead.
+func (app *App) searchWords(text string, filter database.WordFilter) (words []database.Word, err error) {
+	showArchived := filter.Archived == database.ArchivedInclude || filter.Archived == database.ArchivedOnly
+
+	searcher, ok := app.Store.(database.Searcher)
+	if !ok {
+		archived := database.ArchivedExclude
+		if showArchived {
+			archived = database.ArchivedInclude
+		}
+		words, _, err = app.Store.WordList(context.Background(), database.WordListArgs{
+			Filter: database.WordFilter{
+				Language:     filter.Language,
+				Part:         filter.Part,
+				WordContains: text,
+				Archived:     archived,
+			},
+			OrderBy: []database.OrderBy{{Key: "word"}},
+			Limit:   database.MaxSearchLimit,
+		})
+		return words, err
+	}
+
+	matches, err := searcher.WordSearch(context.Background(), database.WordSearchArgs{
+		Query:        text,
+		Language:     filter.Language,
+		Part:         filter.Part,
+		ShowArchived: showArchived,
+	})
+	if err != nil {
+		return words, err
+	}
+	for _, match := range matches {
+		words = append(words, match.Word)
+	}
+	return words, nil
+}